@@ -8,7 +8,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	"go.uber.org/multierr"
 	v1 "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/component/arm/v1"
 
@@ -163,6 +165,11 @@ func CreateStatus(ctx context.Context, a Arm) (*pb.Status, error) {
 
 // Move is a helper function to abstract away movement for general arms.
 func Move(ctx context.Context, logger logging.Logger, a Arm, dst spatialmath.Pose) error {
+	return MoveWithOptions(ctx, logger, a, dst, nil)
+}
+
+// MoveWithOptions is like Move, but passes the given options through to the motion planner.
+func MoveWithOptions(ctx context.Context, logger logging.Logger, a Arm, dst spatialmath.Pose, planningOpts map[string]interface{}) error {
 	joints, err := a.JointPositions(ctx, nil)
 	if err != nil {
 		return err
@@ -176,25 +183,78 @@ func Move(ctx context.Context, logger logging.Logger, a Arm, dst spatialmath.Pos
 		return err
 	}
 
-	solution, err := Plan(ctx, logger, a, dst)
-	if err != nil {
+	solution, err := PlanWithOptions(ctx, logger, a, dst, planningOpts)
+	if err != nil && !errors.Is(err, motionplan.ErrPlanSuboptimal) {
 		return err
 	}
 	return GoToWaypoints(ctx, a, solution)
 }
 
+// RelativeFrame selects the axes that the delta of a relative move is expressed in.
+type RelativeFrame string
+
+const (
+	// RelativeToBase expresses the delta in the arm's base frame, the frame that EndPosition is reported in. The translation is
+	// added to the current end position, and the rotation is applied about the end effector using the base frame's axes.
+	RelativeToBase RelativeFrame = "base"
+	// RelativeToTool expresses the delta in the frame of the end effector, so that the translation moves along the tool's own axes.
+	RelativeToTool RelativeFrame = "tool"
+)
+
+// MoveRelative moves the end effector of the arm by delta from its current end position, which is useful for jogging the arm.
+// The delta is interpreted in the frame selected by relativeTo.
+func MoveRelative(ctx context.Context, logger logging.Logger, a Arm, delta spatialmath.Pose, relativeTo RelativeFrame) error {
+	current, err := a.EndPosition(ctx, nil)
+	if err != nil {
+		return err
+	}
+	dst, err := relativeGoal(current, delta, relativeTo)
+	if err != nil {
+		return err
+	}
+	return Move(ctx, logger, a, dst)
+}
+
+// relativeGoal composes delta onto current in the frame selected by relativeTo.
+func relativeGoal(current, delta spatialmath.Pose, relativeTo RelativeFrame) (spatialmath.Pose, error) {
+	switch relativeTo {
+	case RelativeToBase:
+		rotation := spatialmath.Compose(
+			spatialmath.NewPoseFromOrientation(delta.Orientation()),
+			spatialmath.NewPoseFromOrientation(current.Orientation()),
+		)
+		return spatialmath.NewPose(current.Point().Add(delta.Point()), rotation.Orientation()), nil
+	case RelativeToTool:
+		return spatialmath.Compose(current, delta), nil
+	default:
+		return nil, fmt.Errorf("unknown relative frame %q, must be one of %q or %q", relativeTo, RelativeToBase, RelativeToTool)
+	}
+}
+
 // Plan is a helper function to be called by arm implementations to abstract away the default procedure for using the
 // motion planning library with arms.
 func Plan(ctx context.Context, logger logging.Logger, a Arm, dst spatialmath.Pose) ([][]referenceframe.Input, error) {
+	return PlanWithOptions(ctx, logger, a, dst, nil)
+}
+
+// PlanWithOptions is like Plan, but passes the given options through to the motion planner.
+func PlanWithOptions(
+	ctx context.Context,
+	logger logging.Logger,
+	a Arm,
+	dst spatialmath.Pose,
+	planningOpts map[string]interface{},
+) ([][]referenceframe.Input, error) {
 	model := a.ModelFrame()
 	jp, err := a.JointPositions(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	return motionplan.PlanFrameMotion(ctx, logger, dst, model, model.InputFromProtobuf(jp), defaultArmPlannerOptions, nil)
+	return motionplan.PlanFrameMotion(ctx, logger, dst, model, model.InputFromProtobuf(jp), defaultArmPlannerOptions, planningOpts)
 }
 
-// GoToWaypoints will visit in turn each of the joint position waypoints generated by a motion planner.
+// GoToWaypoints will visit in turn each of the joint position waypoints generated by a motion planner. Use GoToWaypointsWithStop
+// to stop the arm if the motion is interrupted.
 func GoToWaypoints(ctx context.Context, a Arm, waypoints [][]referenceframe.Input) error {
 	for _, waypoint := range waypoints {
 		err := ctx.Err() // make sure we haven't been cancelled
@@ -210,6 +270,72 @@ func GoToWaypoints(ctx context.Context, a Arm, waypoints [][]referenceframe.Inpu
 	return nil
 }
 
+// stopTimeout bounds how long stopping an arm after an interrupted motion may take.
+const stopTimeout = 5 * time.Second
+
+// GoToWaypointsWithStop visits each of the waypoints in turn like GoToWaypoints, but stops the arm if ctx is cancelled or moving to
+// a waypoint fails, so that an interrupted motion leaves the arm at rest. If holdPosition is set, the arm is then also commanded to
+// the inputs it stopped at. It returns the index of the last waypoint that was reached, which is -1 if none was.
+func GoToWaypointsWithStop(ctx context.Context, a Arm, waypoints [][]referenceframe.Input, holdPosition bool) (int, error) {
+	for i, waypoint := range waypoints {
+		err := ctx.Err()
+		if err == nil {
+			err = a.GoToInputs(ctx, waypoint)
+		}
+		if err != nil {
+			return i - 1, multierr.Combine(err, stopInterrupted(a, holdPosition))
+		}
+	}
+	return len(waypoints) - 1, nil
+}
+
+// stopInterrupted stops the arm after an interrupted motion, and holds it where it stopped if holdPosition is set. A fresh context
+// is used since that of the motion may have been cancelled.
+func stopInterrupted(a Arm, holdPosition bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), stopTimeout)
+	defer cancel()
+	if err := a.Stop(ctx, nil); err != nil {
+		return fmt.Errorf("cannot stop arm: %w", err)
+	}
+	if !holdPosition {
+		return nil
+	}
+	current, err := a.CurrentInputs(ctx)
+	if err == nil {
+		err = a.GoToInputs(ctx, current)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot hold arm position: %w", err)
+	}
+	return nil
+}
+
+// MoveToJointPositions moves the arm to the target joint positions by linearly interpolating from its current inputs, visiting
+// `steps` evenly spaced waypoints in turn, the last of which is the target.
+func MoveToJointPositions(ctx context.Context, a Arm, target []referenceframe.Input, steps int) error {
+	model := a.ModelFrame()
+	if len(target) != len(model.DoF()) {
+		return referenceframe.NewIncorrectInputLengthError(len(target), len(model.DoF()))
+	}
+	if steps < 1 {
+		return fmt.Errorf("steps must be at least 1, got %d", steps)
+	}
+	current, err := a.CurrentInputs(ctx)
+	if err != nil {
+		return err
+	}
+	waypoints := make([][]referenceframe.Input, 0, steps)
+	for i := 1; i < steps; i++ {
+		waypoint, err := model.Interpolate(current, target, float64(i)/float64(steps))
+		if err != nil {
+			return err
+		}
+		waypoints = append(waypoints, waypoint)
+	}
+	waypoints = append(waypoints, target)
+	return GoToWaypoints(ctx, a, waypoints)
+}
+
 // CheckDesiredJointPositions validates that the desired joint positions either bring the joint back
 // in bounds or do not move the joint more out of bounds.
 func CheckDesiredJointPositions(ctx context.Context, a Arm, desiredInputs []referenceframe.Input) error {
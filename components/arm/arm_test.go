@@ -3,6 +3,7 @@ package arm_test
 import (
 	"context"
 	"errors"
+	"math"
 	"strings"
 	"testing"
 
@@ -315,6 +316,258 @@ func TestOOBArm(t *testing.T) {
 	})
 }
 
+func TestMoveToJointPositions(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cfg := resource.Config{
+		Name:                arm.API.String(),
+		Model:               resource.DefaultModelFamily.WithModel("ur5e"),
+		ConvertedAttributes: &fake.Config{ArmModel: "ur5e"},
+	}
+	notReal, err := fake.NewArm(context.Background(), nil, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	steps := [][]referenceframe.Input{}
+	injectedArm := &inject.Arm{Arm: notReal}
+	injectedArm.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+		return referenceframe.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0}), nil
+	}
+	injectedArm.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+		steps = append(steps, inputSteps...)
+		return nil
+	}
+
+	target := referenceframe.FloatsToInputs([]float64{1, -1, 0.5, 0, 0, 0})
+	test.That(t, arm.MoveToJointPositions(context.Background(), injectedArm, target, 4), test.ShouldBeNil)
+	test.That(t, len(steps), test.ShouldEqual, 4)
+	test.That(t, steps[1], test.ShouldResemble, referenceframe.FloatsToInputs([]float64{0.5, -0.5, 0.25, 0, 0, 0}))
+	test.That(t, steps[3], test.ShouldResemble, target)
+
+	err = arm.MoveToJointPositions(context.Background(), injectedArm, target[:5], 4)
+	test.That(t, err, test.ShouldBeError, referenceframe.NewIncorrectInputLengthError(5, 6))
+	err = arm.MoveToJointPositions(context.Background(), injectedArm, target, 0)
+	test.That(t, err, test.ShouldBeError, errors.New("steps must be at least 1, got 0"))
+}
+
+func TestGoToWaypointsWithStop(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cfg := resource.Config{
+		Name:                arm.API.String(),
+		Model:               resource.DefaultModelFamily.WithModel("ur5e"),
+		ConvertedAttributes: &fake.Config{ArmModel: "ur5e"},
+	}
+	notReal, err := fake.NewArm(context.Background(), nil, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	waypoints := [][]referenceframe.Input{
+		referenceframe.FloatsToInputs([]float64{0.1, 0, 0, 0, 0, 0}),
+		referenceframe.FloatsToInputs([]float64{0.2, 0, 0, 0, 0, 0}),
+		referenceframe.FloatsToInputs([]float64{0.3, 0, 0, 0, 0, 0}),
+	}
+	current := referenceframe.FloatsToInputs([]float64{0.15, 0, 0, 0, 0, 0})
+	moveErr := errors.New("cannot move")
+	var steps [][]referenceframe.Input
+	var stops int
+	injectedArm := &inject.Arm{Arm: notReal}
+	injectedArm.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+		return current, nil
+	}
+	injectedArm.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		stops++
+		return nil
+	}
+	// moving to the second waypoint fails
+	injectedArm.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+		steps = append(steps, inputSteps...)
+		if inputSteps[0][0].Value == 0.2 {
+			return moveErr
+		}
+		return nil
+	}
+
+	reached, err := arm.GoToWaypointsWithStop(context.Background(), injectedArm, waypoints, false)
+	test.That(t, err, test.ShouldBeError, moveErr)
+	test.That(t, reached, test.ShouldEqual, 0)
+	test.That(t, stops, test.ShouldEqual, 1)
+	test.That(t, steps, test.ShouldResemble, waypoints[:2])
+
+	// holding position sends the arm to where it stopped
+	steps, stops = nil, 0
+	reached, err = arm.GoToWaypointsWithStop(context.Background(), injectedArm, waypoints, true)
+	test.That(t, err, test.ShouldBeError, moveErr)
+	test.That(t, reached, test.ShouldEqual, 0)
+	test.That(t, stops, test.ShouldEqual, 1)
+	test.That(t, steps, test.ShouldResemble, [][]referenceframe.Input{waypoints[0], waypoints[1], current})
+
+	// a cancelled context stops the arm before the next waypoint
+	steps, stops = nil, 0
+	ctx, cancel := context.WithCancel(context.Background())
+	injectedArm.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+		steps = append(steps, inputSteps...)
+		cancel()
+		return nil
+	}
+	reached, err = arm.GoToWaypointsWithStop(ctx, injectedArm, waypoints, false)
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+	test.That(t, reached, test.ShouldEqual, 0)
+	test.That(t, stops, test.ShouldEqual, 1)
+	test.That(t, steps, test.ShouldResemble, waypoints[:1])
+
+	// a failure to stop is reported along with the original error
+	injectedArm.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		return errors.New("stuck")
+	}
+	_, err = arm.GoToWaypointsWithStop(ctx, injectedArm, waypoints, false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "context canceled")
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot stop arm: stuck")
+
+	steps = nil
+	reached, err = arm.GoToWaypointsWithStop(context.Background(), injectedArm, waypoints, false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reached, test.ShouldEqual, 2)
+	test.That(t, steps, test.ShouldResemble, waypoints)
+}
+
+func TestMoveRelative(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	cfg := resource.Config{
+		Name:                arm.API.String(),
+		Model:               resource.DefaultModelFamily.WithModel("ur5e"),
+		ConvertedAttributes: &fake.Config{ArmModel: "ur5e"},
+	}
+	notReal, err := fake.NewArm(context.Background(), nil, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	start := referenceframe.FloatsToInputs([]float64{0.5, -1.2, 1.6, -1.9, -1.57, 0})
+	test.That(t, notReal.GoToInputs(context.Background(), start), test.ShouldBeNil)
+	delta := spatialmath.NewPoseFromPoint(r3.Vector{X: 20, Y: -10, Z: 30})
+
+	for _, tc := range []struct {
+		relativeTo arm.RelativeFrame
+		expected   func(spatialmath.Pose) spatialmath.Pose
+	}{
+		{arm.RelativeToTool, func(current spatialmath.Pose) spatialmath.Pose { return spatialmath.Compose(current, delta) }},
+		{arm.RelativeToBase, func(current spatialmath.Pose) spatialmath.Pose {
+			return spatialmath.NewPose(current.Point().Add(delta.Point()), current.Orientation())
+		}},
+	} {
+		t.Run(string(tc.relativeTo), func(t *testing.T) {
+			current, err := notReal.EndPosition(context.Background(), nil)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, arm.MoveRelative(context.Background(), logger, notReal, delta, tc.relativeTo), test.ShouldBeNil)
+			end, err := notReal.EndPosition(context.Background(), nil)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, spatialmath.PoseAlmostCoincidentEps(end, tc.expected(current), 1), test.ShouldBeTrue)
+		})
+	}
+
+	err = arm.MoveRelative(context.Background(), logger, notReal, delta, "elbow")
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown relative frame "elbow", must be one of "base" or "tool"`))
+}
+
+func TestMotionProfile(t *testing.T) {
+	profile := arm.MotionProfile{
+		MaxVelocity:     []float64{1, 1, 1, 1, 1, 1},
+		MaxAcceleration: []float64{1, 1, 1, 1, 1, 1},
+	}
+	start := referenceframe.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0})
+
+	t.Run("TimeParameterize", func(t *testing.T) {
+		// long enough to reach max velocity, so 1s accelerating, 1s cruising, and 1s decelerating
+		trapezoid := referenceframe.FloatsToInputs([]float64{2, 1, 0, 0, 0, 0})
+		// too short to reach max velocity
+		triangle := referenceframe.FloatsToInputs([]float64{2, 1, 0, 0, 0, 0.5})
+		durations, err := arm.TimeParameterize([][]referenceframe.Input{start, trapezoid, trapezoid, triangle}, profile)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(durations), test.ShouldEqual, 3)
+		test.That(t, durations[0].Seconds(), test.ShouldAlmostEqual, 3)
+		test.That(t, durations[1], test.ShouldEqual, 0)
+		test.That(t, durations[2].Seconds(), test.ShouldAlmostEqual, 2*math.Sqrt(0.5), 1e-6)
+
+		_, err = arm.TimeParameterize([][]referenceframe.Input{start, trapezoid[:5]}, profile)
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = arm.TimeParameterize([][]referenceframe.Input{start[:5], trapezoid[:5]}, profile)
+		test.That(t, err.Error(), test.ShouldEqual, "motion profile has 6 max velocities, but arm has 5 joints")
+		badProfile := arm.MotionProfile{MaxVelocity: profile.MaxVelocity, MaxAcceleration: []float64{1, 1, 0, 1, 1, 1}}
+		_, err = arm.TimeParameterize([][]referenceframe.Input{start, trapezoid}, badProfile)
+		test.That(t, err.Error(), test.ShouldEqual, "max acceleration of joint 2 must be positive, got 0")
+	})
+
+	t.Run("GoToWaypointsWithProfile", func(t *testing.T) {
+		logger := logging.NewTestLogger(t)
+		cfg := resource.Config{
+			Name:                arm.API.String(),
+			Model:               resource.DefaultModelFamily.WithModel("ur5e"),
+			ConvertedAttributes: &fake.Config{ArmModel: "ur5e"},
+		}
+		notReal, err := fake.NewArm(context.Background(), nil, cfg, logger)
+		test.That(t, err, test.ShouldBeNil)
+
+		steps := [][]referenceframe.Input{}
+		injectedArm := &inject.Arm{Arm: notReal}
+		injectedArm.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+			return start, nil
+		}
+		injectedArm.GoToInputsFunc = func(ctx context.Context, inputSteps ...[]referenceframe.Input) error {
+			steps = append(steps, inputSteps...)
+			return nil
+		}
+
+		fastProfile := &arm.MotionProfile{
+			MaxVelocity:     []float64{2, 2, 2, 2, 2, 2},
+			MaxAcceleration: []float64{20, 20, 20, 20, 20, 20},
+		}
+		goal := referenceframe.FloatsToInputs([]float64{0.5, -0.25, 0, 0, 0, 0})
+		err = arm.GoToWaypointsWithProfile(context.Background(), injectedArm, [][]referenceframe.Input{goal}, fastProfile)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(steps), test.ShouldBeGreaterThan, 2)
+		test.That(t, steps[len(steps)-1], test.ShouldResemble, goal)
+		prev := start
+		for _, step := range steps {
+			// inputs are streamed every 50ms, so no joint should move further than max velocity allows in that time
+			for i := range step {
+				test.That(t, math.Abs(step[i].Value-prev[i].Value), test.ShouldBeLessThanOrEqualTo, 2*0.05+1e-6)
+			}
+			prev = step
+		}
+
+		err = arm.GoToWaypointsWithProfile(context.Background(), injectedArm, [][]referenceframe.Input{goal[:5]}, fastProfile)
+		test.That(t, err, test.ShouldNotBeNil)
+
+		// with no profile given, the limits of the arm's model are used, so it must have some
+		err = arm.GoToWaypointsWithProfile(context.Background(), injectedArm, [][]referenceframe.Input{goal}, nil)
+		test.That(t, err, test.ShouldBeError, errors.New(
+			"no motion profile was given, and the arm's model has no joint velocity and acceleration limits",
+		))
+
+		limited, err := referenceframe.UnmarshalModelJSON([]byte(`{
+			"name": "limited",
+			"links": [{"id": "tip", "parent": "waist", "translation": {"x": 100, "y": 0, "z": 0}}],
+			"joints": [{
+				"id": "waist", "type": "revolute", "parent": "world", "axis": {"x": 0, "y": 0, "z": 1}, "min": -180, "max": 180,
+				"max_velocity": 90, "max_acceleration": 900
+			}]
+		}`), "")
+		test.That(t, err, test.ShouldBeNil)
+		injectedArm.ModelFrameFunc = func() referenceframe.Model {
+			return limited
+		}
+		injectedArm.CurrentInputsFunc = func(ctx context.Context) ([]referenceframe.Input, error) {
+			return []referenceframe.Input{{0}}, nil
+		}
+		steps = nil
+		goal = []referenceframe.Input{{math.Pi / 4}}
+		err = arm.GoToWaypointsWithProfile(context.Background(), injectedArm, [][]referenceframe.Input{goal}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(steps), test.ShouldBeGreaterThan, 2)
+		test.That(t, steps[len(steps)-1], test.ShouldResemble, goal)
+		prev = []referenceframe.Input{{0}}
+		for _, step := range steps {
+			test.That(t, math.Abs(step[0].Value-prev[0].Value), test.ShouldBeLessThanOrEqualTo, math.Pi/2*0.05+1e-6)
+			prev = step
+		}
+	})
+}
+
 func TestXArm6Locations(t *testing.T) {
 	// check the exact values/locations of arm geometries at a couple different poses
 	logger := logging.NewTestLogger(t)
@@ -142,6 +142,11 @@ func (a *Arm) ModelFrame() referenceframe.Model {
 	return a.model
 }
 
+// Joints returns the name and limits of each joint of the model used to plan for this arm.
+func (a *Arm) Joints() []referenceframe.Joint {
+	return referenceframe.Joints(a.ModelFrame())
+}
+
 // EndPosition returns the set position.
 func (a *Arm) EndPosition(ctx context.Context, extra map[string]interface{}) (spatialmath.Pose, error) {
 	joints, err := a.JointPositions(ctx, extra)
@@ -101,3 +101,20 @@ func TestReconfigure(t *testing.T) {
 	test.That(t, fakeArm.joints.Values, test.ShouldResemble, modelJoints)
 	test.That(t, fakeArm.model, test.ShouldResemble, model)
 }
+
+func TestJoints(t *testing.T) {
+	cfg := resource.Config{
+		Name:                "testArm",
+		ConvertedAttributes: &Config{ArmModel: "xArm6"},
+	}
+	a, err := NewArm(context.Background(), nil, cfg, logging.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	fakeArm := a.(*Arm)
+
+	joints := fakeArm.Joints()
+	test.That(t, len(joints), test.ShouldEqual, 6)
+	test.That(t, joints[0].Name, test.ShouldEqual, "waist")
+	for i, limit := range fakeArm.ModelFrame().DoF() {
+		test.That(t, joints[i].Limit, test.ShouldResemble, limit)
+	}
+}
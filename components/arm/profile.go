@@ -0,0 +1,177 @@
+//go:build !no_cgo
+
+package arm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+// profileSampleInterval is how often a new set of inputs is sent to the arm while following a time-parameterized trajectory.
+const profileSampleInterval = 50 * time.Millisecond
+
+// MotionProfile bounds how quickly each joint of an arm may move when following a set of waypoints. Velocities are in units of
+// the joint's inputs (radians or mm) per second, and accelerations are in those units per second squared.
+type MotionProfile struct {
+	MaxVelocity     []float64
+	MaxAcceleration []float64
+}
+
+// MotionProfiler is implemented by model frames which know the velocity and acceleration limits of their own joints, such as a
+// referenceframe.SimpleModel whose kinematics file gives max_velocity and max_acceleration for every joint. Both are nil if the
+// limits are not known.
+type MotionProfiler interface {
+	MotionLimits() (maxVelocity, maxAcceleration []float64)
+}
+
+var errNoMotionProfile = errors.New("no motion profile was given, and the arm's model has no joint velocity and acceleration limits")
+
+func (p MotionProfile) validate(dof int) error {
+	if len(p.MaxVelocity) != dof {
+		return fmt.Errorf("motion profile has %d max velocities, but arm has %d joints", len(p.MaxVelocity), dof)
+	}
+	if len(p.MaxAcceleration) != dof {
+		return fmt.Errorf("motion profile has %d max accelerations, but arm has %d joints", len(p.MaxAcceleration), dof)
+	}
+	for i := 0; i < dof; i++ {
+		if p.MaxVelocity[i] <= 0 {
+			return fmt.Errorf("max velocity of joint %d must be positive, got %v", i, p.MaxVelocity[i])
+		}
+		if p.MaxAcceleration[i] <= 0 {
+			return fmt.Errorf("max acceleration of joint %d must be positive, got %v", i, p.MaxAcceleration[i])
+		}
+	}
+	return nil
+}
+
+// segmentProfile is a trapezoidal velocity profile over the fraction of a segment travelled, from 0 to 1. All joints share the
+// same profile so that they start and stop together and the path between waypoints is unchanged.
+type segmentProfile struct {
+	accel    float64 // fraction of segment per second squared
+	peak     float64 // fraction of segment per second
+	rampTime float64 // seconds
+	duration float64 // seconds
+}
+
+func newSegmentProfile(from, to []referenceframe.Input, profile MotionProfile) segmentProfile {
+	maxVel := math.Inf(1)
+	maxAccel := math.Inf(1)
+	for i := range from {
+		dist := math.Abs(to[i].Value - from[i].Value)
+		if dist == 0 {
+			continue
+		}
+		maxVel = math.Min(maxVel, profile.MaxVelocity[i]/dist)
+		maxAccel = math.Min(maxAccel, profile.MaxAcceleration[i]/dist)
+	}
+	if math.IsInf(maxVel, 1) {
+		return segmentProfile{}
+	}
+	// If the segment is too short to reach max velocity, the profile is triangular rather than trapezoidal.
+	peak := math.Min(maxVel, math.Sqrt(maxAccel))
+	rampTime := peak / maxAccel
+	cruiseTime := (1 - peak*rampTime) / peak
+	return segmentProfile{accel: maxAccel, peak: peak, rampTime: rampTime, duration: 2*rampTime + cruiseTime}
+}
+
+// fraction returns how far along the segment the profile is after t seconds.
+func (sp segmentProfile) fraction(t float64) float64 {
+	switch {
+	case t >= sp.duration:
+		return 1
+	case t < sp.rampTime:
+		return 0.5 * sp.accel * t * t
+	case t < sp.duration-sp.rampTime:
+		return 0.5*sp.accel*sp.rampTime*sp.rampTime + sp.peak*(t-sp.rampTime)
+	default:
+		remaining := sp.duration - t
+		return 1 - 0.5*sp.accel*remaining*remaining
+	}
+}
+
+// TimeParameterize returns how long each segment between consecutive waypoints takes when every joint respects the velocity and
+// acceleration limits of the given profile. The arm comes to rest at each waypoint.
+func TimeParameterize(waypoints [][]referenceframe.Input, profile MotionProfile) ([]time.Duration, error) {
+	if len(waypoints) == 0 {
+		return nil, nil
+	}
+	dof := len(waypoints[0])
+	if err := profile.validate(dof); err != nil {
+		return nil, err
+	}
+	durations := make([]time.Duration, 0, len(waypoints)-1)
+	for i := 1; i < len(waypoints); i++ {
+		if len(waypoints[i]) != dof {
+			return nil, referenceframe.NewIncorrectInputLengthError(len(waypoints[i]), dof)
+		}
+		sp := newSegmentProfile(waypoints[i-1], waypoints[i], profile)
+		durations = append(durations, time.Duration(sp.duration*float64(time.Second)))
+	}
+	return durations, nil
+}
+
+// GoToWaypointsWithProfile visits each of the given joint position waypoints in turn, streaming intermediate inputs to the arm
+// so that no joint exceeds the velocity or acceleration limits of the profile. If profile is nil, the limits of the arm's model
+// frame are used, and an error is returned if it has none; use GoToWaypoints to move without limits.
+func GoToWaypointsWithProfile(ctx context.Context, a Arm, waypoints [][]referenceframe.Input, profile *MotionProfile) error {
+	if profile == nil {
+		profiler, ok := a.ModelFrame().(MotionProfiler)
+		if !ok {
+			return errNoMotionProfile
+		}
+		maxVelocity, maxAcceleration := profiler.MotionLimits()
+		if maxVelocity == nil || maxAcceleration == nil {
+			return errNoMotionProfile
+		}
+		profile = &MotionProfile{MaxVelocity: maxVelocity, MaxAcceleration: maxAcceleration}
+	}
+	if len(waypoints) == 0 {
+		return nil
+	}
+	dof := len(a.ModelFrame().DoF())
+	if err := profile.validate(dof); err != nil {
+		return err
+	}
+	for _, waypoint := range waypoints {
+		if len(waypoint) != dof {
+			return referenceframe.NewIncorrectInputLengthError(len(waypoint), dof)
+		}
+	}
+
+	current, err := a.CurrentInputs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, waypoint := range waypoints {
+		sp := newSegmentProfile(current, waypoint, *profile)
+		start := time.Now()
+		for elapsed := profileSampleInterval; ; elapsed += profileSampleInterval {
+			t := math.Min(elapsed.Seconds(), sp.duration)
+			if !utils.SelectContextOrWait(ctx, time.Until(start.Add(time.Duration(t*float64(time.Second))))) {
+				return ctx.Err()
+			}
+			if t >= sp.duration {
+				break
+			}
+			step, err := a.ModelFrame().Interpolate(current, waypoint, sp.fraction(t))
+			if err != nil {
+				return err
+			}
+			if err := a.GoToInputs(ctx, step); err != nil {
+				return err
+			}
+		}
+		if err := a.GoToInputs(ctx, waypoint); err != nil {
+			return err
+		}
+		current = waypoint
+	}
+	return nil
+}
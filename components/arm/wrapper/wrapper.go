@@ -4,6 +4,7 @@ package wrapper
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
 
@@ -23,6 +24,12 @@ import (
 type Config struct {
 	ModelFilePath string `json:"model-path"`
 	ArmName       string `json:"arm-name"`
+
+	// IKSolver selects the IK solver used when planning moves, either "nlopt" or "combined". Defaults to "combined".
+	IKSolver string `json:"ik-solver,omitempty"`
+	// IKMaxIterations and IKTolerance tune the IK solver. If unset, the solver's defaults are used.
+	IKMaxIterations int     `json:"ik-max-iterations,omitempty"`
+	IKTolerance     float64 `json:"ik-tolerance,omitempty"`
 }
 
 var model = resource.DefaultModelFamily.WithModel("wrapper_arm")
@@ -36,10 +43,41 @@ func (cfg *Config) Validate(path string) ([]string, error) {
 	if _, err := modelFromPath(cfg.ModelFilePath, ""); err != nil {
 		return nil, err
 	}
+	if err := cfg.validateIK(); err != nil {
+		return nil, err
+	}
 	deps = append(deps, cfg.ArmName)
 	return deps, nil
 }
 
+func (cfg *Config) validateIK() error {
+	if err := motionplan.ValidateIKSolver(cfg.IKSolver); err != nil {
+		return err
+	}
+	if cfg.IKMaxIterations < 0 {
+		return fmt.Errorf("ik-max-iterations must not be negative, got %d", cfg.IKMaxIterations)
+	}
+	if cfg.IKTolerance < 0 {
+		return fmt.Errorf("ik-tolerance must not be negative, got %v", cfg.IKTolerance)
+	}
+	return nil
+}
+
+// planningOptions returns the motion planning options which select and tune the configured IK solver.
+func (cfg *Config) planningOptions() map[string]interface{} {
+	opts := map[string]interface{}{}
+	if cfg.IKSolver != "" {
+		opts["ik_solver"] = cfg.IKSolver
+	}
+	if cfg.IKMaxIterations > 0 {
+		opts["ik_max_iterations"] = cfg.IKMaxIterations
+	}
+	if cfg.IKTolerance > 0 {
+		opts["ik_tolerance"] = cfg.IKTolerance
+	}
+	return opts
+}
+
 func init() {
 	resource.RegisterComponent(arm.API, model, resource.Registration[arm.Arm, *Config]{
 		Constructor: NewWrapperArm,
@@ -53,9 +91,10 @@ type Arm struct {
 	logger logging.Logger
 	opMgr  *operation.SingleOperationManager
 
-	mu     sync.RWMutex
-	model  referenceframe.Model
-	actual arm.Arm
+	mu           sync.RWMutex
+	model        referenceframe.Model
+	actual       arm.Arm
+	planningOpts map[string]interface{}
 }
 
 // NewWrapperArm returns a wrapper component for another arm.
@@ -79,6 +118,9 @@ func (wrapper *Arm) Reconfigure(ctx context.Context, deps resource.Dependencies,
 	if err != nil {
 		return err
 	}
+	if err := newConf.validateIK(); err != nil {
+		return err
+	}
 	model, err := modelFromPath(newConf.ModelFilePath, conf.Name)
 	if err != nil {
 		return err
@@ -92,6 +134,7 @@ func (wrapper *Arm) Reconfigure(ctx context.Context, deps resource.Dependencies,
 	wrapper.mu.Lock()
 	wrapper.model = model
 	wrapper.actual = newArm
+	wrapper.planningOpts = newConf.planningOptions()
 	wrapper.mu.Unlock()
 
 	return nil
@@ -104,6 +147,11 @@ func (wrapper *Arm) ModelFrame() referenceframe.Model {
 	return wrapper.model
 }
 
+// Joints returns the name and limits of each joint of the model used to plan for this arm.
+func (wrapper *Arm) Joints() []referenceframe.Joint {
+	return referenceframe.Joints(wrapper.ModelFrame())
+}
+
 // EndPosition returns the set position.
 func (wrapper *Arm) EndPosition(ctx context.Context, extra map[string]interface{}) (spatialmath.Pose, error) {
 	wrapper.mu.RLock()
@@ -120,7 +168,10 @@ func (wrapper *Arm) EndPosition(ctx context.Context, extra map[string]interface{
 func (wrapper *Arm) MoveToPosition(ctx context.Context, pos spatialmath.Pose, extra map[string]interface{}) error {
 	ctx, done := wrapper.opMgr.New(ctx)
 	defer done()
-	return arm.Move(ctx, wrapper.logger, wrapper, pos)
+	wrapper.mu.RLock()
+	planningOpts := wrapper.planningOpts
+	wrapper.mu.RUnlock()
+	return arm.MoveWithOptions(ctx, wrapper.logger, wrapper, pos, planningOpts)
 }
 
 // MoveToJointPositions sets the joints.
@@ -2,12 +2,14 @@ package wrapper
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"go.viam.com/test"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/testutils/inject"
 )
@@ -66,6 +68,8 @@ func TestReconfigure(t *testing.T) {
 	deps := resource.Dependencies{armName: actualArm}
 
 	test.That(t, wrapperArm.Reconfigure(context.Background(), deps, cfg1), test.ShouldBeNil)
+	test.That(t, len(wrapperArm.Joints()), test.ShouldEqual, 6)
+	test.That(t, wrapperArm.Joints()[0].Name, test.ShouldEqual, "waist")
 
 	err = wrapperArm.Reconfigure(context.Background(), deps, cfg1Err)
 	test.That(t, err, test.ShouldNotBeNil)
@@ -74,4 +78,29 @@ func TestReconfigure(t *testing.T) {
 	err = wrapperArm.Reconfigure(context.Background(), deps, cfg2Err)
 	test.That(t, err, test.ShouldNotBeNil)
 	test.That(t, err.Error(), test.ShouldContainSubstring, "only files")
+
+	cfgIK := resource.Config{
+		Name: "testArm",
+		ConvertedAttributes: &Config{
+			ModelFilePath:   "../xarm/xarm6_kinematics.json",
+			ArmName:         armName.ShortName(),
+			IKSolver:        motionplan.NloptIKSolver,
+			IKMaxIterations: 100,
+		},
+	}
+	test.That(t, wrapperArm.Reconfigure(context.Background(), deps, cfgIK), test.ShouldBeNil)
+	test.That(t, wrapperArm.planningOpts, test.ShouldResemble, map[string]interface{}{"ik_solver": "nlopt", "ik_max_iterations": 100})
+
+	cfgIKErr := resource.Config{
+		Name: "testArm",
+		ConvertedAttributes: &Config{
+			ModelFilePath: "../xarm/xarm6_kinematics.json",
+			ArmName:       armName.ShortName(),
+			IKSolver:      "newton",
+		},
+	}
+	err = wrapperArm.Reconfigure(context.Background(), deps, cfgIKErr)
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown ik solver "newton", must be one of "nlopt" or "combined"`))
+	_, err = cfgIKErr.ConvertedAttributes.(*Config).Validate("")
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown ik solver "newton", must be one of "nlopt" or "combined"`))
 }
@@ -0,0 +1,157 @@
+package videosource
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+)
+
+// ModelImageDirectory is the name of the camera which serves the images in a directory, for testing without hardware.
+var ModelImageDirectory = resource.DefaultModelFamily.WithModel("image_directory")
+
+// imageDirectoryExtensions are the extensions of the files in the directory which are served as frames.
+var imageDirectoryExtensions = map[string]bool{".jpg": true, ".jpeg": true, ".png": true}
+
+func init() {
+	resource.RegisterComponent(camera.API, ModelImageDirectory,
+		resource.Registration[camera.Camera, *ImageDirectoryConfig]{
+			Constructor: func(ctx context.Context, _ resource.Dependencies,
+				conf resource.Config, logger logging.Logger,
+			) (camera.Camera, error) {
+				newConf, err := resource.NativeConfig[*ImageDirectoryConfig](conf)
+				if err != nil {
+					return nil, err
+				}
+				return newImageDirectoryCamera(ctx, conf.ResourceName(), newConf, logger)
+			},
+		})
+}
+
+// ImageDirectoryConfig is the attribute struct for image directory cameras.
+type ImageDirectoryConfig struct {
+	CameraParameters     *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
+	DistortionParameters *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
+	// Directory holds the jpeg and png images served as frames, in the order of their file names.
+	Directory string `json:"directory"`
+	// FrameRate is how many frames are served per second. If unset, a new frame is served every time one is asked for.
+	FrameRate float32 `json:"frame_rate,omitempty"`
+	// OneShot serves each image once and then fails to serve frames, rather than looping back to the first image.
+	OneShot bool `json:"one_shot,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (c ImageDirectoryConfig) Validate(path string) ([]string, error) {
+	if c.Directory == "" {
+		return nil, resource.NewConfigValidationFieldRequiredError(path, "directory")
+	}
+	if c.FrameRate < 0 {
+		return nil, fmt.Errorf("got illegal negative frame rate (%.2f) field set for image_directory camera", c.FrameRate)
+	}
+	if c.CameraParameters != nil {
+		if c.CameraParameters.Width < 0 || c.CameraParameters.Height < 0 {
+			return nil, fmt.Errorf(
+				"got illegal negative dimensions for width_px and height_px (%d, %d) fields set in intrinsic_parameters for image_directory camera",
+				c.CameraParameters.Width, c.CameraParameters.Height)
+		}
+	}
+	return []string{}, nil
+}
+
+func newImageDirectoryCamera(
+	ctx context.Context, name resource.Name, conf *ImageDirectoryConfig, logger logging.Logger,
+) (camera.Camera, error) {
+	paths, err := imageDirectoryPaths(conf.Directory)
+	if err != nil {
+		return nil, err
+	}
+	reader := &imageDirectorySource{paths: paths, oneShot: conf.OneShot}
+	if conf.FrameRate > 0 {
+		reader.period = time.Duration(float64(time.Second) / float64(conf.FrameRate))
+	}
+	cameraModel := camera.NewPinholeModelWithBrownConradyDistortion(conf.CameraParameters, conf.DistortionParameters)
+	src, err := camera.NewVideoSourceFromReader(ctx, reader, &cameraModel, camera.ColorStream)
+	if err != nil {
+		return nil, err
+	}
+	return camera.FromVideoSource(name, src, logger), nil
+}
+
+// imageDirectoryPaths returns the paths of the images in the directory, ordered by file name.
+func imageDirectoryPaths(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read image directory %s", dir)
+	}
+	var paths []string
+	for _, entry := range entries {
+		if entry.IsDir() || !imageDirectoryExtensions[strings.ToLower(filepath.Ext(entry.Name()))] {
+			continue
+		}
+		paths = append(paths, filepath.Join(dir, entry.Name()))
+	}
+	if len(paths) == 0 {
+		return nil, errors.Errorf("image directory %s has no jpeg or png images", dir)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// imageDirectorySource serves the images in a directory in turn, at most one per period.
+type imageDirectorySource struct {
+	paths   []string
+	oneShot bool
+	period  time.Duration
+
+	mu   sync.Mutex
+	next int
+	due  time.Time
+}
+
+// Read waits until the next frame is due and returns it, reading it from its file.
+func (s *imageDirectorySource) Read(ctx context.Context) (image.Image, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.next == len(s.paths) {
+		if s.oneShot {
+			return nil, nil, errors.Errorf("all %d images have been served and one_shot is set", len(s.paths))
+		}
+		s.next = 0
+	}
+	if s.period > 0 {
+		if wait := time.Until(s.due); wait > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			case <-time.After(wait):
+			}
+		}
+		// frames are not served in a burst to catch up after a gap between reads
+		s.due = time.Now().Add(s.period)
+	}
+
+	img, err := rimage.NewImageFromFile(s.paths[s.next])
+	if err != nil {
+		return nil, nil, err
+	}
+	s.next++
+	return img, func() {}, nil
+}
+
+// Close does nothing.
+func (s *imageDirectorySource) Close(ctx context.Context) error {
+	return nil
+}
@@ -0,0 +1,100 @@
+package videosource
+
+import (
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage"
+	"go.viam.com/rdk/rimage/transform"
+)
+
+func TestImageDirectory(t *testing.T) {
+	ctx := context.Background()
+	logger := logging.NewTestLogger(t)
+	dir := t.TempDir()
+	// each image is a different width, so the frames can be told apart
+	for i, name := range []string{"b.png", "a.jpg", "c.png"} {
+		img := image.NewRGBA(image.Rect(0, 0, 10+2*i, 8))
+		img.Set(0, 0, color.RGBA{R: 255, A: 255})
+		test.That(t, rimage.WriteImageToFile(filepath.Join(dir, name), img), test.ShouldBeNil)
+	}
+	test.That(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not an image"), 0o600), test.ShouldBeNil)
+	test.That(t, os.Mkdir(filepath.Join(dir, "nested.png"), 0o700), test.ShouldBeNil)
+
+	readWidths := func(cam camera.Camera, n int) []int {
+		stream, err := cam.Stream(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() { test.That(t, stream.Close(ctx), test.ShouldBeNil) }()
+		widths := make([]int, 0, n)
+		for i := 0; i < n; i++ {
+			img, _, err := stream.Next(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			widths = append(widths, img.Bounds().Dx())
+		}
+		return widths
+	}
+
+	t.Run("loops over the images in name order", func(t *testing.T) {
+		intrinsics := &transform.PinholeCameraIntrinsics{Width: 12, Height: 8, Fx: 10, Fy: 10, Ppx: 6, Ppy: 4}
+		cam, err := newImageDirectoryCamera(ctx, resource.Name{API: camera.API},
+			&ImageDirectoryConfig{Directory: dir, CameraParameters: intrinsics}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() { test.That(t, cam.Close(ctx), test.ShouldBeNil) }()
+		test.That(t, readWidths(cam, 4), test.ShouldResemble, []int{12, 10, 14, 12})
+
+		props, err := cam.Properties(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, props.IntrinsicParams, test.ShouldResemble, intrinsics)
+	})
+
+	t.Run("one shot", func(t *testing.T) {
+		cam, err := newImageDirectoryCamera(ctx, resource.Name{API: camera.API},
+			&ImageDirectoryConfig{Directory: dir, OneShot: true}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() { test.That(t, cam.Close(ctx), test.ShouldBeNil) }()
+		test.That(t, readWidths(cam, 3), test.ShouldResemble, []int{12, 10, 14})
+		_, _, err = camera.ReadImage(ctx, cam)
+		test.That(t, err, test.ShouldBeError, errors.New("all 3 images have been served and one_shot is set"))
+	})
+
+	t.Run("frame rate", func(t *testing.T) {
+		cam, err := newImageDirectoryCamera(ctx, resource.Name{API: camera.API},
+			&ImageDirectoryConfig{Directory: dir, FrameRate: 20}, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer func() { test.That(t, cam.Close(ctx), test.ShouldBeNil) }()
+		start := time.Now()
+		readWidths(cam, 3)
+		// the first frame is served right away, and then one every 50ms
+		test.That(t, time.Since(start), test.ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+	})
+
+	t.Run("no images", func(t *testing.T) {
+		empty := t.TempDir()
+		_, err := newImageDirectoryCamera(ctx, resource.Name{API: camera.API}, &ImageDirectoryConfig{Directory: empty}, logger)
+		test.That(t, err, test.ShouldBeError, errors.New("image directory "+empty+" has no jpeg or png images"))
+		_, err = newImageDirectoryCamera(ctx, resource.Name{API: camera.API},
+			&ImageDirectoryConfig{Directory: filepath.Join(empty, "missing")}, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "cannot read image directory")
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		_, err := ImageDirectoryConfig{}.Validate("path")
+		test.That(t, err, test.ShouldBeError, resource.NewConfigValidationFieldRequiredError("path", "directory"))
+		_, err = ImageDirectoryConfig{Directory: dir, FrameRate: -1}.Validate("path")
+		test.That(t, err, test.ShouldBeError, errors.New("got illegal negative frame rate (-1.00) field set for image_directory camera"))
+		_, err = ImageDirectoryConfig{Directory: dir, FrameRate: 30}.Validate("path")
+		test.That(t, err, test.ShouldBeNil)
+	})
+}
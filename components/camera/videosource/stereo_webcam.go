@@ -0,0 +1,223 @@
+package videosource
+
+import (
+	"context"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/pion/mediadevices/pkg/driver"
+	"github.com/pkg/errors"
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/gostream"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+)
+
+// ModelStereoWebcam is the name of the stereo webcam component.
+var ModelStereoWebcam = resource.DefaultModelFamily.WithModel("stereo_webcam")
+
+// Names of the images returned by a stereo webcam.
+const (
+	StereoLeftName  = "left"
+	StereoRightName = "right"
+)
+
+func init() {
+	resource.RegisterComponent(
+		camera.API,
+		ModelStereoWebcam,
+		resource.Registration[camera.Camera, *StereoWebcamConfig]{
+			Constructor: NewStereoWebcam,
+		})
+}
+
+// StereoWebcamConfig is the attribute struct for stereo webcams. Every webcam attribute other than video_path, vendor_id and
+// product_id is shared by both webcams of the pair, which are instead selected by left_video_path and right_video_path.
+type StereoWebcamConfig struct {
+	WebcamConfig `json:",squash"`
+	LeftPath     string `json:"left_video_path"`
+	RightPath    string `json:"right_video_path"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (c StereoWebcamConfig) Validate(path string) ([]string, error) {
+	if c.LeftPath == "" {
+		return nil, resource.NewConfigValidationFieldRequiredError(path, "left_video_path")
+	}
+	if c.RightPath == "" {
+		return nil, resource.NewConfigValidationFieldRequiredError(path, "right_video_path")
+	}
+	if c.LeftPath == c.RightPath {
+		return nil, errors.Errorf("left_video_path and right_video_path (%q) must be different for stereo webcam camera", c.LeftPath)
+	}
+	if c.Path != "" || c.VendorID != "" || c.ProductID != "" {
+		return nil, errors.New(
+			"video_path, vendor_id and product_id cannot be set for stereo webcam camera, use left_video_path and right_video_path")
+	}
+	return c.WebcamConfig.Validate(path)
+}
+
+// eye returns the config of the webcam at the given path.
+func (c StereoWebcamConfig) eye(path string) *WebcamConfig {
+	conf := c.WebcamConfig
+	conf.Path = path
+	return &conf
+}
+
+// StereoFrame is a pair of images read from the two webcams of a stereo webcam, along with when each was captured.
+type StereoFrame struct {
+	Left            image.Image
+	Right           image.Image
+	LeftCapturedAt  time.Time
+	RightCapturedAt time.Time
+}
+
+// Skew returns how far apart in time the two images of the frame were captured.
+func (f StereoFrame) Skew() time.Duration {
+	if f.LeftCapturedAt.After(f.RightCapturedAt) {
+		return f.LeftCapturedAt.Sub(f.RightCapturedAt)
+	}
+	return f.RightCapturedAt.Sub(f.LeftCapturedAt)
+}
+
+// StereoWebcam is a camera made of two webcams whose images are read together.
+type StereoWebcam struct {
+	resource.Named
+	resource.AlwaysRebuild
+	left   camera.Camera
+	right  camera.Camera
+	logger logging.Logger
+}
+
+// NewStereoWebcam returns a new stereo webcam from the two webcams given in the config.
+func NewStereoWebcam(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (camera.Camera, error) {
+	return NewStereoWebcamWithSources(ctx, deps, conf, getVideoDrivers, getVideoSource, logger)
+}
+
+// NewStereoWebcamWithSources returns a new stereo webcam like NewStereoWebcam, but looks up drivers with getDrivers
+// and opens video sources with getSource instead of going to the system's devices.
+func NewStereoWebcamWithSources(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	getDrivers func() []driver.Driver,
+	getSource VideoSourceGetter,
+	logger logging.Logger,
+) (*StereoWebcam, error) {
+	newConf, err := resource.NativeConfig[*StereoWebcamConfig](conf)
+	if err != nil {
+		return nil, err
+	}
+	openEye := func(side, path string) (camera.Camera, error) {
+		eyeConf := resource.Config{
+			Name:                conf.ResourceName().ShortName() + "-" + side,
+			API:                 camera.API,
+			Model:               ModelWebcam,
+			ConvertedAttributes: newConf.eye(path),
+		}
+		cam, err := NewWebcamWithSources(ctx, deps, eyeConf, getDrivers, getSource, logger)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot open %s webcam", side)
+		}
+		return cam, nil
+	}
+	left, err := openEye(StereoLeftName, newConf.LeftPath)
+	if err != nil {
+		return nil, err
+	}
+	right, err := openEye(StereoRightName, newConf.RightPath)
+	if err != nil {
+		return nil, multierr.Combine(err, left.Close(ctx))
+	}
+	return &StereoWebcam{
+		Named:  conf.ResourceName().AsNamed(),
+		left:   left,
+		right:  right,
+		logger: logger,
+	}, nil
+}
+
+// NextPair reads an image from each webcam at the same time, recording when each image was captured.
+func (s *StereoWebcam) NextPair(ctx context.Context) (StereoFrame, error) {
+	var frame StereoFrame
+	var leftErr, rightErr error
+	var wg sync.WaitGroup
+	read := func(cam camera.Camera, img *image.Image, capturedAt *time.Time, err *error) {
+		defer wg.Done()
+		var imgs []camera.NamedImage
+		var metadata resource.ResponseMetadata
+		imgs, metadata, *err = cam.Images(ctx)
+		if *err != nil {
+			return
+		}
+		if len(imgs) == 0 {
+			*err = errors.New("webcam returned no images")
+			return
+		}
+		*img = imgs[0].Image
+		*capturedAt = metadata.CapturedAt
+	}
+	wg.Add(2)
+	go read(s.left, &frame.Left, &frame.LeftCapturedAt, &leftErr)
+	go read(s.right, &frame.Right, &frame.RightCapturedAt, &rightErr)
+	wg.Wait()
+	if leftErr != nil || rightErr != nil {
+		return StereoFrame{}, multierr.Combine(
+			errors.Wrap(leftErr, "cannot read left webcam"),
+			errors.Wrap(rightErr, "cannot read right webcam"),
+		)
+	}
+	return frame, nil
+}
+
+// Images returns the left and right images of the next pair, named StereoLeftName and StereoRightName. The capture time in the
+// metadata is that of the older image; use NextPair for the capture time of each.
+func (s *StereoWebcam) Images(ctx context.Context) ([]camera.NamedImage, resource.ResponseMetadata, error) {
+	frame, err := s.NextPair(ctx)
+	if err != nil {
+		return nil, resource.ResponseMetadata{}, err
+	}
+	capturedAt := frame.LeftCapturedAt
+	if frame.RightCapturedAt.Before(capturedAt) {
+		capturedAt = frame.RightCapturedAt
+	}
+	return []camera.NamedImage{
+		{Image: frame.Left, SourceName: StereoLeftName},
+		{Image: frame.Right, SourceName: StereoRightName},
+	}, resource.ResponseMetadata{CapturedAt: capturedAt}, nil
+}
+
+// Stream returns a stream of the left webcam.
+func (s *StereoWebcam) Stream(ctx context.Context, errHandlers ...gostream.ErrorHandler) (gostream.VideoStream, error) {
+	return s.left.Stream(ctx, errHandlers...)
+}
+
+// NextPointCloud is not supported by stereo webcams.
+func (s *StereoWebcam) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
+	return nil, errors.New("NextPointCloud is not supported by stereo webcam")
+}
+
+// Projector returns the projector of the left webcam.
+func (s *StereoWebcam) Projector(ctx context.Context) (transform.Projector, error) {
+	return s.left.Projector(ctx)
+}
+
+// Properties returns the properties of the left webcam. Both webcams share the same configuration.
+func (s *StereoWebcam) Properties(ctx context.Context) (camera.Properties, error) {
+	return s.left.Properties(ctx)
+}
+
+// Close closes both webcams.
+func (s *StereoWebcam) Close(ctx context.Context) error {
+	return multierr.Combine(s.left.Close(ctx), s.right.Close(ctx))
+}
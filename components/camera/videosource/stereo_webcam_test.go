@@ -0,0 +1,122 @@
+package videosource_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/pion/mediadevices"
+	"github.com/pion/mediadevices/pkg/driver"
+	"github.com/pion/mediadevices/pkg/prop"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/gostream"
+	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/utils"
+)
+
+func TestStereoWebcamConfig(t *testing.T) {
+	conf, err := resource.TransformAttributeMap[*videosource.StereoWebcamConfig](utils.AttributeMap{
+		"left_video_path":  "left cam",
+		"right_video_path": "right cam",
+		"width_px":         320,
+		"rotation_degrees": 180,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, conf.LeftPath, test.ShouldEqual, "left cam")
+	test.That(t, conf.RightPath, test.ShouldEqual, "right cam")
+	test.That(t, conf.Width, test.ShouldEqual, 320)
+	test.That(t, conf.Rotation, test.ShouldEqual, 180)
+	deps, err := conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	conf.RightPath = ""
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldBeError, resource.NewConfigValidationFieldRequiredError("path", "right_video_path"))
+
+	conf.RightPath = "left cam"
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldBeError,
+		errors.New(`left_video_path and right_video_path ("left cam") must be different for stereo webcam camera`))
+
+	conf.RightPath = "right cam"
+	conf.Path = "some label"
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// the shared webcam attributes are validated too
+	conf.Path = ""
+	conf.Rotation = 45
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "rotation_degrees")
+}
+
+func TestStereoWebcamWithFakeSources(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	leftProps := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	rightProps := prop.Video{Width: 640, Height: 480, FrameFormat: "some format", FrameRate: 30.0}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{
+			newFakeDriver("left cam", []prop.Media{{Video: leftProps}}),
+			newFakeDriver("right cam", []prop.Media{{Video: rightProps}}),
+		}
+	}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		switch name {
+		case "left cam":
+			return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: leftProps}}), leftProps), nil
+		case "right cam":
+			return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: rightProps}}), rightProps), nil
+		default:
+			return nil, errors.New("no such webcam")
+		}
+	}
+
+	conf := resource.Config{
+		Name:  "stereo",
+		API:   camera.API,
+		Model: videosource.ModelStereoWebcam,
+		ConvertedAttributes: &videosource.StereoWebcamConfig{
+			LeftPath:  "left cam",
+			RightPath: "right cam",
+		},
+	}
+	cam, err := videosource.NewStereoWebcamWithSources(context.Background(), nil, conf, getDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	frame, err := cam.NextPair(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, frame.Left.Bounds().Dx(), test.ShouldEqual, 320)
+	test.That(t, frame.Right.Bounds().Dx(), test.ShouldEqual, 640)
+	test.That(t, frame.LeftCapturedAt.IsZero(), test.ShouldBeFalse)
+	test.That(t, frame.RightCapturedAt.IsZero(), test.ShouldBeFalse)
+	test.That(t, frame.Skew(), test.ShouldBeGreaterThanOrEqualTo, 0)
+
+	imgs, metadata, err := cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs, test.ShouldHaveLength, 2)
+	test.That(t, imgs[0].SourceName, test.ShouldEqual, videosource.StereoLeftName)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 320)
+	test.That(t, imgs[1].SourceName, test.ShouldEqual, videosource.StereoRightName)
+	test.That(t, imgs[1].Image.Bounds().Dx(), test.ShouldEqual, 640)
+	test.That(t, metadata.CapturedAt.IsZero(), test.ShouldBeFalse)
+
+	_, err = cam.NextPointCloud(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// failing to open either webcam fails the pair
+	conf.ConvertedAttributes = &videosource.StereoWebcamConfig{LeftPath: "left cam", RightPath: "missing cam"}
+	_, err = videosource.NewStereoWebcamWithSources(context.Background(), nil, conf, getDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot open right webcam")
+}
@@ -7,11 +7,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"image"
+	"maps"
+	"math"
+	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/disintegration/imaging"
 	"github.com/pion/mediadevices"
 	"github.com/pion/mediadevices/pkg/driver"
 	"github.com/pion/mediadevices/pkg/driver/availability"
@@ -22,6 +28,7 @@ import (
 	"go.uber.org/multierr"
 	pb "go.viam.com/api/component/camera/v1"
 	goutils "go.viam.com/utils"
+	"google.golang.org/protobuf/encoding/protojson"
 
 	"go.viam.com/rdk/components/camera"
 	jetsoncamera "go.viam.com/rdk/components/camera/platforms/jetson"
@@ -31,6 +38,8 @@ import (
 	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/utils"
 )
 
 // ModelWebcam is the name of the webcam component.
@@ -56,8 +65,32 @@ func init() {
 	}
 }
 
+// getVideoDrivers returns the video drivers of the system other than screen captures, so that a screen is never mistaken for a
+// webcam.
 func getVideoDrivers() []driver.Driver {
-	return driver.GetManager().Query(driver.FilterVideoRecorder())
+	return driver.GetManager().Query(driver.FilterAnd(
+		driver.FilterVideoRecorder(),
+		driver.FilterNot(driver.FilterDeviceType(driver.Screen)),
+	))
+}
+
+// VideoSourceGetter opens the video source with the given name that satisfies the constraints.
+// An empty name asks for any available video source.
+type VideoSourceGetter func(
+	name string,
+	constraints mediadevices.MediaStreamConstraints,
+	logger logging.Logger,
+) (gostream.VideoSource, error)
+
+func getVideoSource(
+	name string,
+	constraints mediadevices.MediaStreamConstraints,
+	logger logging.Logger,
+) (gostream.VideoSource, error) {
+	if name == "" {
+		return gostream.GetAnyVideoSource(constraints, logger.AsZap())
+	}
+	return gostream.GetNamedVideoSource(name, constraints, logger.AsZap())
 }
 
 // CameraConfig is collection of configuration options for a camera.
@@ -67,25 +100,116 @@ type CameraConfig struct {
 	Properties []prop.Media
 }
 
-// Discover webcam attributes.
+// Reasons a driver can be skipped during discovery.
+const (
+	SkipReasonOpenFailed   = "open failed"
+	SkipReasonNoProperties = "no properties"
+	SkipReasonInUse        = "in use"
+	SkipReasonScreen       = "screen capture"
+)
+
+// DiscoverySkip describes a driver that was left out of discovery and why.
+type DiscoverySkip struct {
+	Label  string
+	Reason string
+	Err    error
+}
+
+// Discover webcam attributes. Screen capture drivers are left out; use DiscoverIncludingScreens to discover them too.
 func Discover(ctx context.Context, getDrivers func() []driver.Driver, logger logging.Logger) (*pb.Webcams, error) {
+	webcams, _, err := DiscoverWithDiagnostics(ctx, getDrivers, logger)
+	return webcams, err
+}
+
+// DiscoverWithMinResolution discovers webcam attributes like Discover, but only includes properties that are at least
+// minWidth by minHeight pixels, and only webcams left with at least one such property. A minimum of zero does not filter.
+// Skipped webcams and properties are logged at debug level.
+func DiscoverWithMinResolution(
+	ctx context.Context,
+	getDrivers func() []driver.Driver,
+	minWidth, minHeight int,
+	logger logging.Logger,
+) (*pb.Webcams, error) {
+	if minWidth < 0 || minHeight < 0 {
+		return nil, fmt.Errorf("minimum resolution cannot be negative, got %dx%d", minWidth, minHeight)
+	}
+	webcams, err := Discover(ctx, getDrivers, logger)
+	if err != nil {
+		return nil, err
+	}
+	filtered := make([]*pb.Webcam, 0, len(webcams.GetWebcams()))
+	for _, wc := range webcams.GetWebcams() {
+		props := make([]*pb.Property, 0, len(wc.GetProperties()))
+		for _, p := range wc.GetProperties() {
+			if int(p.GetWidthPx()) < minWidth || int(p.GetHeightPx()) < minHeight {
+				logger.CDebugw(ctx, "property is below the minimum resolution, skipping...",
+					"webcam", wc.GetLabel(), "width_px", p.GetWidthPx(), "height_px", p.GetHeightPx(),
+					"frame_format", p.GetFrameFormat())
+				continue
+			}
+			props = append(props, p)
+		}
+		if len(props) == 0 {
+			logger.CDebugw(ctx, "no properties meet the minimum resolution, skipping discovery...",
+				"webcam", wc.GetLabel(), "min_width_px", minWidth, "min_height_px", minHeight)
+			continue
+		}
+		wc.Properties = props
+		filtered = append(filtered, wc)
+	}
+	return &pb.Webcams{Webcams: filtered}, nil
+}
+
+// DiscoverIncludingScreens discovers webcam attributes like Discover, but also includes any screen capture drivers returned by
+// getDrivers. These are left out of Discover so that a screen is not accidentally configured as a camera.
+func DiscoverIncludingScreens(ctx context.Context, getDrivers func() []driver.Driver, logger logging.Logger) (*pb.Webcams, error) {
+	webcams, _, err := discoverWithDiagnostics(ctx, getDrivers, true, logger)
+	return webcams, err
+}
+
+// DiscoverWithDiagnostics discovers webcam attributes like Discover and also reports every driver that was
+// skipped along with the reason, to help troubleshoot a camera that does not show up.
+func DiscoverWithDiagnostics(
+	ctx context.Context,
+	getDrivers func() []driver.Driver,
+	logger logging.Logger,
+) (*pb.Webcams, []DiscoverySkip, error) {
+	return discoverWithDiagnostics(ctx, getDrivers, false, logger)
+}
+
+func discoverWithDiagnostics(
+	ctx context.Context,
+	getDrivers func() []driver.Driver,
+	includeScreens bool,
+	logger logging.Logger,
+) (*pb.Webcams, []DiscoverySkip, error) {
 	mediadevicescamera.Initialize()
 	var webcams []*pb.Webcam
+	var skipped []DiscoverySkip
 	drivers := getDrivers()
 	for _, d := range drivers {
 		driverInfo := d.Info()
 
-		props, err := getProperties(d)
-		if len(props) == 0 {
-			logger.CDebugw(ctx, "no properties detected for driver, skipping discovery...", "driver", driverInfo.Label)
+		if driverInfo.DeviceType == driver.Screen && !includeScreens {
+			logger.CDebugw(ctx, "driver is a screen capture, skipping discovery...", "driver", driverInfo.Label)
+			skipped = append(skipped, DiscoverySkip{Label: driverInfo.Label, Reason: SkipReasonScreen})
 			continue
-		} else if err != nil {
+		}
+
+		props, err := getProperties(d)
+		if err != nil {
 			logger.CDebugw(ctx, "cannot access driver properties, skipping discovery...", "driver", driverInfo.Label, "error", err)
+			skipped = append(skipped, DiscoverySkip{Label: driverInfo.Label, Reason: SkipReasonOpenFailed, Err: err})
+			continue
+		} else if len(props) == 0 {
+			logger.CDebugw(ctx, "no properties detected for driver, skipping discovery...", "driver", driverInfo.Label)
+			skipped = append(skipped, DiscoverySkip{Label: driverInfo.Label, Reason: SkipReasonNoProperties})
 			continue
 		}
 
 		if d.Status() == driver.StateRunning {
 			logger.CDebugw(ctx, "driver is in use, skipping discovery...", "driver", driverInfo.Label)
+			skipped = append(skipped, DiscoverySkip{Label: driverInfo.Label, Reason: SkipReasonInUse})
 			continue
 		}
 
@@ -109,7 +233,13 @@ func Discover(ctx context.Context, getDrivers func() []driver.Driver, logger log
 			Properties: make([]*pb.Property, 0, len(d.Properties())),
 		}
 
+		// some drivers report the same property more than once, so only keep the first of each
+		seen := make(map[prop.Video]struct{}, len(props))
 		for _, prop := range props {
+			if _, ok := seen[prop.Video]; ok {
+				continue
+			}
+			seen[prop.Video] = struct{}{}
 			pbProp := &pb.Property{
 				WidthPx:     int32(prop.Video.Width),
 				HeightPx:    int32(prop.Video.Height),
@@ -124,7 +254,91 @@ func Discover(ctx context.Context, getDrivers func() []driver.Driver, logger log
 	if err := debugLogger.GLoggerCamComp.Log("discovery service", webcamsToMap(webcams)); err != nil {
 		logger.Debug(err)
 	}
-	return &pb.Webcams{Webcams: webcams}, nil
+	return &pb.Webcams{Webcams: webcams}, skipped, nil
+}
+
+// DiscoverRemote discovers the webcams of the given robot, which may be a remote robot reached through robot/client, by running
+// webcam discovery through its DiscoverComponents. The deadline and cancellation of ctx are passed on to the robot.
+func DiscoverRemote(ctx context.Context, r robot.Robot) (*pb.Webcams, error) {
+	query := resource.NewDiscoveryQuery(camera.API, ModelWebcam)
+	discoveries, err := r.DiscoverComponents(ctx, []resource.DiscoveryQuery{query})
+	if err != nil {
+		return nil, err
+	}
+	for _, discovery := range discoveries {
+		if discovery.Query != query {
+			continue
+		}
+		switch results := discovery.Results.(type) {
+		case *pb.Webcams:
+			return results, nil
+		case map[string]interface{}:
+			// results sent over the network arrive as a generic struct, so convert them back to webcams
+			resultsJSON, err := json.Marshal(results)
+			if err != nil {
+				return nil, err
+			}
+			webcams := &pb.Webcams{}
+			if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(resultsJSON, webcams); err != nil {
+				return nil, errors.Wrap(err, "cannot parse webcam discovery results")
+			}
+			return webcams, nil
+		default:
+			return nil, errors.Errorf("unexpected webcam discovery results of type %T", discovery.Results)
+		}
+	}
+	return nil, errors.New("robot did not return webcam discovery results")
+}
+
+// DriverStatus is the label and current state of a video driver.
+type DriverStatus struct {
+	Label  string
+	Status driver.State
+}
+
+// InUse returns whether the driver has been opened. Drivers only track their state within this process, so a webcam held by another
+// process may still be reported as closed.
+func (d DriverStatus) InUse() bool {
+	return d.Status == driver.StateRunning || d.Status == driver.StateOpened
+}
+
+// ListDrivers returns the label and state of every video driver, whether or not it is in use, to help work out why a webcam is
+// busy. Unlike discovery, it does not open any driver to read its properties.
+func ListDrivers(getDrivers func() []driver.Driver) []DriverStatus {
+	mediadevicescamera.Initialize()
+	drivers := getDrivers()
+	statuses := make([]DriverStatus, 0, len(drivers))
+	for _, d := range drivers {
+		statuses = append(statuses, DriverStatus{Label: d.Info().Label, Status: d.Status()})
+	}
+	return statuses
+}
+
+// ResolutionFormats lists every frame format a webcam supports at a single resolution.
+type ResolutionFormats struct {
+	WidthPx      int32
+	HeightPx     int32
+	FrameFormats []string
+}
+
+// FormatsByResolution groups the discovered properties of a webcam by resolution, listing each distinct
+// frame format once per resolution. Resolutions are returned in the order they were first discovered.
+func FormatsByResolution(webcam *pb.Webcam) []ResolutionFormats {
+	var grouped []ResolutionFormats
+	indexes := map[[2]int32]int{}
+	for _, p := range webcam.GetProperties() {
+		key := [2]int32{p.GetWidthPx(), p.GetHeightPx()}
+		idx, ok := indexes[key]
+		if !ok {
+			idx = len(grouped)
+			indexes[key] = idx
+			grouped = append(grouped, ResolutionFormats{WidthPx: key[0], HeightPx: key[1]})
+		}
+		if !slices.Contains(grouped[idx].FrameFormats, p.GetFrameFormat()) {
+			grouped[idx].FrameFormats = append(grouped[idx].FrameFormats, p.GetFrameFormat())
+		}
+	}
+	return grouped
 }
 
 func webcamsToMap(webcams []*pb.Webcam) debugLogger.InfoMap {
@@ -166,16 +380,97 @@ func getProperties(d driver.Driver) (_ []prop.Media, err error) {
 
 // WebcamConfig is the attribute struct for webcams.
 type WebcamConfig struct {
-	CameraParameters     *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
-	DistortionParameters *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
-	Debug                bool                               `json:"debug,omitempty"`
-	Format               string                             `json:"format,omitempty"`
-	Path                 string                             `json:"video_path"`
-	Width                int                                `json:"width_px,omitempty"`
-	Height               int                                `json:"height_px,omitempty"`
-	FrameRate            float32                            `json:"frame_rate,omitempty"`
+	CameraParameters        *transform.PinholeCameraIntrinsics `json:"intrinsic_parameters,omitempty"`
+	DistortionParameters    *transform.BrownConrady            `json:"distortion_parameters,omitempty"`
+	DefaultHFOVDegrees      float64                            `json:"default_hfov_degrees,omitempty"`
+	Debug                   bool                               `json:"debug,omitempty"`
+	Format                  string                             `json:"format,omitempty"`
+	FormatFallback          bool                               `json:"format_fallback,omitempty"`
+	FormatPriority          []string                           `json:"format_priority,omitempty"`
+	Path                    string                             `json:"video_path"`
+	Width                   int                                `json:"width_px,omitempty"`
+	Height                  int                                `json:"height_px,omitempty"`
+	MinWidth                int                                `json:"min_width_px,omitempty"`
+	MaxWidth                int                                `json:"max_width_px,omitempty"`
+	MinHeight               int                                `json:"min_height_px,omitempty"`
+	MaxHeight               int                                `json:"max_height_px,omitempty"`
+	FrameRate               float32                            `json:"frame_rate,omitempty"`
+	MaxFPS                  float32                            `json:"max_fps,omitempty"`
+	Rotation                int                                `json:"rotation_degrees,omitempty"`
+	ReconnectAttempts       int                                `json:"reconnect_attempts,omitempty"`
+	ReconnectIntervalMs     int                                `json:"reconnect_interval_ms,omitempty"`
+	OpenTimeoutMs           int                                `json:"open_timeout_ms,omitempty"`
+	VendorID                string                             `json:"vendor_id,omitempty"`
+	ProductID               string                             `json:"product_id,omitempty"`
+	Controls                map[string]int                     `json:"controls,omitempty"`
+	PreferHighestResolution bool                               `json:"prefer_highest_resolution,omitempty"`
+	Undistort               bool                               `json:"undistort,omitempty"`
+	BufferSize              int                                `json:"buffer_size,omitempty"`
+	FrameHistorySize        int                                `json:"frame_history_size,omitempty"`
+	ROI                     *RegionOfInterest                  `json:"roi,omitempty"`
+	ScaleIntrinsics         bool                               `json:"scale_intrinsics,omitempty"`
+}
+
+// RegionOfInterest is the part of each frame a webcam keeps, in pixels of the frame the driver delivers, before any rotation.
+// Configured intrinsics, and those of known camera models, describe the whole frame and are shifted to the region, while
+// intrinsics estimated from default_hfov_degrees treat it as the field of view of the region.
+type RegionOfInterest struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// rect returns the region as a rectangle of the frame.
+func (r RegionOfInterest) rect() image.Rectangle {
+	return image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height)
+}
+
+// fitsIn returns an error if the region does not fit in a frame of the given size.
+func (r RegionOfInterest) fitsIn(width, height int) error {
+	if !r.rect().In(image.Rect(0, 0, width, height)) {
+		return errors.Errorf("roi (x %d, y %d, width %d, height %d) does not fit in a %dx%d frame",
+			r.X, r.Y, r.Width, r.Height, width, height)
+	}
+	return nil
+}
+
+// cropIntrinsics returns the intrinsics of the region of a frame with the given intrinsics, which differ only in the size of
+// the image and in having their principal point shifted by the offset of the region.
+func (r *RegionOfInterest) cropIntrinsics(intrinsics *transform.PinholeCameraIntrinsics) *transform.PinholeCameraIntrinsics {
+	if r == nil || intrinsics == nil {
+		return intrinsics
+	}
+	cropped := *intrinsics
+	cropped.Width, cropped.Height = r.Width, r.Height
+	cropped.Ppx -= float64(r.X)
+	cropped.Ppy -= float64(r.Y)
+	return &cropped
+}
+
+// defaultFormatPriority is the order frame formats are tried in when neither format nor format_priority is set.
+var defaultFormatPriority = []frame.Format{
+	frame.FormatI420,
+	frame.FormatI444,
+	frame.FormatYUY2,
+	frame.FormatUYVY,
+	frame.FormatRGBA,
+	frame.FormatMJPEG,
+	frame.FormatNV12,
+	frame.FormatNV21,
+	frame.FormatZ16,
 }
 
+// validFrameFormats lists every frame format that may appear in format_priority.
+var validFrameFormats = append(slices.Clone(defaultFormatPriority), frame.FormatYUYV)
+
+const (
+	defaultReconnectAttempts = 5
+	defaultReconnectInterval = 500 * time.Millisecond
+	maxReconnectInterval     = 30 * time.Second
+	defaultOpenTimeout       = 5 * time.Second
+)
+
 // Validate ensures all parts of the config are valid.
 func (c WebcamConfig) Validate(path string) ([]string, error) {
 	if c.Width < 0 || c.Height < 0 {
@@ -183,50 +478,277 @@ func (c WebcamConfig) Validate(path string) ([]string, error) {
 			"got illegal negative dimensions for width_px and height_px (%d, %d) fields set for webcam camera",
 			c.Height, c.Width)
 	}
+	if c.MinWidth < 0 || c.MaxWidth < 0 || c.MinHeight < 0 || c.MaxHeight < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative dimensions for min_width_px, max_width_px, min_height_px and max_height_px (%d, %d, %d, %d) "+
+				"fields set for webcam camera",
+			c.MinWidth, c.MaxWidth, c.MinHeight, c.MaxHeight)
+	}
+	if c.MaxWidth > 0 && c.MinWidth > c.MaxWidth {
+		return nil, fmt.Errorf(
+			"min_width_px (%d) cannot be greater than max_width_px (%d) for webcam camera",
+			c.MinWidth, c.MaxWidth)
+	}
+	if c.MaxHeight > 0 && c.MinHeight > c.MaxHeight {
+		return nil, fmt.Errorf(
+			"min_height_px (%d) cannot be greater than max_height_px (%d) for webcam camera",
+			c.MinHeight, c.MaxHeight)
+	}
+	if c.FrameRate < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative frame rate (%.2f) field set for webcam camera",
+			c.FrameRate)
+	}
+	if c.MaxFPS < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative max fps (%.2f) field set for webcam camera",
+			c.MaxFPS)
+	}
+	if c.ReconnectAttempts < 0 || c.ReconnectIntervalMs < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative values for reconnect_attempts and reconnect_interval_ms (%d, %d) fields set for webcam camera",
+			c.ReconnectAttempts, c.ReconnectIntervalMs)
+	}
+	if c.DefaultHFOVDegrees < 0 || c.DefaultHFOVDegrees >= 180 {
+		return nil, fmt.Errorf(
+			"got illegal default_hfov_degrees (%.2f) field set for webcam camera, must be between 0 and 180",
+			c.DefaultHFOVDegrees)
+	}
+	switch c.Rotation {
+	case 0, 90, 180, 270:
+	default:
+		return nil, fmt.Errorf(
+			"got illegal rotation_degrees (%d) field set for webcam camera, must be one of 0, 90, 180 or 270",
+			c.Rotation)
+	}
+	if c.OpenTimeoutMs < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative open_timeout_ms (%d) field set for webcam camera",
+			c.OpenTimeoutMs)
+	}
+	if c.BufferSize < 0 {
+		return nil, fmt.Errorf(
+			"got illegal negative buffer_size (%d) field set for webcam camera",
+			c.BufferSize)
+	}
+	if c.FrameHistorySize < 0 || c.FrameHistorySize > maxFrameHistorySize {
+		return nil, fmt.Errorf(
+			"got illegal frame_history_size (%d) field set for webcam camera, must be between 0 and %d",
+			c.FrameHistorySize, maxFrameHistorySize)
+	}
+	for _, f := range c.FormatPriority {
+		if !slices.Contains(validFrameFormats, frame.Format(f)) {
+			return nil, fmt.Errorf(
+				"got unknown format %q in format_priority field set for webcam camera, valid formats are %v",
+				f, validFrameFormats)
+		}
+	}
+	if (c.VendorID == "") != (c.ProductID == "") {
+		return nil, fmt.Errorf(
+			"vendor_id and product_id (%q, %q) must be set together for webcam camera",
+			c.VendorID, c.ProductID)
+	}
+	if c.PreferHighestResolution && (c.Width != 0 || c.Height != 0) {
+		return nil, fmt.Errorf(
+			"prefer_highest_resolution cannot be set along with width_px and height_px (%d, %d) for webcam camera",
+			c.Width, c.Height)
+	}
+	if err := validateControls(c.Controls); err != nil {
+		return nil, err
+	}
+	if c.ROI != nil {
+		if c.ROI.X < 0 || c.ROI.Y < 0 || c.ROI.Width <= 0 || c.ROI.Height <= 0 {
+			return nil, fmt.Errorf(
+				"got illegal roi (x %d, y %d, width %d, height %d) field set for webcam camera, "+
+					"the offset can't be negative and the size must be positive",
+				c.ROI.X, c.ROI.Y, c.ROI.Width, c.ROI.Height)
+		}
+		if c.Width > 0 && c.Height > 0 {
+			if err := c.ROI.fitsIn(c.Width, c.Height); err != nil {
+				return nil, errors.Wrap(err, "roi must fit within width_px and height_px for webcam camera")
+			}
+		}
+	}
+	if c.ScaleIntrinsics && c.CameraParameters == nil {
+		return nil, errors.New("scale_intrinsics requires intrinsic_parameters to be set for webcam camera")
+	}
+	if c.Undistort {
+		if c.CameraParameters == nil || c.DistortionParameters == nil {
+			return nil, errors.New("undistort requires both intrinsic_parameters and distortion_parameters to be set for webcam camera")
+		}
+		if err := c.CameraParameters.CheckValid(); err != nil {
+			return nil, errors.Wrap(err, "undistort requires valid intrinsic_parameters for webcam camera")
+		}
+	}
 
 	return []string{}, nil
 }
 
+// reportedDistortion returns the distortion of the images the camera returns, which is none once they are undistorted.
+func (c WebcamConfig) reportedDistortion() *transform.BrownConrady {
+	if c.Undistort {
+		return nil
+	}
+	return c.DistortionParameters
+}
+
+// outputIntrinsics returns the intrinsics of the frames the camera returns, given those of the frames the driver delivers. Frames are
+// cropped to the region of interest and then rotated.
+func (c WebcamConfig) outputIntrinsics(intrinsics *transform.PinholeCameraIntrinsics) *transform.PinholeCameraIntrinsics {
+	return rotateIntrinsics(c.ROI.cropIntrinsics(intrinsics), c.Rotation)
+}
+
+// frameIntrinsics returns the configured intrinsics for frames of the given size, as the driver delivers them. Intrinsics for frames
+// of a different size are scaled to the given size if scale_intrinsics is set, which requires the same aspect ratio. Otherwise they
+// are returned unchanged with matches false, so that the mismatch can be warned about, unless they are needed to undistort frames.
+func (c WebcamConfig) frameIntrinsics(width, height int) (intrinsics *transform.PinholeCameraIntrinsics, matches bool, err error) {
+	params := c.CameraParameters
+	if params == nil || width == 0 || height == 0 || (params.Width == width && params.Height == height) {
+		return params, true, nil
+	}
+	if !c.ScaleIntrinsics {
+		if c.Undistort {
+			return nil, false, errors.Errorf(
+				"intrinsic_parameters are for %dx%d frames but the webcam delivers %dx%d frames, which cannot be undistorted; "+
+					"set scale_intrinsics to scale them", params.Width, params.Height, width, height)
+		}
+		return params, false, nil
+	}
+	const aspectRatioTolerance = 0.01
+	if math.Abs(float64(params.Width)/float64(params.Height)-float64(width)/float64(height)) > aspectRatioTolerance {
+		return nil, false, errors.Errorf(
+			"intrinsic_parameters for %dx%d frames cannot be scaled to the %dx%d frames the webcam delivers, "+
+				"which have a different aspect ratio", params.Width, params.Height, width, height)
+	}
+	scaleX := float64(width) / float64(params.Width)
+	scaleY := float64(height) / float64(params.Height)
+	return &transform.PinholeCameraIntrinsics{
+		Width:  width,
+		Height: height,
+		Fx:     params.Fx * scaleX,
+		Fy:     params.Fy * scaleY,
+		Ppx:    params.Ppx * scaleX,
+		Ppy:    params.Ppy * scaleY,
+	}, true, nil
+}
+
+// hasUSBID returns whether the config selects a device by its USB vendor and product IDs.
+func (c WebcamConfig) hasUSBID() bool {
+	return c.VendorID != "" && c.ProductID != ""
+}
+
+// reconnectAttempts returns how many times the camera should try to reopen a disconnected device, with a growing backoff, before
+// reporting it as lost. It keeps trying at maxReconnectInterval afterwards.
+func (c WebcamConfig) reconnectAttempts() int {
+	if c.ReconnectAttempts > 0 {
+		return c.ReconnectAttempts
+	}
+	return defaultReconnectAttempts
+}
+
+// reconnectInterval returns the wait before the first reconnect attempt; it doubles after every failed attempt.
+func (c WebcamConfig) reconnectInterval() time.Duration {
+	if c.ReconnectIntervalMs > 0 {
+		return time.Duration(c.ReconnectIntervalMs) * time.Millisecond
+	}
+	return defaultReconnectInterval
+}
+
+// openTimeout returns how long opening a device may take before it is abandoned.
+func (c WebcamConfig) openTimeout() time.Duration {
+	if c.OpenTimeoutMs > 0 {
+		return time.Duration(c.OpenTimeoutMs) * time.Millisecond
+	}
+	return defaultOpenTimeout
+}
+
 func (c WebcamConfig) needsDriverReinit(other WebcamConfig) bool {
 	return !(c.Format == other.Format &&
+		c.FormatFallback == other.FormatFallback &&
+		c.OpenTimeoutMs == other.OpenTimeoutMs &&
+		slices.Equal(c.FormatPriority, other.FormatPriority) &&
 		c.Path == other.Path &&
+		c.VendorID == other.VendorID &&
+		c.ProductID == other.ProductID &&
 		c.Width == other.Width &&
-		c.Height == other.Height)
+		c.Height == other.Height &&
+		c.PreferHighestResolution == other.PreferHighestResolution &&
+		c.MinWidth == other.MinWidth &&
+		c.MaxWidth == other.MaxWidth &&
+		c.MinHeight == other.MinHeight &&
+		c.MaxHeight == other.MaxHeight &&
+		c.FrameRate == other.FrameRate &&
+		c.MaxFPS == other.MaxFPS &&
+		c.BufferSize == other.BufferSize &&
+		c.Rotation == other.Rotation &&
+		reflect.DeepEqual(c.ROI, other.ROI) &&
+		c.Undistort == other.Undistort &&
+		// the undistortion map is computed from the camera model when the device is opened
+		(!c.Undistort || (reflect.DeepEqual(c.CameraParameters, other.CameraParameters) &&
+			reflect.DeepEqual(c.DistortionParameters, other.DistortionParameters) &&
+			c.ScaleIntrinsics == other.ScaleIntrinsics)) &&
+		c.DefaultHFOVDegrees == other.DefaultHFOVDegrees)
+}
+
+// The resolution a webcam asks for when its config sets no width_px or height_px, unless changed with SetDefaultResolution.
+const (
+	DefaultWidth  = 640
+	DefaultHeight = 480
+)
+
+var (
+	defaultResolutionMu sync.Mutex
+	defaultWidth        = DefaultWidth
+	defaultHeight       = DefaultHeight
+)
+
+// SetDefaultResolution changes the resolution that webcams opened afterwards ask for when their config sets no width_px or
+// height_px, for deployments that prefer another default. Webcams which set width_px or height_px are not affected, and the
+// default is still kept within any configured min and max bounds.
+func SetDefaultResolution(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return errors.Errorf("default resolution must be positive, got %dx%d", width, height)
+	}
+	defaultResolutionMu.Lock()
+	defer defaultResolutionMu.Unlock()
+	defaultWidth, defaultHeight = width, height
+	return nil
 }
 
 func makeConstraints(conf *WebcamConfig, debug bool, logger logging.Logger) mediadevices.MediaStreamConstraints {
+	defaultResolutionMu.Lock()
+	idealWidth, idealHeight := defaultWidth, defaultHeight
+	defaultResolutionMu.Unlock()
 	return mediadevices.MediaStreamConstraints{
 		Video: func(constraint *mediadevices.MediaTrackConstraints) {
 			if conf.Width > 0 {
 				constraint.Width = prop.IntExact(conf.Width)
 			} else {
-				constraint.Width = prop.IntRanged{Min: 0, Ideal: 640, Max: 4096}
+				constraint.Width = makeDimensionRange(conf.MinWidth, conf.MaxWidth, idealWidth, 4096)
 			}
 
 			if conf.Height > 0 {
 				constraint.Height = prop.IntExact(conf.Height)
 			} else {
-				constraint.Height = prop.IntRanged{Min: 0, Ideal: 480, Max: 2160}
+				constraint.Height = makeDimensionRange(conf.MinHeight, conf.MaxHeight, idealHeight, 2160)
 			}
 
 			if conf.FrameRate > 0.0 {
-				constraint.FrameRate = prop.FloatExact(conf.FrameRate)
+				// cap the rate at the configured value so drivers don't deliver more frames than asked for
+				constraint.FrameRate = prop.FloatRanged{Min: 0.0, Ideal: conf.FrameRate, Max: conf.FrameRate}
 			} else {
 				constraint.FrameRate = prop.FloatRanged{Min: 0.0, Ideal: 30.0, Max: 140.0}
 			}
 
 			if conf.Format == "" {
-				constraint.FrameFormat = prop.FrameFormatOneOf{
-					frame.FormatI420,
-					frame.FormatI444,
-					frame.FormatYUY2,
-					frame.FormatUYVY,
-					frame.FormatRGBA,
-					frame.FormatMJPEG,
-					frame.FormatNV12,
-					frame.FormatNV21,
-					frame.FormatZ16,
+				formats := defaultFormatPriority
+				if len(conf.FormatPriority) > 0 {
+					formats = make([]frame.Format, 0, len(conf.FormatPriority))
+					for _, f := range conf.FormatPriority {
+						formats = append(formats, frame.Format(f))
+					}
 				}
+				constraint.FrameFormat = prop.FrameFormatOneOf(formats)
 			} else {
 				constraint.FrameFormat = prop.FrameFormatExact(conf.Format)
 			}
@@ -238,180 +760,960 @@ func makeConstraints(conf *WebcamConfig, debug bool, logger logging.Logger) medi
 	}
 }
 
-// findAndMakeVideoSource finds a video device and returns a video source with that video device as the source.
+// estimateIntrinsics returns approximate pinhole intrinsics for an uncalibrated camera from its
+// resolution and horizontal field of view, assuming square pixels and a centered principal point.
+func estimateIntrinsics(width, height int, hfovDegrees float64) *transform.PinholeCameraIntrinsics {
+	fx := float64(width) / 2 / math.Tan(utils.DegToRad(hfovDegrees)/2)
+	return &transform.PinholeCameraIntrinsics{
+		Width:  width,
+		Height: height,
+		Fx:     fx,
+		Fy:     fx,
+		Ppx:    float64(width) / 2,
+		Ppy:    float64(height) / 2,
+	}
+}
+
+// makeDimensionRange builds a range constraint from optional configured bounds, falling back to the
+// given defaults for any bound that is unset. The ideal value is kept within the resulting range.
+func makeDimensionRange(minVal, maxVal, defaultIdeal, defaultMax int) prop.IntRanged {
+	if maxVal == 0 {
+		maxVal = defaultMax
+	}
+	ideal := defaultIdeal
+	if ideal < minVal {
+		ideal = minVal
+	}
+	if ideal > maxVal {
+		ideal = maxVal
+	}
+	return prop.IntRanged{Min: minVal, Ideal: ideal, Max: maxVal}
+}
+
+// findAndMakeVideoSource finds a video device and returns a video source with that video device as the source, along with its label
+// and the properties of the frames the driver delivers before they are cropped or rotated.
 func findAndMakeVideoSource(
 	ctx context.Context,
 	conf *WebcamConfig,
 	label string,
+	getDrivers func() []driver.Driver,
+	getSource VideoSourceGetter,
 	logger logging.Logger,
-) (gostream.VideoSource, string, error) {
+) (gostream.VideoSource, string, prop.Video, error) {
 	mediadevicescamera.Initialize()
-	debug := conf.Debug
-	constraints := makeConstraints(conf, debug, logger)
 	if label != "" {
-		cam, err := tryWebcamOpen(ctx, conf, label, false, constraints, logger)
+		if conf.Path != "" && conf.hasUSBID() {
+			logger.Warnw("both video_path and vendor_id/product_id are set, using video_path",
+				"video_path", conf.Path, "vendor_id", conf.VendorID, "product_id", conf.ProductID)
+		}
+		cam, raw, err := openWebcam(ctx, conf, label, false, getDrivers, getSource, logger)
 		if err != nil {
-			return nil, "", errors.Wrap(err, "cannot open webcam")
+			return nil, "", prop.Video{}, explainMissingWebcam(err, getDrivers(), resolveVideoName(label, false))
+		}
+		return cam, label, raw, nil
+	}
+
+	if conf.hasUSBID() {
+		drivers := getDrivers()
+		usbLabel, err := findLabelByUSBID(drivers, conf.VendorID, conf.ProductID)
+		if err != nil {
+			return nil, "", prop.Video{}, explainMissingWebcam(err, drivers, "")
+		}
+		cam, raw, err := openWebcam(ctx, conf, usbLabel, true, getDrivers, getSource, logger)
+		if err != nil {
+			return nil, "", prop.Video{}, err
+		}
+		return cam, usbLabel, raw, nil
+	}
+
+	constraints := makeConstraints(conf, conf.Debug, logger)
+	source, err := getSource("", constraints, logger)
+	if err != nil {
+		return nil, "", prop.Video{}, explainMissingWebcam(errors.Wrap(err, "found no webcams"), getDrivers(), "")
+	}
+	raw := videoSourceProperties(source)
+	source, err = wrapOpenedVideoSource(source, conf, logger)
+	if err != nil {
+		return nil, "", prop.Video{}, err
+	}
+
+	if label == "" {
+		label = getLabelFromVideoSource(source, logger)
+	}
+
+	return source, label, raw, nil
+}
+
+// explainMissingWebcam adds to an error finding or opening a webcam whether there are no video devices at all, which is usually a
+// hardware or permissions problem, or whether there are devices but none of them is the named one, which is usually a configuration
+// problem. The error is returned as is when the named device exists, or no name is given and there are devices.
+func explainMissingWebcam(err error, drivers []driver.Driver, name string) error {
+	if len(drivers) == 0 {
+		return errors.Wrap(err, "no video devices were found, check that a webcam is connected and that this process can access it")
+	}
+	if name == "" {
+		return err
+	}
+	var labels []string
+	for _, d := range drivers {
+		if slices.Contains(strings.Split(d.Info().Label, mediadevicescamera.LabelSeparator), name) {
+			return err
+		}
+		labels = append(labels, d.Info().Label)
+	}
+	return errors.Wrapf(err, "no video device is named %q, check video_path against the available devices %v", name, labels)
+}
+
+// openWebcam opens the webcam at path with the configured format. If that fails and format_fallback is set, opening is retried
+// once with the formats from format_priority, or the default formats, in place of the exact format.
+func openWebcam(
+	ctx context.Context,
+	conf *WebcamConfig,
+	path string,
+	fromLabel bool,
+	getDrivers func() []driver.Driver,
+	getSource VideoSourceGetter,
+	logger logging.Logger,
+) (gostream.VideoSource, prop.Video, error) {
+	cam, raw, err := openWebcamWithFormat(ctx, conf, path, fromLabel, getDrivers, getSource, logger)
+	if err == nil || !conf.FormatFallback || conf.Format == "" {
+		return cam, raw, err
+	}
+	logger.Warnw("cannot open webcam with the configured format, falling back to other formats",
+		"path", path, "format", conf.Format, "error", err)
+	fallbackConf := *conf
+	fallbackConf.Format = ""
+	cam, raw, fallbackErr := openWebcamWithFormat(ctx, &fallbackConf, path, fromLabel, getDrivers, getSource, logger)
+	if fallbackErr != nil {
+		return nil, prop.Video{}, multierr.Combine(err, errors.Wrap(fallbackErr, "format fallback failed"))
+	}
+	return cam, raw, nil
+}
+
+// openWebcamWithFormat checks that the named device supports the configured format and then opens it.
+func openWebcamWithFormat(
+	ctx context.Context,
+	conf *WebcamConfig,
+	path string,
+	fromLabel bool,
+	getDrivers func() []driver.Driver,
+	getSource VideoSourceGetter,
+	logger logging.Logger,
+) (gostream.VideoSource, prop.Video, error) {
+	name := path
+	if !fromLabel {
+		name = resolveVideoName(path, false)
+	}
+	drivers := getDrivers()
+	if err := checkFormatSupported(conf, drivers, name); err != nil {
+		return nil, prop.Video{}, err
+	}
+	if conf.PreferHighestResolution {
+		width, height, err := highestResolution(conf, drivers, name)
+		if err != nil {
+			logger.Warnw("cannot find the highest resolution of webcam, using the default resolution", "path", path, "error", err)
+		} else {
+			logger.Debugw("opening webcam at its highest resolution", "path", path, "width", width, "height", height)
+			pinned := *conf
+			pinned.Width, pinned.Height = width, height
+			conf = &pinned
+		}
+	}
+	constraints := makeConstraints(conf, conf.Debug, logger)
+	cam, raw, err := tryWebcamOpen(ctx, conf, path, fromLabel, constraints, getSource, logger)
+	if err != nil {
+		return nil, prop.Video{}, errors.Wrap(err, "cannot open webcam")
+	}
+	return cam, raw, nil
+}
+
+// checkFormatSupported returns a descriptive error if the configured format is not one the named device
+// reports supporting. The check is skipped in debug mode, or when the device or its formats cannot be found,
+// leaving it to the driver to report the problem.
+func checkFormatSupported(conf *WebcamConfig, drivers []driver.Driver, name string) error {
+	if conf.Format == "" || conf.Debug {
+		return nil
+	}
+	for _, d := range drivers {
+		labels := strings.Split(d.Info().Label, mediadevicescamera.LabelSeparator)
+		if !slices.Contains(labels, name) {
+			continue
+		}
+		props, err := getProperties(d)
+		if err != nil || len(props) == 0 {
+			return nil
+		}
+		var supported []string
+		for _, p := range props {
+			if format := string(p.FrameFormat); !slices.Contains(supported, format) {
+				supported = append(supported, format)
+			}
+		}
+		if !slices.Contains(supported, conf.Format) {
+			return errors.Errorf("requested format %q is not supported by webcam %q, supported formats are %v",
+				conf.Format, name, supported)
+		}
+		return nil
+	}
+	return nil
+}
+
+// highestResolution returns the largest resolution that the named device reports supporting in the configured format, if any,
+// and within the configured bounds.
+func highestResolution(conf *WebcamConfig, drivers []driver.Driver, name string) (int, int, error) {
+	inBounds := func(v, minVal, maxVal int) bool {
+		return v >= minVal && (maxVal == 0 || v <= maxVal)
+	}
+	for _, d := range drivers {
+		labels := strings.Split(d.Info().Label, mediadevicescamera.LabelSeparator)
+		if !slices.Contains(labels, name) {
+			continue
+		}
+		props, err := getProperties(d)
+		if err != nil {
+			return 0, 0, err
+		}
+		var best prop.Video
+		for _, p := range props {
+			if conf.Format != "" && string(p.FrameFormat) != conf.Format {
+				continue
+			}
+			if !inBounds(p.Width, conf.MinWidth, conf.MaxWidth) || !inBounds(p.Height, conf.MinHeight, conf.MaxHeight) {
+				continue
+			}
+			if p.Width*p.Height > best.Width*best.Height {
+				best = p.Video
+			}
+		}
+		if best.Width == 0 {
+			return 0, 0, errors.Errorf("webcam %q reports no resolutions within the configured format and bounds", name)
+		}
+		return best.Width, best.Height, nil
+	}
+	return 0, 0, errors.Errorf("found no driver for webcam %q", name)
+}
+
+// findLabelByUSBID returns the label of the first driver whose device reports the given USB vendor and product IDs.
+func findLabelByUSBID(drivers []driver.Driver, vendorID, productID string) (string, error) {
+	for _, d := range drivers {
+		labels := strings.Split(d.Info().Label, mediadevicescamera.LabelSeparator)
+		device := labels[len(labels)-1]
+		vendor, product, err := readUSBID(device)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(vendor, vendorID) && strings.EqualFold(product, productID) {
+			return device, nil
+		}
+	}
+	return "", errors.Errorf("found no webcam with vendor_id %q and product_id %q", vendorID, productID)
+}
+
+// readUSBID looks up the USB vendor and product IDs of a video4linux device (e.g. video0) in sysfs.
+func readUSBID(device string) (string, string, error) {
+	// the device link points at the USB interface; the IDs live on its parent USB device
+	iface, err := filepath.EvalSymlinks(filepath.Join("/sys/class/video4linux", device, "device"))
+	if err != nil {
+		return "", "", err
+	}
+	usbDevice := filepath.Dir(iface)
+	vendor, err := os.ReadFile(filepath.Join(usbDevice, "idVendor"))
+	if err != nil {
+		return "", "", err
+	}
+	product, err := os.ReadFile(filepath.Join(usbDevice, "idProduct"))
+	if err != nil {
+		return "", "", err
+	}
+	return strings.TrimSpace(string(vendor)), strings.TrimSpace(string(product)), nil
+}
+
+// getLabelFromVideoSource returns the path from the camera or an empty string if a path is not found.
+func getLabelFromVideoSource(src gostream.VideoSource, logger logging.Logger) string {
+	labels, err := gostream.LabelsFromMediaSource[image.Image, prop.Video](src)
+	if err != nil || len(labels) == 0 {
+		logger.Errorw("could not get labels from media source", "error", err)
+		return ""
+	}
+
+	return labels[0]
+}
+
+// NewWebcam returns a new source based on a webcam discovered from the given config.
+func NewWebcam(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	logger logging.Logger,
+) (camera.Camera, error) {
+	return NewWebcamWithSources(ctx, deps, conf, getVideoDrivers, getVideoSource, logger)
+}
+
+// NewWebcamWithSources returns a new webcam like NewWebcam, but looks up drivers with getDrivers
+// and opens video sources with getSource instead of going to the system's devices.
+func NewWebcamWithSources(
+	ctx context.Context,
+	deps resource.Dependencies,
+	conf resource.Config,
+	getDrivers func() []driver.Driver,
+	getSource VideoSourceGetter,
+	logger logging.Logger,
+) (camera.Camera, error) {
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cam := &monitoredWebcam{
+		Named:          conf.ResourceName().AsNamed(),
+		logger:         logging.FromZapCompatible(logger.With("camera_name", conf.ResourceName().ShortName())),
+		originalLogger: logger,
+		cancelCtx:      cancelCtx,
+		cancel:         cancel,
+		getDrivers:     getDrivers,
+		getSource:      getSource,
+	}
+	if err := cam.Reconfigure(ctx, deps, conf); err != nil {
+		return nil, err
+	}
+	cam.Monitor()
+	cam.recordFrameHistory()
+
+	s, err := cam.Stream(ctx)
+	if err != nil {
+		if err := debugLogger.GLoggerCamComp.Log("camera test results",
+			debugLogger.InfoMap{
+				"name":  cam.Name().Name,
+				"error": fmt.Sprint(err),
+			},
+		); err != nil {
+			logger.Debug(err)
+		}
+		return cam, nil
+	}
+
+	img, _, err := s.Next(ctx)
+	if err := debugLogger.GLoggerCamComp.Log("camera test results",
+		debugLogger.InfoMap{
+			"camera name":        cam.Name().Name,
+			"has non-nil image?": fmt.Sprintf("%t", img != nil),
+			"error:":             fmt.Sprintf("%s", err),
+		},
+	); err != nil {
+		logger.Debug(err)
+	}
+
+	return cam, nil
+}
+
+type noopCloser struct {
+	gostream.VideoSource
+}
+
+func (n *noopCloser) Close(ctx context.Context) error {
+	return nil
+}
+
+func (c *monitoredWebcam) Reconfigure(
+	ctx context.Context,
+	_ resource.Dependencies,
+	conf resource.Config,
+) error {
+	newConf, err := resource.NativeConfig[*WebcamConfig](conf)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	needDriverReinit := c.conf.needsDriverReinit(*newConf)
+	c.history.resize(newConf.FrameHistorySize)
+
+	if c.underlyingSource != nil && !needDriverReinit {
+		if err := c.updateProjector(ctx, newConf); err != nil {
+			return err
+		}
+		if !maps.Equal(c.conf.Controls, newConf.Controls) {
+			c.applyControls(ctx, newConf.Controls)
+		}
+		c.conf = *newConf
+		return nil
+	}
+	c.logger.CDebug(ctx, "reinitializing driver")
+
+	c.targetPath = newConf.Path
+	if err := c.reconnectCamera(newConf); err != nil {
+		return err
+	}
+
+	c.hasLoggedIntrinsicsInfo = false
+
+	// only set once we're good
+	c.conf = *newConf
+	return nil
+}
+
+// tryWebcamOpen uses getNamedVideoSource to try and find a video device (gostream.MediaSource).
+// If successful, it will wrap that MediaSource in a camera, returning the properties of the frames the driver delivers along
+// with it. Opening is abandoned once the configured open timeout passes so that a misbehaving driver cannot block forever.
+func tryWebcamOpen(
+	ctx context.Context,
+	conf *WebcamConfig,
+	path string,
+	fromLabel bool,
+	constraints mediadevices.MediaStreamConstraints,
+	getSource VideoSourceGetter,
+	logger logging.Logger,
+) (gostream.VideoSource, prop.Video, error) {
+	ctx, cancel := context.WithTimeout(ctx, conf.openTimeout())
+	defer cancel()
+
+	type openResult struct {
+		source gostream.VideoSource
+		err    error
+	}
+	opened := make(chan openResult, 1)
+	go func() {
+		source, err := getNamedVideoSource(path, fromLabel, constraints, getSource, logger)
+		opened <- openResult{source, err}
+	}()
+
+	var source gostream.VideoSource
+	select {
+	case res := <-opened:
+		if res.err != nil {
+			return nil, prop.Video{}, res.err
+		}
+		source = res.source
+	case <-ctx.Done():
+		logger.Warnw("timed out opening webcam", "path", path, "timeout", conf.openTimeout())
+		// close the device if the driver does eventually open it
+		go func() {
+			if res := <-opened; res.err == nil {
+				goutils.UncheckedError(res.source.Close(context.Background()))
+			}
+		}()
+		return nil, prop.Video{}, errors.Wrapf(ctx.Err(), "cannot open webcam %q", path)
+	}
+	warnOnConstraintMismatch(ctx, source, constraints, path, logger)
+
+	if conf.Width != 0 && conf.Height != 0 {
+		img, release, err := gostream.ReadMedia(ctx, source)
+		if release != nil {
+			defer release()
+		}
+		if err != nil {
+			return nil, prop.Video{}, err
+		}
+		if img.Bounds().Dx() != conf.Width || img.Bounds().Dy() != conf.Height {
+			return nil, prop.Video{}, errors.Errorf("requested width and height (%dx%d) are not available for this webcam"+
+				" (closest driver found by gostream supports resolution %dx%d)",
+				conf.Width, conf.Height, img.Bounds().Dx(), img.Bounds().Dy())
+		}
+	}
+	raw := videoSourceProperties(source)
+	wrapped, err := wrapOpenedVideoSource(source, conf, logger)
+	return wrapped, raw, err
+}
+
+// warnOnConstraintMismatch logs a warning if the video properties that the driver negotiated for a newly opened source do not
+// satisfy the constraints it was asked for, such as granting 1280x720 when 1920x1080 was asked for. Both are logged, since the
+// mismatch otherwise only shows up downstream, for instance as images that do not match the configured intrinsics.
+func warnOnConstraintMismatch(
+	ctx context.Context,
+	source gostream.VideoSource,
+	constraints mediadevices.MediaStreamConstraints,
+	path string,
+	logger logging.Logger,
+) {
+	provider, ok := source.(gostream.VideoPropertyProvider)
+	if !ok || constraints.Video == nil {
+		return
+	}
+	negotiated, err := provider.MediaProperties(ctx)
+	if err != nil {
+		return
+	}
+	var requested mediadevices.MediaTrackConstraints
+	constraints.Video(&requested)
+	if _, ok := requested.FitnessDistance(prop.Media{Video: negotiated}); ok {
+		return
+	}
+	logger.CWarnw(ctx, "webcam negotiated video properties that do not match the requested constraints",
+		"path", path,
+		"requested_width", fmt.Sprint(requested.Width),
+		"requested_height", fmt.Sprint(requested.Height),
+		"requested_frame_rate", fmt.Sprint(requested.FrameRate),
+		"requested_frame_format", fmt.Sprint(requested.FrameFormat),
+		"negotiated_width", negotiated.Width,
+		"negotiated_height", negotiated.Height,
+		"negotiated_frame_rate", negotiated.FrameRate,
+		"negotiated_frame_format", negotiated.FrameFormat,
+	)
+}
+
+// wrapOpenedVideoSource applies the configured undistortion, region of interest, software rotation, frame rate limit and
+// frame buffer to a newly opened source. The configured intrinsics are checked against the resolution the driver negotiated first.
+func wrapOpenedVideoSource(src gostream.VideoSource, conf *WebcamConfig, logger logging.Logger) (gostream.VideoSource, error) {
+	raw := videoSourceProperties(src)
+	intrinsics, matches, err := conf.frameIntrinsics(raw.Width, raw.Height)
+	if err != nil {
+		return nil, multierr.Combine(err, src.Close(context.Background()))
+	}
+	if !matches {
+		logger.Warnw("intrinsic_parameters do not match the resolution of the webcam, so projections will be wrong; "+
+			"set scale_intrinsics to scale them to the resolution",
+			"intrinsics_width", conf.CameraParameters.Width, "intrinsics_height", conf.CameraParameters.Height,
+			"width", raw.Width, "height", raw.Height)
+	}
+	if intrinsics != conf.CameraParameters {
+		scaled := *conf
+		scaled.CameraParameters = intrinsics
+		conf = &scaled
+	}
+	undistorted, err := undistortVideoSource(src, conf)
+	if err != nil {
+		return nil, err
+	}
+	cropped, err := cropVideoSource(undistorted, conf.ROI)
+	if err != nil {
+		return nil, err
+	}
+	return bufferVideoSource(throttleVideoSource(rotateVideoSource(cropped, conf.Rotation), conf.MaxFPS), conf.BufferSize), nil
+}
+
+// wrapVideoSource returns a source that reads from reader and reports the given properties, keeping a
+// reference to the driver of the wrapped source so that labels and intrinsics can still be looked up.
+func wrapVideoSource(src gostream.VideoSource, reader gostream.VideoReader, props prop.Video) gostream.VideoSource {
+	if d, err := gostream.DriverFromMediaSource[image.Image, prop.Video](src); err == nil {
+		return gostream.NewVideoSourceForDriver(d, reader, props)
+	}
+	return gostream.NewVideoSource(reader, props)
+}
+
+// closeWrappedVideoSource closes a source passed to wrapVideoSource. The wrapper still holds a reference
+// to the driver at that point and closes it afterwards, so the driver being in use is expected.
+func closeWrappedVideoSource(ctx context.Context, src gostream.VideoSource) error {
+	err := src.Close(ctx)
+	var inUseErr *gostream.DriverInUseError
+	if errors.As(err, &inUseErr) {
+		return nil
+	}
+	return err
+}
+
+// videoSourceProperties returns the video properties of the source, or empty properties if it has none.
+func videoSourceProperties(src gostream.VideoSource) prop.Video {
+	if provider, ok := src.(gostream.VideoPropertyProvider); ok {
+		if props, err := provider.MediaProperties(context.Background()); err == nil {
+			return props
+		}
+	}
+	return prop.Video{}
+}
+
+// undistortVideoSource wraps the source so that every frame is rectified with the configured distortion model, if undistort is
+// set. The lookup from rectified to distorted pixels is computed once here rather than for every frame. The original source is
+// returned otherwise.
+func undistortVideoSource(src gostream.VideoSource, conf *WebcamConfig) (gostream.VideoSource, error) {
+	if !conf.Undistort {
+		return src, nil
+	}
+	model := camera.NewPinholeModelWithBrownConradyDistortion(conf.CameraParameters, conf.DistortionParameters)
+	undistortion, err := model.UndistortionMap()
+	if err != nil {
+		return nil, multierr.Combine(errors.Wrap(err, "cannot undistort webcam"), src.Close(context.Background()))
+	}
+	reader := &undistortedVideoReader{
+		src:          src,
+		stream:       gostream.NewEmbeddedVideoStream(src),
+		undistortion: undistortion,
+	}
+	return wrapVideoSource(src, reader, videoSourceProperties(src)), nil
+}
+
+// undistortedVideoReader rectifies every frame read from its source.
+type undistortedVideoReader struct {
+	src          gostream.VideoSource
+	stream       gostream.VideoStream
+	undistortion *transform.UndistortionMap
+}
+
+func (r *undistortedVideoReader) Read(ctx context.Context) (image.Image, func(), error) {
+	img, release, err := r.stream.Next(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if release != nil {
+		// the rectified image is a copy, so the original can be released right away
+		defer release()
+	}
+	undistorted, err := r.undistortion.Undistort(img)
+	if err != nil {
+		return nil, nil, err
+	}
+	return undistorted, func() {}, nil
+}
+
+func (r *undistortedVideoReader) Close(ctx context.Context) error {
+	return multierr.Combine(r.stream.Close(ctx), closeWrappedVideoSource(ctx, r.src))
+}
+
+// cropVideoSource wraps the source so that every frame is cropped to the region of interest, which must fit within the resolution
+// the driver negotiated. The original source is returned if roi is not set.
+func cropVideoSource(src gostream.VideoSource, roi *RegionOfInterest) (gostream.VideoSource, error) {
+	if roi == nil {
+		return src, nil
+	}
+	props := videoSourceProperties(src)
+	if props.Width > 0 && props.Height > 0 {
+		if err := roi.fitsIn(props.Width, props.Height); err != nil {
+			return nil, multierr.Combine(errors.Wrap(err, "roi does not fit within the negotiated resolution"), src.Close(context.Background()))
 		}
-		return cam, label, nil
 	}
+	props.Width, props.Height = roi.Width, roi.Height
+	reader := &croppedVideoReader{
+		src:    src,
+		stream: gostream.NewEmbeddedVideoStream(src),
+		roi:    *roi,
+	}
+	return wrapVideoSource(src, reader, props), nil
+}
+
+// croppedVideoReader crops every frame read from its source to a region of interest.
+type croppedVideoReader struct {
+	src    gostream.VideoSource
+	stream gostream.VideoStream
+	roi    RegionOfInterest
+}
 
-	source, err := gostream.GetAnyVideoSource(constraints, logger.AsZap())
+func (r *croppedVideoReader) Read(ctx context.Context) (image.Image, func(), error) {
+	img, release, err := r.stream.Next(ctx)
 	if err != nil {
-		return nil, "", errors.Wrap(err, "found no webcams")
+		return nil, nil, err
 	}
-
-	if label == "" {
-		label = getLabelFromVideoSource(source, logger)
+	if release != nil {
+		// the cropped image is a copy, so the original can be released right away
+		defer release()
+	}
+	bounds := img.Bounds()
+	if err := r.roi.fitsIn(bounds.Dx(), bounds.Dy()); err != nil {
+		return nil, nil, err
 	}
+	return imaging.Crop(img, r.roi.rect().Add(bounds.Min)), func() {}, nil
+}
 
-	return source, label, nil
+func (r *croppedVideoReader) Close(ctx context.Context) error {
+	return multierr.Combine(r.stream.Close(ctx), closeWrappedVideoSource(ctx, r.src))
 }
 
-// getLabelFromVideoSource returns the path from the camera or an empty string if a path is not found.
-func getLabelFromVideoSource(src gostream.VideoSource, logger logging.Logger) string {
-	labels, err := gostream.LabelsFromMediaSource[image.Image, prop.Video](src)
-	if err != nil || len(labels) == 0 {
-		logger.Errorw("could not get labels from media source", "error", err)
-		return ""
+// rotateVideoSource wraps the source so that every frame is rotated clockwise by the given degrees,
+// swapping the reported width and height for quarter turns. The original source is returned if no
+// rotation is set.
+func rotateVideoSource(src gostream.VideoSource, degrees int) gostream.VideoSource {
+	if degrees == 0 {
+		return src
+	}
+	props := videoSourceProperties(src)
+	if degrees == 90 || degrees == 270 {
+		props.Width, props.Height = props.Height, props.Width
 	}
+	reader := &rotatedVideoReader{
+		src:     src,
+		stream:  gostream.NewEmbeddedVideoStream(src),
+		degrees: degrees,
+	}
+	return wrapVideoSource(src, reader, props)
+}
 
-	return labels[0]
+// rotateIntrinsics returns the intrinsics of frames rotated clockwise by the given degrees, given those of the frames before rotation.
+// Quarter turns swap the width, height and focal lengths, and the principal point moves with the pixels.
+func rotateIntrinsics(intrinsics *transform.PinholeCameraIntrinsics, degrees int) *transform.PinholeCameraIntrinsics {
+	if intrinsics == nil {
+		return nil
+	}
+	width, height := float64(intrinsics.Width), float64(intrinsics.Height)
+	rotated := *intrinsics
+	switch degrees {
+	case 90:
+		rotated.Width, rotated.Height = intrinsics.Height, intrinsics.Width
+		rotated.Fx, rotated.Fy = intrinsics.Fy, intrinsics.Fx
+		rotated.Ppx, rotated.Ppy = height-intrinsics.Ppy, intrinsics.Ppx
+	case 180:
+		rotated.Ppx, rotated.Ppy = width-intrinsics.Ppx, height-intrinsics.Ppy
+	case 270:
+		rotated.Width, rotated.Height = intrinsics.Height, intrinsics.Width
+		rotated.Fx, rotated.Fy = intrinsics.Fy, intrinsics.Fx
+		rotated.Ppx, rotated.Ppy = intrinsics.Ppy, width-intrinsics.Ppx
+	default:
+		return intrinsics
+	}
+	return &rotated
 }
 
-// NewWebcam returns a new source based on a webcam discovered from the given config.
-func NewWebcam(
-	ctx context.Context,
-	deps resource.Dependencies,
-	conf resource.Config,
-	logger logging.Logger,
-) (camera.Camera, error) {
-	cancelCtx, cancel := context.WithCancel(context.Background())
-	cam := &monitoredWebcam{
-		Named:          conf.ResourceName().AsNamed(),
-		logger:         logging.FromZapCompatible(logger.With("camera_name", conf.ResourceName().ShortName())),
-		originalLogger: logger,
-		cancelCtx:      cancelCtx,
-		cancel:         cancel,
+// rotateDistortion returns the distortion of frames rotated clockwise by the given degrees, given that of the frames before rotation.
+// Radial distortion is symmetric about the principal point and is unchanged, while the tangential coefficients turn with the image.
+func rotateDistortion(distortion *transform.BrownConrady, degrees int) *transform.BrownConrady {
+	if distortion == nil {
+		return nil
 	}
-	if err := cam.Reconfigure(ctx, deps, conf); err != nil {
-		return nil, err
+	rotated := *distortion
+	switch degrees {
+	case 90:
+		rotated.TangentialP1, rotated.TangentialP2 = distortion.TangentialP2, -distortion.TangentialP1
+	case 180:
+		rotated.TangentialP1, rotated.TangentialP2 = -distortion.TangentialP1, -distortion.TangentialP2
+	case 270:
+		rotated.TangentialP1, rotated.TangentialP2 = -distortion.TangentialP2, distortion.TangentialP1
+	default:
+		return distortion
 	}
-	cam.Monitor()
+	return &rotated
+}
 
-	s, err := cam.Stream(ctx)
+// rotatedVideoReader rotates every frame read from its source.
+type rotatedVideoReader struct {
+	src     gostream.VideoSource
+	stream  gostream.VideoStream
+	degrees int
+}
+
+func (r *rotatedVideoReader) Read(ctx context.Context) (image.Image, func(), error) {
+	img, release, err := r.stream.Next(ctx)
 	if err != nil {
-		if err := debugLogger.GLoggerCamComp.Log("camera test results",
-			debugLogger.InfoMap{
-				"name":  cam.Name().Name,
-				"error": fmt.Sprint(err),
-			},
-		); err != nil {
-			logger.Debug(err)
-		}
-		return cam, nil
+		return nil, nil, err
 	}
-
-	img, _, err := s.Next(ctx)
-	if err := debugLogger.GLoggerCamComp.Log("camera test results",
-		debugLogger.InfoMap{
-			"camera name":        cam.Name().Name,
-			"has non-nil image?": fmt.Sprintf("%t", img != nil),
-			"error:":             fmt.Sprintf("%s", err),
-		},
-	); err != nil {
-		logger.Debug(err)
+	if release != nil {
+		// the rotated image is a copy, so the original can be released right away
+		defer release()
+	}
+	// imaging rotates counter-clockwise while the configured rotation is clockwise
+	switch r.degrees {
+	case 90:
+		return imaging.Rotate270(img), func() {}, nil
+	case 180:
+		return imaging.Rotate180(img), func() {}, nil
+	case 270:
+		return imaging.Rotate90(img), func() {}, nil
+	default:
+		return nil, nil, errors.Errorf("unsupported rotation of %d degrees", r.degrees)
 	}
+}
 
-	return cam, nil
+func (r *rotatedVideoReader) Close(ctx context.Context) error {
+	return multierr.Combine(r.stream.Close(ctx), closeWrappedVideoSource(ctx, r.src))
 }
 
-type noopCloser struct {
-	gostream.VideoSource
+// throttleVideoSource wraps the source so that it delivers at most maxFPS frames per second, for drivers
+// that ignore the requested frame rate. Frames produced while waiting for the next slot are dropped.
+// The original source is returned if maxFPS is not set.
+func throttleVideoSource(src gostream.VideoSource, maxFPS float32) gostream.VideoSource {
+	if maxFPS <= 0 {
+		return src
+	}
+	reader := &throttledVideoReader{
+		src:      src,
+		interval: time.Duration(float64(time.Second) / float64(maxFPS)),
+	}
+	return wrapVideoSource(src, reader, videoSourceProperties(src))
 }
 
-func (n *noopCloser) Close(ctx context.Context) error {
-	return nil
+// throttledVideoReader reads from a persistent stream of its source no more often than its interval allows.
+type throttledVideoReader struct {
+	src      gostream.VideoSource
+	interval time.Duration
+
+	mu       sync.Mutex
+	stream   gostream.VideoStream
+	lastRead time.Time
 }
 
-func (c *monitoredWebcam) Reconfigure(
-	ctx context.Context,
-	_ resource.Dependencies,
-	conf resource.Config,
-) error {
-	newConf, err := resource.NativeConfig[*WebcamConfig](conf)
-	if err != nil {
-		return err
+func (r *throttledVideoReader) Read(ctx context.Context) (image.Image, func(), error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if wait := r.interval - time.Since(r.lastRead); wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, nil, ctx.Err()
+		case <-timer.C:
+		}
 	}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	cameraModel := camera.NewPinholeModelWithBrownConradyDistortion(newConf.CameraParameters, newConf.DistortionParameters)
-	projector, err := camera.WrapVideoSourceWithProjector(
-		ctx,
-		&noopCloser{c},
-		&cameraModel,
-		camera.ColorStream,
-	)
+	if r.stream == nil {
+		stream, err := r.src.Stream(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.stream = stream
+	}
+	img, release, err := r.stream.Next(ctx)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
+	r.lastRead = time.Now()
+	return img, release, nil
+}
 
-	needDriverReinit := c.conf.needsDriverReinit(*newConf)
-	if c.exposedProjector != nil {
-		goutils.UncheckedError(c.exposedProjector.Close(ctx))
+func (r *throttledVideoReader) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var err error
+	if r.stream != nil {
+		err = r.stream.Close(ctx)
+		r.stream = nil
 	}
-	c.exposedProjector = projector
+	return multierr.Combine(err, closeWrappedVideoSource(ctx, r.src))
+}
 
-	if c.underlyingSource != nil && !needDriverReinit {
-		c.conf = *newConf
-		return nil
+// bufferErrorBackoff is how long a buffered source waits after a failed read before reading again.
+const bufferErrorBackoff = 100 * time.Millisecond
+
+// bufferVideoSource wraps the source so that frames are read continuously in the background into a buffer holding up to size
+// frames, which reads are then served from in order. When the buffer is full the oldest frame is dropped, so a small buffer keeps
+// the frames read fresh while a larger one lets slow readers catch up after a stall at the cost of latency. The original source,
+// which is only read from when a frame is asked for, is returned if size is not set.
+func bufferVideoSource(src gostream.VideoSource, size int) gostream.VideoSource {
+	if size <= 0 {
+		return src
 	}
-	c.logger.CDebug(ctx, "reinitializing driver")
+	reader := &bufferedVideoReader{
+		src:    src,
+		frames: make(chan bufferedFrame, size),
+		closed: make(chan struct{}),
+	}
+	return wrapVideoSource(src, reader, videoSourceProperties(src))
+}
 
-	c.targetPath = newConf.Path
-	if err := c.reconnectCamera(newConf); err != nil {
-		return err
+// bufferedFrame is the result of a single read from the source of a buffered reader.
+type bufferedFrame struct {
+	img     image.Image
+	release func()
+	err     error
+}
+
+func (f bufferedFrame) releaseFrame() {
+	if f.release != nil {
+		f.release()
 	}
+}
 
-	c.hasLoggedIntrinsicsInfo = false
+// bufferedVideoReader fills its buffer from a persistent stream of its source, which is opened on the first read.
+type bufferedVideoReader struct {
+	src    gostream.VideoSource
+	frames chan bufferedFrame
+	// closed is closed when the reader is, to wake up any reads waiting on an empty buffer.
+	closed chan struct{}
+
+	mu      sync.Mutex
+	stream  gostream.VideoStream
+	cancel  func()
+	workers sync.WaitGroup
+}
 
-	// only set once we're good
-	c.conf = *newConf
-	return nil
+func (r *bufferedVideoReader) Read(ctx context.Context) (image.Image, func(), error) {
+	if err := r.start(); err != nil {
+		return nil, nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case <-r.closed:
+		return nil, nil, errors.New("buffered video source is closed")
+	case f := <-r.frames:
+		return f.img, f.release, f.err
+	}
 }
 
-// tryWebcamOpen uses getNamedVideoSource to try and find a video device (gostream.MediaSource).
-// If successful, it will wrap that MediaSource in a camera.
-func tryWebcamOpen(
-	ctx context.Context,
-	conf *WebcamConfig,
-	path string,
-	fromLabel bool,
-	constraints mediadevices.MediaStreamConstraints,
-	logger logging.Logger,
-) (gostream.VideoSource, error) {
-	source, err := getNamedVideoSource(path, fromLabel, constraints, logger)
+// start opens the stream of the source and starts filling the buffer from it, unless that has already happened.
+func (r *bufferedVideoReader) start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.closed:
+		return errors.New("buffered video source is closed")
+	default:
+	}
+	if r.stream != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := r.src.Stream(ctx)
 	if err != nil {
-		return nil, err
+		cancel()
+		return err
 	}
+	r.stream = stream
+	r.cancel = cancel
+	r.workers.Add(1)
+	go r.fill(ctx, stream)
+	return nil
+}
 
-	if conf.Width != 0 && conf.Height != 0 {
-		img, release, err := gostream.ReadMedia(ctx, source)
-		if release != nil {
-			defer release()
+// fill reads frames from the stream into the buffer until ctx is done. Read errors are buffered along with the frames, so that
+// readers see them in order.
+func (r *bufferedVideoReader) fill(ctx context.Context, stream gostream.VideoStream) {
+	defer r.workers.Done()
+	for {
+		img, release, err := stream.Next(ctx)
+		if ctx.Err() != nil {
+			bufferedFrame{release: release}.releaseFrame()
+			return
 		}
+		r.push(bufferedFrame{img: img, release: release, err: err})
 		if err != nil {
-			return nil, err
+			// wait before reading again, so that a failing stream is not spun on
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(bufferErrorBackoff):
+			}
 		}
-		if img.Bounds().Dx() != conf.Width || img.Bounds().Dy() != conf.Height {
-			return nil, errors.Errorf("requested width and height (%dx%d) are not available for this webcam"+
-				" (closest driver found by gostream supports resolution %dx%d)",
-				conf.Width, conf.Height, img.Bounds().Dx(), img.Bounds().Dy())
+	}
+}
+
+// push adds the frame to the buffer, dropping the oldest frame if the buffer is full. fill is the only writer to the buffer, so
+// there is always room for the frame once one has been dropped.
+func (r *bufferedVideoReader) push(f bufferedFrame) {
+	select {
+	case r.frames <- f:
+		return
+	default:
+	}
+	select {
+	case dropped := <-r.frames:
+		dropped.releaseFrame()
+	default:
+	}
+	r.frames <- f
+}
+
+// Close stops filling the buffer, waiting for the background reader to return, and releases every frame left in the buffer.
+func (r *bufferedVideoReader) Close(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	select {
+	case <-r.closed:
+		return nil
+	default:
+	}
+	close(r.closed)
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.workers.Wait()
+	for drained := false; !drained; {
+		select {
+		case f := <-r.frames:
+			f.releaseFrame()
+		default:
+			drained = true
 		}
 	}
-	return source, nil
+	var err error
+	if r.stream != nil {
+		err = r.stream.Close(ctx)
+		r.stream = nil
+	}
+	return multierr.Combine(err, closeWrappedVideoSource(ctx, r.src))
 }
 
 // getNamedVideoSource attempts to find a video device (not a screen) by the given name.
@@ -421,15 +1723,53 @@ func getNamedVideoSource(
 	path string,
 	fromLabel bool,
 	constraints mediadevices.MediaStreamConstraints,
+	getSource VideoSourceGetter,
 	logger logging.Logger,
 ) (gostream.MediaSource[image.Image], error) {
+	return getSource(resolveVideoName(path, fromLabel), constraints, logger)
+}
+
+// resolveVideoPath returns the path of the video device at path with any symbolic links resolved, or path itself if it is a label
+// rather than a path.
+func resolveVideoPath(path string) string {
+	if resolvedPath, err := filepath.EvalSymlinks(path); err == nil {
+		return resolvedPath
+	}
+	return path
+}
+
+// openedDevice describes the video device a webcam opened.
+type openedDevice struct {
+	// path is the path or label the device was found by, with any symbolic links resolved.
+	path string
+	// label is the label the driver of the device reports, which lists the names it is known by.
+	label    string
+	openedAt time.Time
+}
+
+// deviceInfo returns the device the camera was last opened on, as reported by the get_device command, so that it is clear which
+// device was chosen when the configured path is a symbolic link or the camera was found by USB ID or picked automatically.
+func (c *monitoredWebcam) deviceInfo() (map[string]interface{}, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.opened.openedAt.IsZero() {
+		return nil, errors.New("camera has not opened a device")
+	}
+	return map[string]interface{}{
+		"video_path":  c.conf.Path,
+		"device_path": c.opened.path,
+		"label":       c.opened.label,
+		"opened_at":   c.opened.openedAt.Format(time.RFC3339Nano),
+	}, nil
+}
+
+// resolveVideoName returns the name a video device is looked up by, resolving any symbolic links
+// in the path unless it is already a label.
+func resolveVideoName(path string, fromLabel bool) string {
 	if !fromLabel {
-		resolvedPath, err := filepath.EvalSymlinks(path)
-		if err == nil {
-			path = resolvedPath
-		}
+		path = resolveVideoPath(path)
 	}
-	return gostream.GetNamedVideoSource(filepath.Base(path), constraints, logger.AsZap())
+	return filepath.Base(path)
 }
 
 // monitoredWebcam tries to ensure its underlying camera stays connected.
@@ -446,14 +1786,84 @@ type monitoredWebcam struct {
 	// treats it as a video path.
 	targetPath string
 	conf       WebcamConfig
+	getDrivers func() []driver.Driver
+	getSource  VideoSourceGetter
+	// controlDevice is the device file that controls are set on.
+	controlDevice string
+	// negotiated holds the video properties the driver settled on when the camera was opened.
+	negotiated prop.Video
+	// rawProps are the properties of frames as the driver delivers them, before they are cropped or rotated.
+	rawProps prop.Video
+	// opened describes the device the camera was last opened on.
+	opened openedDevice
+	// estimatedIntrinsics are computed from default_hfov_degrees when no intrinsics are configured.
+	estimatedIntrinsics *transform.PinholeCameraIntrinsics
 
 	cancelCtx               context.Context
 	cancel                  func()
 	closed                  bool
 	disconnected            bool
+	reconnectExhausted      bool
 	activeBackgroundWorkers sync.WaitGroup
 	logger                  logging.Logger
 	originalLogger          logging.Logger
+
+	// frameMu guards the result of the most recent frame read, reported by the health command.
+	frameMu       sync.Mutex
+	lastFrameTime time.Time
+	lastFrameErr  error
+
+	// history keeps the most recent frames when frame_history_size is set.
+	history frameHistory
+}
+
+// recordFrame stores the outcome of a frame read for health reporting. It returns the time a successfully read frame
+// arrived from the driver, which is used as the frame's capture time, or the zero time if the read failed.
+func (c *monitoredWebcam) recordFrame(err error) time.Time {
+	c.frameMu.Lock()
+	defer c.frameMu.Unlock()
+	c.lastFrameErr = err
+	if err != nil {
+		return time.Time{}
+	}
+	c.lastFrameTime = time.Now()
+	return c.lastFrameTime
+}
+
+// health reports when the last frame was successfully read and the current error state, if any.
+func (c *monitoredWebcam) health() map[string]interface{} {
+	c.mu.RLock()
+	err := c.ensureActive()
+	c.mu.RUnlock()
+
+	c.frameMu.Lock()
+	defer c.frameMu.Unlock()
+	if err == nil {
+		err = c.lastFrameErr
+	}
+	resp := map[string]interface{}{
+		"last_frame_time": "",
+		"error":           "",
+	}
+	if !c.lastFrameTime.IsZero() {
+		resp["last_frame_time"] = c.lastFrameTime.Format(time.RFC3339Nano)
+	}
+	if err != nil {
+		resp["error"] = err.Error()
+	}
+	return resp
+}
+
+// frameTrackingStream records the outcome of every frame read from the wrapped stream.
+type frameTrackingStream struct {
+	gostream.VideoStream
+	record func(error) time.Time
+}
+
+func (s *frameTrackingStream) Next(ctx context.Context) (image.Image, func(), error) {
+	img, release, err := s.VideoStream.Next(ctx)
+	s.record(err)
+	return img, release, err
 }
 
 func (c *monitoredWebcam) MediaProperties(ctx context.Context) (prop.Video, error) {
@@ -472,7 +1882,8 @@ func (c *monitoredWebcam) isCameraConnected() (bool, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	if c.underlyingSource == nil {
-		return true, errors.New("no configured camera")
+		// the device could not be reopened after being lost, so it is still disconnected
+		return false, nil
 	}
 	d, err := gostream.DriverFromMediaSource[image.Image, prop.Video](c.underlyingSource)
 	if err != nil {
@@ -494,7 +1905,7 @@ func (c *monitoredWebcam) reconnectCamera(conf *WebcamConfig) error {
 		c.underlyingSource = nil
 	}
 
-	newSrc, foundLabel, err := findAndMakeVideoSource(c.cancelCtx, conf, c.targetPath, c.logger)
+	newSrc, foundLabel, raw, err := findAndMakeVideoSource(c.cancelCtx, conf, c.targetPath, c.getDrivers, c.getSource, c.logger)
 	if err != nil {
 		// If we are on a Jetson Orin AGX, we need to validate hardware/software setup.
 		// If not, simply pass through the error.
@@ -513,13 +1924,53 @@ func (c *monitoredWebcam) reconnectCamera(conf *WebcamConfig) error {
 		c.exposedSwapper.Swap(newSrc)
 	}
 	c.underlyingSource = newSrc
+	c.rawProps = raw
+	c.negotiated = prop.Video{}
+	if provider, ok := newSrc.(gostream.VideoPropertyProvider); ok {
+		if props, err := provider.MediaProperties(c.cancelCtx); err == nil {
+			c.negotiated = props
+		}
+	}
+	c.estimatedIntrinsics = nil
+	if conf.CameraParameters == nil && conf.DefaultHFOVDegrees > 0 && raw.Width > 0 && raw.Height > 0 {
+		// the field of view is that of the sensor, so the estimate is made for frames as the driver delivers them
+		c.estimatedIntrinsics = conf.outputIntrinsics(estimateIntrinsics(raw.Width, raw.Height, conf.DefaultHFOVDegrees))
+	}
 	c.disconnected = false
+	c.reconnectExhausted = false
 	c.closed = false
-	if c.targetPath == "" {
+	// devices selected by USB ID are looked up again on every reconnect since their video path can change
+	if c.targetPath == "" && !conf.hasUSBID() {
 		c.targetPath = foundLabel
 	}
-	c.logger = logging.FromZapCompatible(c.originalLogger.With("camera_label", c.targetPath))
+	c.logger = logging.FromZapCompatible(c.originalLogger.With("camera_label", foundLabel))
+	c.controlDevice = controlDevice(foundLabel)
+	c.opened = openedDevice{path: resolveVideoPath(foundLabel), openedAt: time.Now()}
+	if d, err := gostream.DriverFromMediaSource[image.Image, prop.Video](newSrc); err == nil {
+		c.opened.label = d.Info().Label
+	}
+	c.applyControls(c.cancelCtx, conf.Controls)
+
+	return c.updateProjector(c.cancelCtx, conf)
+}
 
+// updateProjector replaces the projector of the camera with one for the intrinsics of the frames it delivers, which are the configured
+// intrinsics scaled to the resolution of the device if needed and cropped to the region of interest. It assumes a write lock is held.
+func (c *monitoredWebcam) updateProjector(ctx context.Context, conf *WebcamConfig) error {
+	intrinsics, _, err := conf.frameIntrinsics(c.rawProps.Width, c.rawProps.Height)
+	if err != nil {
+		return err
+	}
+	cameraModel := camera.NewPinholeModelWithBrownConradyDistortion(
+		conf.outputIntrinsics(intrinsics), rotateDistortion(conf.reportedDistortion(), conf.Rotation))
+	projector, err := camera.WrapVideoSourceWithProjector(ctx, &noopCloser{c}, &cameraModel, camera.ColorStream)
+	if err != nil {
+		return err
+	}
+	if c.exposedProjector != nil {
+		goutils.UncheckedError(c.exposedProjector.Close(ctx))
+	}
+	c.exposedProjector = projector
 	return nil
 }
 
@@ -553,28 +2004,42 @@ func (c *monitoredWebcam) Monitor() {
 			if !ok {
 				c.mu.Lock()
 				c.disconnected = true
+				attempts, backoff := c.conf.reconnectAttempts(), c.conf.reconnectInterval()
 				c.mu.Unlock()
 
 				logger.Error("camera no longer connected; reconnecting")
-				for {
-					if !goutils.SelectContextOrWait(c.cancelCtx, wait) {
+				for attempt := 1; ; attempt++ {
+					if !goutils.SelectContextOrWait(c.cancelCtx, backoff) {
 						return
 					}
-					cont := func() bool {
+					reconnected := func() bool {
 						c.mu.Lock()
 						defer c.mu.Unlock()
 
 						if err := c.reconnectCamera(&c.conf); err != nil {
-							c.logger.Errorw("failed to reconnect camera", "error", err)
-							return true
+							c.logger.Errorw("failed to reconnect camera", "attempt", attempt, "error", err)
+							return false
 						}
-						c.logger.Infow("camera reconnected")
-						return false
+						c.logger.Infow("camera reconnected", "attempt", attempt)
+						return true
 					}()
-					if cont {
+					if reconnected {
+						break
+					}
+					if attempt == attempts {
+						// the device may still be plugged back in, so keep trying at the slowest rate and only report the failures
+						c.mu.Lock()
+						c.reconnectExhausted = true
+						c.mu.Unlock()
+						logger.Errorw("cannot reconnect camera; retrying less often until it comes back",
+							"attempts", attempts, "retry_interval", maxReconnectInterval)
+						backoff = maxReconnectInterval
 						continue
 					}
-					break
+					backoff *= 2
+					if backoff > maxReconnectInterval {
+						backoff = maxReconnectInterval
+					}
 				}
 			}
 		}
@@ -590,11 +2055,26 @@ func (c *monitoredWebcam) Projector(ctx context.Context) (transform.Projector, e
 	return c.exposedProjector.Projector(ctx)
 }
 
+// Images returns the next frame from the webcam. The capture time in the metadata is when the frame arrived from the driver,
+// and matches the last_frame_time reported by the health command.
 func (c *monitoredWebcam) Images(ctx context.Context) ([]camera.NamedImage, resource.ResponseMetadata, error) {
-	if c, ok := c.underlyingSource.(camera.ImagesSource); ok {
-		return c.Images(ctx)
+	c.mu.RLock()
+	if err := c.ensureActive(); err != nil {
+		c.mu.RUnlock()
+		return nil, resource.ResponseMetadata{}, err
 	}
-	img, release, err := camera.ReadImage(ctx, c.underlyingSource)
+	underlying := c.underlyingSource
+	c.mu.RUnlock()
+	if src, ok := underlying.(camera.ImagesSource); ok {
+		imgs, metadata, err := src.Images(ctx)
+		capturedAt := c.recordFrame(err)
+		if err == nil && metadata.CapturedAt.IsZero() {
+			metadata.CapturedAt = capturedAt
+		}
+		return imgs, metadata, err
+	}
+	img, release, err := camera.ReadImage(ctx, underlying)
+	capturedAt := c.recordFrame(err)
 	if err != nil {
 		return nil, resource.ResponseMetadata{}, errors.Wrap(err, "monitoredWebcam: call to get Images failed")
 	}
@@ -603,7 +2083,7 @@ func (c *monitoredWebcam) Images(ctx context.Context) ([]camera.NamedImage, reso
 			release()
 		}
 	}()
-	return []camera.NamedImage{{img, c.Name().Name}}, resource.ResponseMetadata{time.Now()}, nil
+	return []camera.NamedImage{{img, c.Name().Name}}, resource.ResponseMetadata{CapturedAt: capturedAt}, nil
 }
 
 func (c *monitoredWebcam) Stream(ctx context.Context, errHandlers ...gostream.ErrorHandler) (gostream.VideoStream, error) {
@@ -612,7 +2092,129 @@ func (c *monitoredWebcam) Stream(ctx context.Context, errHandlers ...gostream.Er
 	if err := c.ensureActive(); err != nil {
 		return nil, err
 	}
-	return c.exposedSwapper.Stream(ctx, errHandlers...)
+	stream, err := c.exposedSwapper.Stream(ctx, errHandlers...)
+	if err != nil {
+		return nil, err
+	}
+	return &frameTrackingStream{VideoStream: stream, record: c.recordFrame}, nil
+}
+
+func (c *monitoredWebcam) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	name, ok := cmd["command"]
+	if !ok {
+		return nil, errors.New("missing 'command' value")
+	}
+	switch name {
+	case "health":
+		return c.health(), nil
+	case "get_properties":
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		if err := c.ensureActive(); err != nil {
+			return nil, err
+		}
+		return c.negotiatedProperties(), nil
+	case "reconfigure":
+		return c.reopenWithSettings(ctx, cmd)
+	case "set_controls":
+		return c.setControls(ctx, cmd)
+	case "list_controls":
+		return c.listControls()
+	case "get_device":
+		return c.deviceInfo()
+	case "measure_fps":
+		return c.measureFPS(ctx, cmd)
+	case "list_drivers":
+		// the lock is only needed to read the path, as listing drivers does not touch this camera
+		c.mu.RLock()
+		targetPath := c.targetPath
+		c.mu.RUnlock()
+		drivers := make([]interface{}, 0)
+		for _, d := range ListDrivers(c.getDrivers) {
+			drivers = append(drivers, map[string]interface{}{
+				"label":   d.Label,
+				"status":  string(d.Status),
+				"in_use":  d.InUse(),
+				"current": targetPath != "" && slices.Contains(strings.Split(d.Label, mediadevicescamera.LabelSeparator), targetPath),
+			})
+		}
+		return map[string]interface{}{"drivers": drivers}, nil
+	default:
+		return nil, fmt.Errorf("no such command: %s", name)
+	}
+}
+
+// negotiatedProperties returns the video properties the driver settled on, as reported by the get_properties command.
+// It assumes a lock is held.
+func (c *monitoredWebcam) negotiatedProperties() map[string]interface{} {
+	return map[string]interface{}{
+		"width_px":     c.negotiated.Width,
+		"height_px":    c.negotiated.Height,
+		"frame_format": string(c.negotiated.FrameFormat),
+		"frame_rate":   c.negotiated.FrameRate,
+	}
+}
+
+// reconfigureCommand holds the settings that the reconfigure command may change. Settings which are left out keep their current
+// value, and a value of zero or an empty format clears the setting.
+type reconfigureCommand struct {
+	Width     *int     `json:"width"`
+	Height    *int     `json:"height"`
+	Format    *string  `json:"format"`
+	FrameRate *float32 `json:"frame_rate"`
+}
+
+// reopenWithSettings reopens the webcam with the resolution, format and frame rate given in cmd, without reconfiguring the rest of
+// the robot. Readers are held off until it returns, and if the webcam cannot be reopened with the new settings, it is reopened with
+// the previous ones.
+func (c *monitoredWebcam) reopenWithSettings(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	settings, err := resource.TransformAttributeMap[*reconfigureCommand](cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse reconfigure command")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ensureActive(); err != nil {
+		return nil, err
+	}
+
+	previous := c.conf
+	newConf := c.conf
+	if settings.Width != nil || settings.Height != nil {
+		// an explicit resolution takes the place of the highest one
+		newConf.PreferHighestResolution = false
+	}
+	if settings.Width != nil {
+		newConf.Width = *settings.Width
+	}
+	if settings.Height != nil {
+		newConf.Height = *settings.Height
+	}
+	if settings.Format != nil {
+		newConf.Format = *settings.Format
+	}
+	if settings.FrameRate != nil {
+		newConf.FrameRate = *settings.FrameRate
+	}
+	if _, err := newConf.Validate(""); err != nil {
+		return nil, err
+	}
+
+	c.logger.CInfow(ctx, "reopening webcam with new settings",
+		"width", newConf.Width, "height", newConf.Height, "format", newConf.Format, "frame_rate", newConf.FrameRate)
+	if err := c.reconnectCamera(&newConf); err != nil {
+		c.logger.CWarnw(ctx, "cannot reopen webcam with new settings, reverting to previous settings", "error", err)
+		if revertErr := c.reconnectCamera(&previous); revertErr != nil {
+			// the monitor keeps trying to reopen the device with the previous settings
+			c.disconnected = true
+			return nil, multierr.Combine(err, errors.Wrap(revertErr, "cannot reopen webcam with previous settings"))
+		}
+		return nil, errors.Wrap(err, "cannot reopen webcam with new settings, reverted to previous settings")
+	}
+	c.conf = newConf
+	c.hasLoggedIntrinsicsInfo = false
+	return c.negotiatedProperties(), nil
 }
 
 func (c *monitoredWebcam) NextPointCloud(ctx context.Context) (pointcloud.PointCloud, error) {
@@ -648,6 +2250,7 @@ func (c *monitoredWebcam) Properties(ctx context.Context) (camera.Properties, er
 	if err != nil {
 		return camera.Properties{}, err
 	}
+	props.MimeTypes = mimeTypesForFormat(c.negotiated.FrameFormat)
 	// Looking for intrinsics in map built using viam camera
 	// calibration here https://github.com/viam-labs/camera-calibration/tree/main
 	if props.IntrinsicParams == nil {
@@ -660,6 +2263,15 @@ func (c *monitoredWebcam) Properties(ctx context.Context) (camera.Properties, er
 		}
 
 		cameraIntrinsics, exists := data[dInfo.Name]
+		if !exists && c.estimatedIntrinsics != nil {
+			if !c.hasLoggedIntrinsicsInfo {
+				c.logger.CInfow(ctx, "camera model not found in known camera models, using intrinsics estimated from "+
+					"default_hfov_degrees", "model", dInfo.Name, "intrinsics", *c.estimatedIntrinsics)
+				c.hasLoggedIntrinsicsInfo = true
+			}
+			props.IntrinsicParams = c.estimatedIntrinsics
+			return props, nil
+		}
 		if !exists {
 			if !c.hasLoggedIntrinsicsInfo {
 				c.logger.CInfo(ctx, "camera model not found in known camera models for: ", dInfo.Name, ". returning "+
@@ -693,20 +2305,41 @@ func (c *monitoredWebcam) Properties(ctx context.Context) (camera.Properties, er
 				"to camera properties")
 			c.hasLoggedIntrinsicsInfo = true
 		}
-		props.IntrinsicParams = &cameraIntrinsics
+		props.IntrinsicParams = c.conf.outputIntrinsics(&cameraIntrinsics)
 	}
 	return props, nil
 }
 
+// mimeTypesForFormat returns the MIME types of the images a webcam delivering frames in the given format can serve, ordered from
+// the cheapest to produce, so that clients can ask for the encoding closest to what the driver delivers. Frames from MJPEG webcams
+// are already JPEG compressed, depth frames are best served raw, and other formats are uncompressed and so are cheapest served raw.
+func mimeTypesForFormat(format frame.Format) []string {
+	switch format {
+	case frame.FormatMJPEG:
+		return []string{utils.MimeTypeJPEG, utils.MimeTypeRawRGBA, utils.MimeTypePNG}
+	case frame.FormatZ16:
+		return []string{utils.MimeTypeRawDepth, utils.MimeTypePNG}
+	case "":
+		// the driver did not report the format it settled on
+		return []string{utils.MimeTypeJPEG, utils.MimeTypePNG, utils.MimeTypeRawRGBA}
+	default:
+		return []string{utils.MimeTypeRawRGBA, utils.MimeTypeJPEG, utils.MimeTypePNG}
+	}
+}
+
 var (
 	errClosed       = errors.New("camera has been closed")
 	errDisconnected = errors.New("camera is disconnected; please try again in a few moments")
+	errExhausted    = errors.New("camera is disconnected and reconnect attempts keep failing; it is still being retried, but less often")
 )
 
 func (c *monitoredWebcam) ensureActive() error {
 	if c.closed {
 		return errClosed
 	}
+	if c.reconnectExhausted {
+		return errExhausted
+	}
 	if c.disconnected {
 		return errDisconnected
 	}
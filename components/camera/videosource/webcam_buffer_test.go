@@ -0,0 +1,82 @@
+package videosource
+
+import (
+	"context"
+	"image"
+	"sync/atomic"
+	"testing"
+
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/gostream"
+)
+
+// channelVideoSource is a video source whose stream returns a frame as wide as each number sent on frames, counting how many of
+// the frames are released.
+type channelVideoSource struct {
+	frames   chan int
+	released atomic.Int32
+}
+
+func (s *channelVideoSource) Stream(ctx context.Context, errHandlers ...gostream.ErrorHandler) (gostream.VideoStream, error) {
+	return s, nil
+}
+
+func (s *channelVideoSource) Next(ctx context.Context) (image.Image, func(), error) {
+	select {
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	case width := <-s.frames:
+		return image.NewGray(image.Rect(0, 0, width, 1)), func() { s.released.Add(1) }, nil
+	}
+}
+
+func (s *channelVideoSource) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestBufferedVideoReader(t *testing.T) {
+	src := &channelVideoSource{frames: make(chan int)}
+	test.That(t, bufferVideoSource(src, 0), test.ShouldEqual, src)
+
+	reader := &bufferedVideoReader{src: src, frames: make(chan bufferedFrame, 2), closed: make(chan struct{})}
+	readWidth := func() int {
+		t.Helper()
+		img, _, err := reader.Read(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		return img.Bounds().Dx()
+	}
+
+	// the first read starts buffering and waits for a frame
+	go func() { src.frames <- 1 }()
+	test.That(t, readWidth(), test.ShouldEqual, 1)
+
+	// once the buffer is full, the oldest frames are dropped and released
+	for width := 2; width <= 5; width++ {
+		src.frames <- width
+	}
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, src.released.Load(), test.ShouldEqual, 2)
+	})
+	test.That(t, readWidth(), test.ShouldEqual, 4)
+	test.That(t, readWidth(), test.ShouldEqual, 5)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err := reader.Read(ctx)
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+
+	// closing stops the background reader and releases the frames left in the buffer
+	src.frames <- 6
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		test.That(tb, reader.frames, test.ShouldHaveLength, 1)
+	})
+	test.That(t, reader.Close(context.Background()), test.ShouldBeNil)
+	test.That(t, src.released.Load(), test.ShouldEqual, 3)
+	_, _, err = reader.Read(context.Background())
+	test.That(t, err, test.ShouldBeError, "buffered video source is closed")
+	test.That(t, reader.Close(context.Background()), test.ShouldBeNil)
+}
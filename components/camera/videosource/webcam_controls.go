@@ -0,0 +1,169 @@
+package videosource
+
+import (
+	"context"
+	"maps"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	mediadevicescamera "github.com/pion/mediadevices/pkg/driver/camera"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// webcamControls lists the controls that may be set in the controls attribute, by name.
+var webcamControls = map[string]uint32{
+	"brightness":                0x00980900,
+	"contrast":                  0x00980901,
+	"saturation":                0x00980902,
+	"auto_white_balance":        0x0098090c,
+	"gain":                      0x00980913,
+	"white_balance_temperature": 0x0098091a,
+	"sharpness":                 0x0098091b,
+	"exposure_auto":             0x009a0901,
+	"exposure":                  0x009a0902,
+}
+
+// webcamControlNames returns the sorted names of the controls that may be set.
+func webcamControlNames() []string {
+	names := make([]string, 0, len(webcamControls))
+	for name := range webcamControls {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateControls returns an error if any of the controls is not one that may be set.
+func validateControls(controls map[string]int) error {
+	for name := range controls {
+		if _, ok := webcamControls[name]; !ok {
+			return errors.Errorf("got unknown control %q in controls field set for webcam camera, valid controls are %v",
+				name, webcamControlNames())
+		}
+	}
+	return nil
+}
+
+// controlDevice returns the device file to set the controls of the webcam with the given label on.
+func controlDevice(label string) string {
+	parts := strings.Split(label, mediadevicescamera.LabelSeparator)
+	return filepath.Join("/dev", resolveVideoName(parts[len(parts)-1], false))
+}
+
+// applyControls sets each of the controls on the open webcam, returning the error for every control that could not be set.
+// Controls are best effort, as which ones a webcam supports and their ranges vary from model to model. It assumes a lock is held.
+func (c *monitoredWebcam) applyControls(ctx context.Context, controls map[string]int) map[string]error {
+	failed := map[string]error{}
+	for _, name := range sortedKeys(controls) {
+		if err := setControl(c.controlDevice, webcamControls[name], controls[name]); err != nil {
+			c.logger.CWarnw(ctx, "cannot set webcam control", "control", name, "value", controls[name], "device", c.controlDevice, "error", err)
+			failed[name] = err
+		}
+	}
+	return failed
+}
+
+// setControls sets the controls given in cmd on the webcam at runtime, and keeps them so that they are set again whenever the
+// webcam is reopened. It reports which controls could not be set rather than failing.
+func (c *monitoredWebcam) setControls(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	args, err := resource.TransformAttributeMap[*struct {
+		Controls map[string]int `json:"controls"`
+	}](cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse set_controls command")
+	}
+	if len(args.Controls) == 0 {
+		return nil, errors.New("set_controls command needs at least one control")
+	}
+	if err := validateControls(args.Controls); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.ensureActive(); err != nil {
+		return nil, err
+	}
+	controls := maps.Clone(c.conf.Controls)
+	if controls == nil {
+		controls = map[string]int{}
+	}
+	maps.Copy(controls, args.Controls)
+	c.conf.Controls = controls
+
+	failed := map[string]interface{}{}
+	for name, err := range c.applyControls(ctx, args.Controls) {
+		failed[name] = err.Error()
+	}
+	return map[string]interface{}{"failed": failed}, nil
+}
+
+// deviceControl describes a V4L2 control of a webcam, as reported by its driver.
+type deviceControl struct {
+	ID       uint32
+	Name     string
+	Type     string
+	Minimum  int32
+	Maximum  int32
+	Step     int32
+	Default  int32
+	Value    *int32
+	ReadOnly bool
+	Inactive bool
+}
+
+// listControls lists every control the webcam's driver reports, with its range and current value. Unlike set_controls, this is
+// not limited to the controls that may be set, so that users can see everything their webcam supports.
+func (c *monitoredWebcam) listControls() (map[string]interface{}, error) {
+	c.mu.RLock()
+	if err := c.ensureActive(); err != nil {
+		c.mu.RUnlock()
+		return nil, err
+	}
+	device := c.controlDevice
+	c.mu.RUnlock()
+
+	controls, err := queryControls(device)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot list controls of webcam device %s", device)
+	}
+	settable := map[uint32]string{}
+	for name, id := range webcamControls {
+		settable[id] = name
+	}
+	list := make([]interface{}, 0, len(controls))
+	for _, control := range controls {
+		entry := map[string]interface{}{
+			"id":        control.ID,
+			"name":      control.Name,
+			"type":      control.Type,
+			"minimum":   control.Minimum,
+			"maximum":   control.Maximum,
+			"step":      control.Step,
+			"default":   control.Default,
+			"read_only": control.ReadOnly,
+			"inactive":  control.Inactive,
+		}
+		if control.Value != nil {
+			entry["value"] = *control.Value
+		}
+		if name, ok := settable[control.ID]; ok {
+			// the name to give the control in the controls attribute and the set_controls command
+			entry["control"] = name
+		}
+		list = append(list, entry)
+	}
+	return map[string]interface{}{"device": device, "controls": list}, nil
+}
+
+func sortedKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,124 @@
+package videosource
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+const (
+	// vidiocGCtrl is the VIDIOC_G_CTRL ioctl request, which gets the value of a V4L2 control.
+	vidiocGCtrl = 0xc008561b
+	// vidiocSCtrl is the VIDIOC_S_CTRL ioctl request, which sets the value of a V4L2 control.
+	vidiocSCtrl = 0xc008561c
+	// vidiocQueryCtrl is the VIDIOC_QUERYCTRL ioctl request, which describes a V4L2 control.
+	vidiocQueryCtrl = 0xc0445624
+)
+
+// flags of struct v4l2_queryctrl.
+const (
+	v4l2CtrlFlagDisabled  = 0x0001
+	v4l2CtrlFlagReadOnly  = 0x0004
+	v4l2CtrlFlagInactive  = 0x0010
+	v4l2CtrlFlagWriteOnly = 0x0040
+	v4l2CtrlFlagNextCtrl  = 0x80000000
+)
+
+// v4l2CtrlTypeClass is the type of the controls which only name a class of the controls following them.
+const v4l2CtrlTypeClass = 6
+
+// v4l2ControlTypes names the types of V4L2 controls, by their value in enum v4l2_ctrl_type.
+var v4l2ControlTypes = map[uint32]string{
+	1: "integer",
+	2: "boolean",
+	3: "menu",
+	4: "button",
+	5: "integer64",
+	7: "string",
+	8: "bitmask",
+	9: "integer_menu",
+}
+
+// v4l2QueryCtrl mirrors struct v4l2_queryctrl.
+type v4l2QueryCtrl struct {
+	id           uint32
+	ctrlType     uint32
+	name         [32]byte
+	minimum      int32
+	maximum      int32
+	step         int32
+	defaultValue int32
+	flags        uint32
+	reserved     [2]uint32
+}
+
+// v4l2Control mirrors struct v4l2_control.
+type v4l2Control struct {
+	id    uint32
+	value int32
+}
+
+// setControl sets a V4L2 control on the device. V4L2 devices may be opened more than once, so this works while the webcam is
+// streaming.
+func setControl(device string, id uint32, value int) error {
+	f, err := os.OpenFile(device, os.O_RDWR|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close() //nolint:errcheck
+
+	ctrl := v4l2Control{id: id, value: int32(value)}
+	//nolint:gosec
+	if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), vidiocSCtrl, uintptr(unsafe.Pointer(&ctrl))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// queryControls lists the V4L2 controls of the device along with their current values, by asking the driver for each control in
+// turn. Controls whose value cannot be read, such as write only ones, are listed without a value.
+func queryControls(device string) ([]deviceControl, error) {
+	f, err := os.OpenFile(device, os.O_RDWR|syscall.O_NONBLOCK, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	var controls []deviceControl
+	query := v4l2QueryCtrl{id: v4l2CtrlFlagNextCtrl}
+	for {
+		//nolint:gosec
+		_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), vidiocQueryCtrl, uintptr(unsafe.Pointer(&query)))
+		if errors.Is(errno, syscall.EINVAL) {
+			// there are no more controls
+			return controls, nil
+		}
+		if errno != 0 {
+			return nil, errno
+		}
+		if query.flags&v4l2CtrlFlagDisabled == 0 && query.ctrlType != v4l2CtrlTypeClass {
+			control := deviceControl{
+				ID:       query.id,
+				Name:     string(bytes.TrimRight(query.name[:], "\x00")),
+				Type:     v4l2ControlTypes[query.ctrlType],
+				Minimum:  query.minimum,
+				Maximum:  query.maximum,
+				Step:     query.step,
+				Default:  query.defaultValue,
+				ReadOnly: query.flags&v4l2CtrlFlagReadOnly != 0,
+				Inactive: query.flags&v4l2CtrlFlagInactive != 0,
+			}
+			if query.flags&v4l2CtrlFlagWriteOnly == 0 {
+				ctrl := v4l2Control{id: query.id}
+				//nolint:gosec
+				if _, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), vidiocGCtrl, uintptr(unsafe.Pointer(&ctrl))); errno == 0 {
+					control.Value = &ctrl.value
+				}
+			}
+			controls = append(controls, control)
+		}
+		query = v4l2QueryCtrl{id: query.id | v4l2CtrlFlagNextCtrl}
+	}
+}
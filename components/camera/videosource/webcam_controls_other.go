@@ -0,0 +1,15 @@
+//go:build !linux
+
+package videosource
+
+import "github.com/pkg/errors"
+
+// setControl is only supported for V4L2 devices on linux.
+func setControl(device string, id uint32, value int) error {
+	return errors.New("webcam controls are only supported on linux")
+}
+
+// queryControls is only supported for V4L2 devices on linux.
+func queryControls(device string) ([]deviceControl, error) {
+	return nil, errors.New("listing webcam controls is only supported on linux")
+}
@@ -0,0 +1,92 @@
+package videosource
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/resource"
+)
+
+// maxMeasureFPSFrames bounds how many frames the measure_fps command may read, so that it can't run for too long.
+const maxMeasureFPSFrames = 1000
+
+// measureFPS reads the number of frames given in cmd and reports the frame rate they arrived at, along with the jitter between
+// them, so that it can be checked that the webcam delivers the rate it was configured for. The frames are read from a stream of
+// its own, which does not take frames away from any other stream of the webcam.
+func (c *monitoredWebcam) measureFPS(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	args, err := resource.TransformAttributeMap[*struct {
+		Frames int `json:"frames"`
+	}](cmd)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse measure_fps command")
+	}
+	if args.Frames < 2 || args.Frames > maxMeasureFPSFrames {
+		return nil, errors.Errorf("measure_fps command needs between 2 and %d frames, got %d", maxMeasureFPSFrames, args.Frames)
+	}
+
+	c.mu.RLock()
+	if err := c.ensureActive(); err != nil {
+		c.mu.RUnlock()
+		return nil, err
+	}
+	swapper := c.exposedSwapper
+	configured := c.negotiated.FrameRate
+	c.mu.RUnlock()
+
+	stream, err := swapper.Stream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer goutils.UncheckedErrorFunc(func() error { return stream.Close(context.Background()) })
+
+	capturedAt := make([]time.Time, 0, args.Frames)
+	for len(capturedAt) < args.Frames {
+		_, release, err := stream.Next(ctx)
+		captured := c.recordFrame(err)
+		if err != nil {
+			return nil, errors.Wrapf(err, "measure_fps failed after reading %d frames", len(capturedAt))
+		}
+		if release != nil {
+			release()
+		}
+		capturedAt = append(capturedAt, captured)
+	}
+	return frameRateStats(capturedAt, configured), nil
+}
+
+// frameRateStats reports the frame rate and the spread of the intervals between frames captured at the given times, which are
+// in order, alongside the frame rate the driver was configured for.
+func frameRateStats(capturedAt []time.Time, configured float32) map[string]interface{} {
+	intervals := make([]float64, 0, len(capturedAt)-1)
+	for i := 1; i < len(capturedAt); i++ {
+		intervals = append(intervals, float64(capturedAt[i].Sub(capturedAt[i-1]))/float64(time.Millisecond))
+	}
+	total := float64(capturedAt[len(capturedAt)-1].Sub(capturedAt[0])) / float64(time.Millisecond)
+	mean := total / float64(len(intervals))
+	minInterval, maxInterval, variance := math.Inf(1), 0., 0.
+	for _, interval := range intervals {
+		minInterval = math.Min(minInterval, interval)
+		maxInterval = math.Max(maxInterval, interval)
+		variance += (interval - mean) * (interval - mean)
+	}
+	variance /= float64(len(intervals))
+
+	fps := 0.
+	if total > 0 {
+		fps = 1000 * float64(len(intervals)) / total
+	}
+	return map[string]interface{}{
+		"frames":           len(capturedAt),
+		"duration_ms":      total,
+		"fps":              fps,
+		"configured_fps":   float64(configured),
+		"mean_interval_ms": mean,
+		"min_interval_ms":  minInterval,
+		"max_interval_ms":  maxInterval,
+		"jitter_ms":        math.Sqrt(variance),
+	}
+}
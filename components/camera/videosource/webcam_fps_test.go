@@ -0,0 +1,28 @@
+package videosource
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestFrameRateStats(t *testing.T) {
+	start := time.Now()
+	capturedAt := []time.Time{start, start.Add(10 * time.Millisecond), start.Add(30 * time.Millisecond), start.Add(40 * time.Millisecond)}
+	stats := frameRateStats(capturedAt, 30)
+	test.That(t, stats["frames"], test.ShouldEqual, 4)
+	test.That(t, stats["duration_ms"], test.ShouldAlmostEqual, 40)
+	test.That(t, stats["fps"], test.ShouldAlmostEqual, 75)
+	test.That(t, stats["configured_fps"], test.ShouldEqual, 30)
+	test.That(t, stats["mean_interval_ms"], test.ShouldAlmostEqual, 40./3)
+	test.That(t, stats["min_interval_ms"], test.ShouldAlmostEqual, 10)
+	test.That(t, stats["max_interval_ms"], test.ShouldAlmostEqual, 20)
+	// intervals of 10, 20 and 10ms spread about 4.71ms from their mean
+	test.That(t, stats["jitter_ms"], test.ShouldAlmostEqual, 4.714, 0.001)
+
+	// evenly spaced frames have no jitter
+	stats = frameRateStats([]time.Time{start, start.Add(50 * time.Millisecond), start.Add(100 * time.Millisecond)}, 0)
+	test.That(t, stats["fps"], test.ShouldAlmostEqual, 20)
+	test.That(t, stats["jitter_ms"], test.ShouldAlmostEqual, 0)
+}
@@ -0,0 +1,147 @@
+package videosource
+
+import (
+	"context"
+	"image"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/gostream"
+	"go.viam.com/rdk/rimage"
+)
+
+// maxFrameHistorySize bounds frame_history_size, as every frame in the history is a full copy of an image.
+const maxFrameHistorySize = 300
+
+// frameHistoryRetryWait is how long the frame history recorder waits before trying again when it is not recording or cannot read a
+// frame.
+const frameHistoryRetryWait = 100 * time.Millisecond
+
+// FrameHistory is implemented by webcams which keep the frames they produced recently, when frame_history_size is set, so that
+// the frame captured closest to a given time can be looked up, for example to pair it with a reading from another sensor.
+type FrameHistory interface {
+	// GetNearest returns the frame captured closest to the given time, along with when it was captured. It returns an error if the
+	// time is outside the window of frames kept.
+	GetNearest(t time.Time) (image.Image, time.Time, error)
+}
+
+// timedFrame is a frame along with when it was captured.
+type timedFrame struct {
+	img        image.Image
+	capturedAt time.Time
+}
+
+// frameHistory is a ring buffer of the most recent frames, oldest first.
+type frameHistory struct {
+	mu     sync.Mutex
+	size   int
+	frames []timedFrame
+	next   int
+}
+
+// resize changes how many frames are kept, dropping the history if it changes.
+func (h *frameHistory) resize(size int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if size == h.size {
+		return
+	}
+	h.size = size
+	h.frames = nil
+	h.next = 0
+}
+
+// add records a frame, replacing the oldest frame if the history is full.
+func (h *frameHistory) add(img image.Image, capturedAt time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.size == 0 {
+		return
+	}
+	if len(h.frames) < h.size {
+		h.frames = append(h.frames, timedFrame{img, capturedAt})
+		return
+	}
+	h.frames[h.next] = timedFrame{img, capturedAt}
+	h.next = (h.next + 1) % h.size
+}
+
+// nearest returns the frame captured closest to t, which must lie between the oldest and newest frames kept.
+func (h *frameHistory) nearest(t time.Time) (image.Image, time.Time, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.size == 0 {
+		return nil, time.Time{}, errors.New("frame history is not enabled, set frame_history_size to keep recent frames")
+	}
+	if len(h.frames) == 0 {
+		return nil, time.Time{}, errors.New("no frames have been captured yet")
+	}
+	ordered := append(append(make([]timedFrame, 0, len(h.frames)), h.frames[h.next:]...), h.frames[:h.next]...)
+	oldest, newest := ordered[0].capturedAt, ordered[len(ordered)-1].capturedAt
+	if t.Before(oldest) || t.After(newest) {
+		return nil, time.Time{}, errors.Errorf(
+			"time %s is outside the window of frames kept, from %s to %s",
+			t.Format(time.RFC3339Nano), oldest.Format(time.RFC3339Nano), newest.Format(time.RFC3339Nano))
+	}
+	// the first frame captured at or after t, which is compared against the frame before it
+	i := sort.Search(len(ordered), func(i int) bool { return !ordered[i].capturedAt.Before(t) })
+	if i > 0 && t.Sub(ordered[i-1].capturedAt) <= ordered[i].capturedAt.Sub(t) {
+		i--
+	}
+	return ordered[i].img, ordered[i].capturedAt, nil
+}
+
+// GetNearest returns the frame captured closest to the given time from the frames kept when frame_history_size is set.
+func (c *monitoredWebcam) GetNearest(t time.Time) (image.Image, time.Time, error) {
+	return c.history.nearest(t)
+}
+
+// recordFrameHistory keeps copies of the frames the webcam produces in its frame history, for as long as the webcam is open and
+// frame_history_size is set. The webcam is read continuously while recording, rather than only when a frame is asked for.
+func (c *monitoredWebcam) recordFrameHistory() {
+	c.activeBackgroundWorkers.Add(1)
+	goutils.ManagedGo(func() {
+		var stream gostream.VideoStream
+		closeStream := func() {
+			if stream != nil {
+				goutils.UncheckedError(stream.Close(context.Background()))
+				stream = nil
+			}
+		}
+		defer closeStream()
+		for c.cancelCtx.Err() == nil {
+			c.mu.RLock()
+			size := c.conf.FrameHistorySize
+			swapper := c.exposedSwapper
+			c.mu.RUnlock()
+			if size == 0 || swapper == nil {
+				closeStream()
+				goutils.SelectContextOrWait(c.cancelCtx, frameHistoryRetryWait)
+				continue
+			}
+
+			if stream == nil {
+				var err error
+				if stream, err = swapper.Stream(c.cancelCtx); err != nil {
+					goutils.SelectContextOrWait(c.cancelCtx, frameHistoryRetryWait)
+					continue
+				}
+			}
+			img, release, err := stream.Next(c.cancelCtx)
+			if err != nil {
+				goutils.SelectContextOrWait(c.cancelCtx, frameHistoryRetryWait)
+				continue
+			}
+			capturedAt := time.Now()
+			// the frame may be reused by the driver once released, so a copy is kept
+			c.history.add(rimage.CloneImage(img), capturedAt)
+			if release != nil {
+				release()
+			}
+		}
+	}, c.activeBackgroundWorkers.Done)
+}
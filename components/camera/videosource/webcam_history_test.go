@@ -0,0 +1,47 @@
+package videosource
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+)
+
+func TestFrameHistory(t *testing.T) {
+	var history frameHistory
+	_, _, err := history.nearest(time.Now())
+	test.That(t, err, test.ShouldBeError, "frame history is not enabled, set frame_history_size to keep recent frames")
+
+	history.resize(3)
+	_, _, err = history.nearest(time.Now())
+	test.That(t, err, test.ShouldBeError, "no frames have been captured yet")
+
+	// frames as wide as the number of seconds after start they were captured at, the oldest of which is dropped
+	start := time.Unix(1000, 0)
+	for width := 1; width <= 4; width++ {
+		history.add(image.NewGray(image.Rect(0, 0, width, 1)), start.Add(time.Duration(width)*time.Second))
+	}
+	nearestWidth := func(t *testing.T, after time.Duration) int {
+		t.Helper()
+		img, capturedAt, err := history.nearest(start.Add(after))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, capturedAt, test.ShouldEqual, start.Add(time.Duration(img.Bounds().Dx())*time.Second))
+		return img.Bounds().Dx()
+	}
+	test.That(t, nearestWidth(t, 2*time.Second), test.ShouldEqual, 2)
+	test.That(t, nearestWidth(t, 2400*time.Millisecond), test.ShouldEqual, 2)
+	test.That(t, nearestWidth(t, 2600*time.Millisecond), test.ShouldEqual, 3)
+	test.That(t, nearestWidth(t, 4*time.Second), test.ShouldEqual, 4)
+
+	_, _, err = history.nearest(start.Add(1500 * time.Millisecond))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "is outside the window of frames kept")
+	_, _, err = history.nearest(start.Add(5 * time.Second))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// changing the size drops the frames kept
+	history.resize(2)
+	_, _, err = history.nearest(start.Add(4 * time.Second))
+	test.That(t, err, test.ShouldBeError, "no frames have been captured yet")
+}
@@ -2,14 +2,31 @@ package videosource_test
 
 import (
 	"context"
+	"errors"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/pion/mediadevices"
 	"github.com/pion/mediadevices/pkg/driver"
+	"github.com/pion/mediadevices/pkg/frame"
 	"github.com/pion/mediadevices/pkg/prop"
 	"go.viam.com/test"
+	"go.viam.com/utils/protoutils"
+	"go.viam.com/utils/testutils"
 
+	"go.viam.com/rdk/components/camera"
 	"go.viam.com/rdk/components/camera/videosource"
+	"go.viam.com/rdk/gostream"
 	"go.viam.com/rdk/logging"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/rimage/transform"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
 )
 
 // fakeDriver is a driver has a label and media properties.
@@ -38,6 +55,15 @@ func testGetDrivers() []driver.Driver {
 	return []driver.Driver{withProps, withoutProps}
 }
 
+// newFakeVideoSource returns a video source backed by the given driver that produces blank images
+// matching the given properties.
+func newFakeVideoSource(d driver.Driver, props prop.Video) gostream.VideoSource {
+	reader := gostream.VideoReaderFunc(func(ctx context.Context) (image.Image, func(), error) {
+		return image.NewRGBA(image.Rect(0, 0, props.Width, props.Height)), func() {}, nil
+	})
+	return gostream.NewVideoSourceForDriver(d, reader, props)
+}
+
 func TestDiscoveryWebcam(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	resp, err := videosource.Discover(context.Background(), testGetDrivers, logger)
@@ -54,3 +80,1274 @@ func TestDiscoveryWebcam(t *testing.T) {
 	test.That(t, respProps[0].FrameFormat, test.ShouldResemble, "some format")
 	test.That(t, respProps[0].FrameRate, test.ShouldResemble, float32(30))
 }
+
+func TestDiscoveryDeduplicatesProperties(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{newFakeDriver("some label", []prop.Media{
+			{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "MJPG", FrameRate: 30.0}},
+			{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "MJPG", FrameRate: 30.0}},
+			{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "YUYV", FrameRate: 30.0}},
+			{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "YUYV", FrameRate: 10.0}},
+			{Video: prop.Video{Width: 640, Height: 480, FrameFormat: "YUYV", FrameRate: 30.0}},
+		})}
+	}
+	resp, err := videosource.Discover(context.Background(), getDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Properties, test.ShouldHaveLength, 4)
+
+	grouped := videosource.FormatsByResolution(resp.Webcams[0])
+	test.That(t, grouped, test.ShouldResemble, []videosource.ResolutionFormats{
+		{WidthPx: 1280, HeightPx: 720, FrameFormats: []string{"MJPG", "YUYV"}},
+		{WidthPx: 640, HeightPx: 480, FrameFormats: []string{"YUYV"}},
+	})
+}
+
+func TestDiscoveryWithMinResolution(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{
+			newFakeDriver("hd", []prop.Media{
+				{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "MJPG", FrameRate: 30.0}},
+				{Video: prop.Video{Width: 640, Height: 480, FrameFormat: "YUYV", FrameRate: 30.0}},
+				{Video: prop.Video{Width: 1920, Height: 240, FrameFormat: "YUYV", FrameRate: 30.0}},
+			}),
+			newFakeDriver("virtual", []prop.Media{
+				{Video: prop.Video{Width: 320, Height: 240, FrameFormat: "YUYV", FrameRate: 30.0}},
+			}),
+		}
+	}
+
+	resp, err := videosource.DiscoverWithMinResolution(context.Background(), getDrivers, 0, 0, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 2)
+
+	// both dimensions must meet the minimum
+	resp, err = videosource.DiscoverWithMinResolution(context.Background(), getDrivers, 640, 480, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Label, test.ShouldEqual, "hd")
+	test.That(t, resp.Webcams[0].Properties, test.ShouldHaveLength, 2)
+	test.That(t, resp.Webcams[0].Properties[0].WidthPx, test.ShouldEqual, 1280)
+	test.That(t, resp.Webcams[0].Properties[1].WidthPx, test.ShouldEqual, 640)
+
+	resp, err = videosource.DiscoverWithMinResolution(context.Background(), getDrivers, 4096, 0, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldBeEmpty)
+
+	_, err = videosource.DiscoverWithMinResolution(context.Background(), getDrivers, -1, 0, logger)
+	test.That(t, err, test.ShouldBeError, errors.New("minimum resolution cannot be negative, got -1x0"))
+}
+
+// inUseDriver is a fakeDriver that is already running.
+type inUseDriver struct {
+	fakeDriver
+}
+
+func (d *inUseDriver) Status() driver.State { return driver.StateRunning }
+
+// screenDriver is a fakeDriver that captures a screen.
+type screenDriver struct {
+	fakeDriver
+}
+
+func (d *screenDriver) Info() driver.Info {
+	return driver.Info{Label: d.label, DeviceType: driver.Screen}
+}
+
+func TestDiscoveryExcludesScreens(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := []prop.Media{{Video: prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}}}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{
+			newFakeDriver("some label", props),
+			&screenDriver{fakeDriver{label: "screen label", props: props}},
+		}
+	}
+	resp, err := videosource.Discover(context.Background(), getDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Label, test.ShouldEqual, "some label")
+
+	_, skipped, err := videosource.DiscoverWithDiagnostics(context.Background(), getDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, skipped, test.ShouldResemble, []videosource.DiscoverySkip{
+		{Label: "screen label", Reason: videosource.SkipReasonScreen},
+	})
+
+	resp, err = videosource.DiscoverIncludingScreens(context.Background(), getDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 2)
+	test.That(t, resp.Webcams[1].Label, test.ShouldEqual, "screen label")
+}
+
+func TestDiscoveryWithDiagnostics(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := []prop.Media{{Video: prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}}}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{
+			newFakeDriver("some label", props),
+			newFakeDriver("another label", []prop.Media{}),
+			&inUseDriver{fakeDriver{label: "busy label", props: props}},
+		}
+	}
+	resp, skipped, err := videosource.DiscoverWithDiagnostics(context.Background(), getDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Label, test.ShouldEqual, "some label")
+	test.That(t, skipped, test.ShouldResemble, []videosource.DiscoverySkip{
+		{Label: "another label", Reason: videosource.SkipReasonNoProperties},
+		{Label: "busy label", Reason: videosource.SkipReasonInUse},
+	})
+}
+
+func TestDiscoverRemote(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	webcams, err := videosource.Discover(context.Background(), testGetDrivers, logger)
+	test.That(t, err, test.ShouldBeNil)
+	query := resource.NewDiscoveryQuery(camera.API, videosource.ModelWebcam)
+
+	r := &inject.Robot{}
+	r.DiscoverComponentsFunc = func(ctx context.Context, qs []resource.DiscoveryQuery) ([]resource.Discovery, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		test.That(t, qs, test.ShouldResemble, []resource.DiscoveryQuery{query})
+		return []resource.Discovery{{Query: query, Results: webcams}}, nil
+	}
+	resp, err := videosource.DiscoverRemote(context.Background(), r)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldEqual, webcams)
+
+	// results from a remote robot arrive as a map, as converted by robot/client
+	pbResults, err := protoutils.StructToStructPb(webcams)
+	test.That(t, err, test.ShouldBeNil)
+	r.DiscoverComponentsFunc = func(ctx context.Context, qs []resource.DiscoveryQuery) ([]resource.Discovery, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return []resource.Discovery{{Query: query, Results: pbResults.AsMap()}}, nil
+	}
+	resp, err = videosource.DiscoverRemote(context.Background(), r)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp.Webcams, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Label, test.ShouldEqual, "some label")
+	test.That(t, resp.Webcams[0].Properties, test.ShouldHaveLength, 1)
+	test.That(t, resp.Webcams[0].Properties[0].WidthPx, test.ShouldEqual, 320)
+	test.That(t, resp.Webcams[0].Properties[0].HeightPx, test.ShouldEqual, 240)
+	test.That(t, resp.Webcams[0].Properties[0].FrameFormat, test.ShouldEqual, "some format")
+	test.That(t, resp.Webcams[0].Properties[0].FrameRate, test.ShouldEqual, 30)
+
+	// the context is passed on to the robot
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = videosource.DiscoverRemote(ctx, r)
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+
+	r.DiscoverComponentsFunc = func(ctx context.Context, qs []resource.DiscoveryQuery) ([]resource.Discovery, error) {
+		return nil, nil
+	}
+	_, err = videosource.DiscoverRemote(context.Background(), r)
+	test.That(t, err, test.ShouldBeError, errors.New("robot did not return webcam discovery results"))
+}
+
+// openCountingDriver is a fakeDriver that counts how often it is opened.
+type openCountingDriver struct {
+	fakeDriver
+	opened int
+}
+
+func (d *openCountingDriver) Open() error {
+	d.opened++
+	return nil
+}
+
+func TestListDrivers(t *testing.T) {
+	closed := &openCountingDriver{fakeDriver: fakeDriver{label: "closed label"}}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{
+			closed,
+			&inUseDriver{fakeDriver{label: "busy label"}},
+		}
+	}
+	drivers := videosource.ListDrivers(getDrivers)
+	test.That(t, drivers, test.ShouldResemble, []videosource.DriverStatus{
+		{Label: "closed label", Status: "some state"},
+		{Label: "busy label", Status: driver.StateRunning},
+	})
+	test.That(t, drivers[0].InUse(), test.ShouldBeFalse)
+	test.That(t, drivers[1].InUse(), test.ShouldBeTrue)
+	// listing must not open drivers as a side effect
+	test.That(t, closed.opened, test.ShouldEqual, 0)
+
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	getCamDrivers := func() []driver.Driver {
+		return append(testGetDrivers(), &inUseDriver{fakeDriver{label: "busy label"}})
+	}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label"},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, getCamDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	resp, err := cam.DoCommand(context.Background(), map[string]interface{}{"command": "list_drivers"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["drivers"], test.ShouldResemble, []interface{}{
+		map[string]interface{}{"label": "some label", "status": "some state", "in_use": false, "current": true},
+		map[string]interface{}{"label": "another label", "status": "some state", "in_use": false, "current": false},
+		map[string]interface{}{"label": "busy label", "status": "running", "in_use": true, "current": false},
+	})
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestWebcamValidation(t *testing.T) {
+	webCfg := &videosource.WebcamConfig{
+		Width:     1280,
+		Height:    640,
+		FrameRate: 100,
+	}
+
+	// no error with positive width, height, and frame rate
+	deps, err := webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	// no error with zero values
+	webCfg = &videosource.WebcamConfig{}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	// error with a negative width
+	webCfg.Width = -200
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative dimensions for width_px and height_px (0, -200) fields set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with min width greater than max width
+	webCfg.Width = 0
+	webCfg.MinWidth = 1920
+	webCfg.MaxWidth = 640
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"min_width_px (1920) cannot be greater than max_width_px (640) for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// no error with a valid width range
+	webCfg.MinWidth = 640
+	webCfg.MaxWidth = 1920
+	deps, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	// error with min height greater than max height
+	webCfg.MinHeight = 1080
+	webCfg.MaxHeight = 480
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"min_height_px (1080) cannot be greater than max_height_px (480) for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a negative frame rate
+	webCfg.MinHeight = 0
+	webCfg.MaxHeight = 0
+	webCfg.Width = 200
+	webCfg.FrameRate = -100
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative frame rate (-100.00) field set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a negative max fps
+	webCfg.FrameRate = 30
+	webCfg.MaxFPS = -5
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative max fps (-5.00) field set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with negative reconnect settings
+	webCfg.MaxFPS = 0
+	webCfg.ReconnectAttempts = -1
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative values for reconnect_attempts and reconnect_interval_ms (-1, 0) fields set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a field of view that is out of range
+	webCfg.ReconnectAttempts = 0
+	webCfg.DefaultHFOVDegrees = 180
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal default_hfov_degrees (180.00) field set for webcam camera, must be between 0 and 180")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a rotation that is not a quarter turn
+	webCfg.DefaultHFOVDegrees = 0
+	webCfg.Rotation = 45
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal rotation_degrees (45) field set for webcam camera, must be one of 0, 90, 180 or 270")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a negative open timeout
+	webCfg.Rotation = 0
+	webCfg.OpenTimeoutMs = -1
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative open_timeout_ms (-1) field set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a negative buffer size
+	webCfg.OpenTimeoutMs = 0
+	webCfg.BufferSize = -1
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal negative buffer_size (-1) field set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a frame history larger than allowed
+	webCfg.BufferSize = 0
+	webCfg.FrameHistorySize = 301
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal frame_history_size (301) field set for webcam camera, must be between 0 and 300")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with a region of interest which is empty or does not fit in the configured resolution
+	webCfg.FrameHistorySize = 0
+	webCfg.ROI = &videosource.RegionOfInterest{X: -1, Y: 0, Width: 10, Height: 10}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"got illegal roi (x -1, y 0, width 10, height 10) field set for webcam camera, "+
+			"the offset can't be negative and the size must be positive")
+	test.That(t, deps, test.ShouldBeNil)
+	webCfg.ROI = &videosource.RegionOfInterest{X: 0, Y: 0, Width: 0, Height: 10}
+	_, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	webCfg.Width, webCfg.Height = 320, 240
+	webCfg.ROI = &videosource.RegionOfInterest{X: 300, Y: 0, Width: 40, Height: 10}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"roi must fit within width_px and height_px for webcam camera: "+
+			"roi (x 300, y 0, width 40, height 10) does not fit in a 320x240 frame")
+	test.That(t, deps, test.ShouldBeNil)
+	webCfg.ROI = &videosource.RegionOfInterest{X: 280, Y: 0, Width: 40, Height: 10}
+	_, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+
+	// error with scaled intrinsics but no intrinsics to scale
+	webCfg.Width, webCfg.Height = 0, 0
+	webCfg.ROI = nil
+	webCfg.ScaleIntrinsics = true
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual, "scale_intrinsics requires intrinsic_parameters to be set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// error with an unknown format in the priority list
+	webCfg.ScaleIntrinsics = false
+	webCfg.FormatPriority = []string{"MJPEG", "H264"}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		`got unknown format "H264" in format_priority field set for webcam camera, `+
+			"valid formats are [I420 I444 YUY2 UYVY RGBA MJPEG NV12 NV21 Z16 YUYV]")
+	test.That(t, deps, test.ShouldBeNil)
+
+	// no error with known formats in the priority list
+	webCfg.FormatPriority = []string{"MJPEG", "YUYV"}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	// error with only one of vendor and product ID
+	webCfg.VendorID = "046d"
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		`vendor_id and product_id ("046d", "") must be set together for webcam camera`)
+	test.That(t, deps, test.ShouldBeNil)
+
+	// no error with both vendor and product ID
+	webCfg.ProductID = "085e"
+	deps, err = webCfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{})
+
+	// error with both an explicit and the highest resolution
+	webCfg.PreferHighestResolution = true
+	webCfg.Width = 640
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"prefer_highest_resolution cannot be set along with width_px and height_px (640, 0) for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+	webCfg.PreferHighestResolution = false
+	webCfg.Width = 0
+
+	// error with undistort but no distortion model
+	webCfg.Undistort = true
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		"undistort requires both intrinsic_parameters and distortion_parameters to be set for webcam camera")
+	test.That(t, deps, test.ShouldBeNil)
+	webCfg.Undistort = false
+
+	// error with an unknown control
+	webCfg.Controls = map[string]int{"gain": 10, "zoom": 2}
+	deps, err = webCfg.Validate("path")
+	test.That(t, err.Error(), test.ShouldEqual,
+		`got unknown control "zoom" in controls field set for webcam camera, valid controls are `+
+			"[auto_white_balance brightness contrast exposure exposure_auto gain saturation sharpness white_balance_temperature]")
+	test.That(t, deps, test.ShouldBeNil)
+}
+
+func TestWebcamWithFakeSource(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		if name != "some label" {
+			return nil, errors.New("no such webcam")
+		}
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label"},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	before := time.Now()
+	imgs, metadata, err := cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs, test.ShouldHaveLength, 1)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 320)
+	test.That(t, imgs[0].Image.Bounds().Dy(), test.ShouldEqual, 240)
+	test.That(t, metadata.CapturedAt.Before(before), test.ShouldBeFalse)
+	test.That(t, metadata.CapturedAt.After(time.Now()), test.ShouldBeFalse)
+
+	resp, err := cam.DoCommand(context.Background(), map[string]interface{}{"command": "get_properties"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldResemble, map[string]interface{}{
+		"width_px":     320,
+		"height_px":    240,
+		"frame_format": "some format",
+		"frame_rate":   float32(30),
+	})
+
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "health"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["last_frame_time"], test.ShouldEqual, metadata.CapturedAt.Format(time.RFC3339Nano))
+	test.That(t, resp["error"], test.ShouldBeEmpty)
+
+	// frames are only kept when a frame history is set
+	_, _, err = cam.(videosource.FrameHistory).GetNearest(time.Now())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "frame history is not enabled")
+
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "get_device"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["video_path"], test.ShouldEqual, "some label")
+	test.That(t, resp["device_path"], test.ShouldEqual, "some label")
+	test.That(t, resp["label"], test.ShouldEqual, "some label")
+	test.That(t, resp["opened_at"], test.ShouldNotBeEmpty)
+
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// a video path which is a symbolic link reports the device it resolved to
+	dir := t.TempDir()
+	device := filepath.Join(dir, "some label")
+	test.That(t, os.WriteFile(device, nil, 0o600), test.ShouldBeNil)
+	link := filepath.Join(dir, "by-id-camera")
+	test.That(t, os.Symlink(device, link), test.ShouldBeNil)
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: link}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "get_device"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["video_path"], test.ShouldEqual, link)
+	resolved, err := filepath.EvalSymlinks(device)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["device_path"], test.ShouldEqual, resolved)
+	test.That(t, resp["label"], test.ShouldEqual, "some label")
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// frames may be buffered in the background
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", BufferSize: 2}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	for i := 0; i < 3; i++ {
+		imgs, _, err = cam.Images(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 320)
+	}
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// recent frames are kept and looked up by time when a frame history is set
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", FrameHistorySize: 300}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	history, ok := cam.(videosource.FrameHistory)
+	test.That(t, ok, test.ShouldBeTrue)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		_, _, err := history.GetNearest(time.Now())
+		test.That(tb, err, test.ShouldNotBeNil)
+		test.That(tb, err.Error(), test.ShouldNotContainSubstring, "no frames have been captured yet")
+	})
+	// once a frame has been kept, a time after it is found when the next frame is kept
+	requested := time.Now()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		img, capturedAt, err := history.GetNearest(requested)
+		test.That(tb, err, test.ShouldBeNil)
+		if err != nil {
+			return
+		}
+		test.That(tb, img.Bounds().Dx(), test.ShouldEqual, 320)
+		test.That(tb, capturedAt.IsZero(), test.ShouldBeFalse)
+	})
+	_, _, err = history.GetNearest(time.Time{})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "is outside the window of frames kept")
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// rotating by a quarter turn swaps the width and height, and turns the intrinsics and distortion with the frames
+	conf.ConvertedAttributes = &videosource.WebcamConfig{
+		Path:                 "some label",
+		Rotation:             90,
+		CameraParameters:     &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 200, Fy: 180, Ppx: 150, Ppy: 110},
+		DistortionParameters: &transform.BrownConrady{RadialK1: 0.1, TangentialP1: 0.01, TangentialP2: 0.02},
+	}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	imgs, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 240)
+	test.That(t, imgs[0].Image.Bounds().Dy(), test.ShouldEqual, 320)
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "get_properties"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["width_px"], test.ShouldEqual, 240)
+	test.That(t, resp["height_px"], test.ShouldEqual, 320)
+	camProps, err := cam.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, camProps.IntrinsicParams, test.ShouldResemble,
+		&transform.PinholeCameraIntrinsics{Width: 240, Height: 320, Fx: 180, Fy: 200, Ppx: 130, Ppy: 150})
+	test.That(t, camProps.DistortionParams, test.ShouldResemble,
+		&transform.BrownConrady{RadialK1: 0.1, TangentialP1: 0.02, TangentialP2: -0.01})
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// intrinsics estimated from the field of view of the sensor are rotated too
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", Rotation: 270, DefaultHFOVDegrees: 90}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	camProps, err = cam.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, camProps.IntrinsicParams, test.ShouldResemble,
+		&transform.PinholeCameraIntrinsics{Width: 240, Height: 320, Fx: 160, Fy: 160, Ppx: 120, Ppy: 160})
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// frames are cropped to the region of interest, whose principal point is shifted to match
+	conf.ConvertedAttributes = &videosource.WebcamConfig{
+		Path:             "some label",
+		ROI:              &videosource.RegionOfInterest{X: 100, Y: 40, Width: 120, Height: 80},
+		CameraParameters: &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 200, Fy: 200, Ppx: 160, Ppy: 120},
+	}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	imgs, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 120)
+	test.That(t, imgs[0].Image.Bounds().Dy(), test.ShouldEqual, 80)
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "get_properties"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["width_px"], test.ShouldEqual, 120)
+	test.That(t, resp["height_px"], test.ShouldEqual, 80)
+	camProps, err = cam.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, camProps.IntrinsicParams, test.ShouldResemble,
+		&transform.PinholeCameraIntrinsics{Width: 120, Height: 80, Fx: 200, Fy: 200, Ppx: 60, Ppy: 80})
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// a region of interest that does not fit in the negotiated resolution fails to open
+	conf.ConvertedAttributes = &videosource.WebcamConfig{
+		Path: "some label",
+		ROI:  &videosource.RegionOfInterest{X: 300, Y: 0, Width: 40, Height: 10},
+	}
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring,
+		"roi does not fit within the negotiated resolution: roi (x 300, y 0, width 40, height 10) does not fit in a 320x240 frame")
+
+	// intrinsics are estimated from the horizontal field of view when not configured
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", DefaultHFOVDegrees: 90}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	camProps, err = cam.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, camProps.IntrinsicParams, test.ShouldNotBeNil)
+	test.That(t, camProps.IntrinsicParams.Width, test.ShouldEqual, 320)
+	test.That(t, camProps.IntrinsicParams.Height, test.ShouldEqual, 240)
+	test.That(t, camProps.IntrinsicParams.Fx, test.ShouldAlmostEqual, 160)
+	test.That(t, camProps.IntrinsicParams.Fy, test.ShouldAlmostEqual, 160)
+	test.That(t, camProps.IntrinsicParams.Ppx, test.ShouldAlmostEqual, 160)
+	test.That(t, camProps.IntrinsicParams.Ppy, test.ShouldAlmostEqual, 120)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// a format the webcam does not support fails with the supported formats
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", Format: "MJPEG"}
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring,
+		`requested format "MJPEG" is not supported by webcam "some label", supported formats are [some format]`)
+
+	// with format_fallback set, the webcam is opened with the default formats instead
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label", Format: "MJPEG", FormatFallback: true}
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	imgs, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 320)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// the fallback reports both failures if the webcam still cannot be opened
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "another label", Format: "MJPEG", FormatFallback: true}
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "format fallback failed")
+
+	// a webcam that cannot be found fails to construct
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "another label"}
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no such webcam")
+}
+
+func TestWebcamMeasureFPS(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label", MaxFPS: 50},
+	}
+	ctx := context.Background()
+	cam, err := videosource.NewWebcamWithSources(ctx, nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() { test.That(t, cam.Close(ctx), test.ShouldBeNil) }()
+
+	// another consumer keeps streaming while the frame rate is measured
+	stream, err := cam.Stream(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() { test.That(t, stream.Close(ctx), test.ShouldBeNil) }()
+	streamed := make(chan error, 1)
+	go func() {
+		for i := 0; i < 10; i++ {
+			if _, _, err := stream.Next(ctx); err != nil {
+				streamed <- err
+				return
+			}
+		}
+		streamed <- nil
+	}()
+
+	resp, err := cam.DoCommand(ctx, map[string]interface{}{"command": "measure_fps", "frames": 10})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["frames"], test.ShouldEqual, 10)
+	test.That(t, resp["configured_fps"], test.ShouldEqual, 30)
+	// frames are throttled to max_fps, which the measured rate can't exceed by much
+	test.That(t, resp["fps"], test.ShouldBeBetween, 10, 55)
+	test.That(t, resp["min_interval_ms"], test.ShouldBeLessThanOrEqualTo, resp["mean_interval_ms"])
+	test.That(t, resp["max_interval_ms"], test.ShouldBeGreaterThanOrEqualTo, resp["mean_interval_ms"])
+	test.That(t, resp["jitter_ms"], test.ShouldBeGreaterThanOrEqualTo, 0)
+	test.That(t, <-streamed, test.ShouldBeNil)
+
+	health, err := cam.DoCommand(ctx, map[string]interface{}{"command": "health"})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, health["last_frame_time"], test.ShouldNotBeEmpty)
+
+	for _, frames := range []interface{}{nil, 1, 1001} {
+		_, err = cam.DoCommand(ctx, map[string]interface{}{"command": "measure_fps", "frames": frames})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "measure_fps command needs between 2 and 1000 frames")
+	}
+	_, err = cam.DoCommand(ctx, map[string]interface{}{"command": "measure_fps", "frames": "many"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot parse measure_fps command")
+}
+
+func TestWebcamMissingDevice(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return nil, errors.New("no such webcam")
+	}
+	noDrivers := func() []driver.Driver { return nil }
+	conf := resource.Config{
+		Name:  "webcam",
+		API:   camera.API,
+		Model: videosource.ModelWebcam,
+	}
+
+	// without any video devices, the error points at the hardware whether or not a path is configured
+	for _, webCfg := range []*videosource.WebcamConfig{{}, {Path: "some label"}} {
+		conf.ConvertedAttributes = webCfg
+		_, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, noDrivers, getSource, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no video devices were found")
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no such webcam")
+	}
+
+	// with video devices, a path matching none of them points at the configuration
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "missing label"}
+	_, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring,
+		`no video device is named "missing label", check video_path against the available devices [some label another label]`)
+
+	// a device that exists but cannot be opened is reported as is
+	conf.ConvertedAttributes = &videosource.WebcamConfig{Path: "some label"}
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "no such webcam")
+	test.That(t, err.Error(), test.ShouldNotContainSubstring, "no video device")
+}
+
+func TestWebcamUndistort(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 80, Height: 60, FrameFormat: "some format", FrameRate: 30.0}
+	distorted := image.NewRGBA(image.Rect(0, 0, props.Width, props.Height))
+	for y := 0; y < props.Height; y++ {
+		for x := 0; x < props.Width; x++ {
+			distorted.Set(x, y, color.RGBA{uint8(3 * x), uint8(4 * y), 0, 0xff})
+		}
+	}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		reader := gostream.VideoReaderFunc(func(ctx context.Context) (image.Image, func(), error) {
+			return distorted, func() {}, nil
+		})
+		return gostream.NewVideoSourceForDriver(newFakeDriver(name, []prop.Media{{Video: props}}), reader, props), nil
+	}
+
+	intrinsics := &transform.PinholeCameraIntrinsics{Width: 80, Height: 60, Fx: 50, Fy: 50, Ppx: 40, Ppy: 30}
+	distortion := &transform.BrownConrady{RadialK1: -0.3, RadialK2: 0.1}
+	conf := resource.Config{
+		Name:  "webcam",
+		API:   camera.API,
+		Model: videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{
+			Path:                 "some label",
+			CameraParameters:     intrinsics,
+			DistortionParameters: distortion,
+			Undistort:            true,
+		},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	model := &transform.PinholeCameraModel{PinholeCameraIntrinsics: intrinsics, Distortion: distortion}
+	undistortion, err := model.UndistortionMap()
+	test.That(t, err, test.ShouldBeNil)
+	expected, err := undistortion.Undistort(distorted)
+	test.That(t, err, test.ShouldBeNil)
+	imgs, _, err := cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image, test.ShouldResemble, expected)
+	test.That(t, imgs[0].Image, test.ShouldNotResemble, distorted)
+
+	// the rectified images have no distortion left to report
+	camProps, err := cam.Properties(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, camProps.IntrinsicParams, test.ShouldResemble, intrinsics)
+	test.That(t, camProps.DistortionParams, test.ShouldBeNil)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+	// frames which do not match the intrinsics cannot be rectified
+	webCfg := &videosource.WebcamConfig{
+		Path:                 "some label",
+		CameraParameters:     &transform.PinholeCameraIntrinsics{Width: 40, Height: 30, Fx: 25, Fy: 25, Ppx: 20, Ppy: 15},
+		DistortionParameters: distortion,
+		Undistort:            true,
+	}
+	conf.ConvertedAttributes = webCfg
+	_, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring,
+		"intrinsic_parameters are for 40x30 frames but the webcam delivers 80x60 frames, which cannot be undistorted")
+
+	// unless the intrinsics are scaled to the frames first
+	webCfg.ScaleIntrinsics = true
+	cam, err = videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	imgs, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image, test.ShouldResemble, expected)
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestWebcamIntrinsicsResolution(t *testing.T) {
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+	halfSize := &transform.PinholeCameraIntrinsics{Width: 160, Height: 120, Fx: 100, Fy: 110, Ppx: 80, Ppy: 60}
+	const warning = "intrinsic_parameters do not match the resolution of the webcam, so projections will be wrong; " +
+		"set scale_intrinsics to scale them to the resolution"
+
+	for _, tc := range []struct {
+		name       string
+		intrinsics *transform.PinholeCameraIntrinsics
+		scale      bool
+		expected   *transform.PinholeCameraIntrinsics
+		warned     bool
+		err        string
+	}{
+		{
+			name:       "matched",
+			intrinsics: &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 200, Fy: 220, Ppx: 160, Ppy: 120},
+			expected:   &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 200, Fy: 220, Ppx: 160, Ppy: 120},
+		},
+		{
+			name:       "mismatched",
+			intrinsics: halfSize,
+			expected:   halfSize,
+			warned:     true,
+		},
+		{
+			name:       "scaled",
+			intrinsics: halfSize,
+			scale:      true,
+			expected:   &transform.PinholeCameraIntrinsics{Width: 320, Height: 240, Fx: 200, Fy: 220, Ppx: 160, Ppy: 120},
+		},
+		{
+			name:       "not scalable",
+			intrinsics: &transform.PinholeCameraIntrinsics{Width: 160, Height: 160, Fx: 100, Fy: 100, Ppx: 80, Ppy: 80},
+			scale:      true,
+			err: "intrinsic_parameters for 160x160 frames cannot be scaled to the 320x240 frames the webcam delivers, " +
+				"which have a different aspect ratio",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, obs := logging.NewObservedTestLogger(t)
+			conf := resource.Config{
+				Name:  "webcam",
+				API:   camera.API,
+				Model: videosource.ModelWebcam,
+				ConvertedAttributes: &videosource.WebcamConfig{
+					Path:             "some label",
+					CameraParameters: tc.intrinsics,
+					ScaleIntrinsics:  tc.scale,
+				},
+			}
+			cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+			if tc.err != "" {
+				test.That(t, err, test.ShouldNotBeNil)
+				test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+				return
+			}
+			test.That(t, err, test.ShouldBeNil)
+			camProps, err := cam.Properties(context.Background())
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, camProps.IntrinsicParams, test.ShouldResemble, tc.expected)
+			test.That(t, obs.FilterMessage(warning).Len() > 0, test.ShouldEqual, tc.warned)
+			test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+		})
+	}
+}
+
+func TestWebcamMimeTypes(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	for _, tc := range []struct {
+		format    frame.Format
+		mimeTypes []string
+	}{
+		{frame.FormatMJPEG, []string{utils.MimeTypeJPEG, utils.MimeTypeRawRGBA, utils.MimeTypePNG}},
+		{frame.FormatYUYV, []string{utils.MimeTypeRawRGBA, utils.MimeTypeJPEG, utils.MimeTypePNG}},
+		{frame.FormatZ16, []string{utils.MimeTypeRawDepth, utils.MimeTypePNG}},
+	} {
+		t.Run(string(tc.format), func(t *testing.T) {
+			props := prop.Video{Width: 320, Height: 240, FrameFormat: tc.format, FrameRate: 30.0}
+			getSource := func(
+				name string,
+				constraints mediadevices.MediaStreamConstraints,
+				logger logging.Logger,
+			) (gostream.VideoSource, error) {
+				return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+			}
+			cam, err := videosource.NewWebcamWithSources(context.Background(), nil, resource.Config{
+				Name:                "webcam",
+				API:                 camera.API,
+				Model:               videosource.ModelWebcam,
+				ConvertedAttributes: &videosource.WebcamConfig{Path: "some label"},
+			}, testGetDrivers, getSource, logger)
+			test.That(t, err, test.ShouldBeNil)
+			defer func() { test.That(t, cam.Close(context.Background()), test.ShouldBeNil) }()
+			camProps, err := cam.Properties(context.Background())
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, camProps.MimeTypes, test.ShouldResemble, tc.mimeTypes)
+		})
+	}
+}
+
+func TestWebcamControls(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+
+	// the fake webcam has no device to set controls on, which is warned about rather than failing
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label", Controls: map[string]int{"brightness": 10}},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, testGetDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	_, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+
+	resp, err := cam.DoCommand(context.Background(), map[string]interface{}{
+		"command":  "set_controls",
+		"controls": map[string]interface{}{"gain": 20.0},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	failed, ok := resp["failed"].(map[string]interface{})
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, failed, test.ShouldContainKey, "gain")
+
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{
+		"command":  "set_controls",
+		"controls": map[string]interface{}{"zoom": 2},
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown control "zoom"`)
+
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "set_controls"})
+	test.That(t, err, test.ShouldBeError, errors.New("set_controls command needs at least one control"))
+
+	// nor is there a device to list controls of
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "list_controls"})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot list controls of webcam device")
+	test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+}
+
+func TestWebcamPreferHighestResolution(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	media := []prop.Media{
+		{Video: prop.Video{Width: 640, Height: 480, FrameFormat: "YUYV", FrameRate: 30.0}},
+		{Video: prop.Video{Width: 1920, Height: 1080, FrameFormat: "MJPEG", FrameRate: 30.0}},
+		{Video: prop.Video{Width: 1280, Height: 720, FrameFormat: "YUYV", FrameRate: 30.0}},
+	}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{newFakeDriver("some label", media)}
+	}
+	// the fake webcam delivers the resolution asked for, or its smallest if none is
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		var track mediadevices.MediaTrackConstraints
+		constraints.Video(&track)
+		props := media[0].Video
+		if width, exact := track.Width.(prop.IntExact); exact {
+			for _, m := range media {
+				if m.Width == int(width) {
+					props = m.Video
+				}
+			}
+		}
+		return newFakeVideoSource(newFakeDriver(name, media), props), nil
+	}
+
+	for _, tc := range []struct {
+		name          string
+		conf          videosource.WebcamConfig
+		expectedWidth int
+	}{
+		{"any format", videosource.WebcamConfig{Path: "some label", PreferHighestResolution: true}, 1920},
+		{"configured format", videosource.WebcamConfig{Path: "some label", PreferHighestResolution: true, Format: "YUYV"}, 1280},
+		{"configured bounds", videosource.WebcamConfig{Path: "some label", PreferHighestResolution: true, MaxWidth: 1280}, 1280},
+		{"not set", videosource.WebcamConfig{Path: "some label"}, 640},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := resource.Config{
+				Name:                "webcam",
+				API:                 camera.API,
+				Model:               videosource.ModelWebcam,
+				ConvertedAttributes: &tc.conf,
+			}
+			cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, getDrivers, getSource, logger)
+			test.That(t, err, test.ShouldBeNil)
+			imgs, _, err := cam.Images(context.Background())
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, tc.expectedWidth)
+			test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+		})
+	}
+}
+
+func TestWebcamDefaultResolution(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	props := prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}
+	var track mediadevices.MediaTrackConstraints
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		track = mediadevices.MediaTrackConstraints{}
+		constraints.Video(&track)
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+	open := func(conf videosource.WebcamConfig) {
+		t.Helper()
+		cam, err := videosource.NewWebcamWithSources(context.Background(), nil, resource.Config{
+			Name:                "webcam",
+			API:                 camera.API,
+			Model:               videosource.ModelWebcam,
+			ConvertedAttributes: &conf,
+		}, testGetDrivers, getSource, logger)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+	}
+
+	open(videosource.WebcamConfig{Path: "some label"})
+	test.That(t, track.Width.(prop.IntRanged).Ideal, test.ShouldEqual, videosource.DefaultWidth)
+	test.That(t, track.Height.(prop.IntRanged).Ideal, test.ShouldEqual, videosource.DefaultHeight)
+
+	test.That(t, videosource.SetDefaultResolution(0, 720), test.ShouldBeError,
+		errors.New("default resolution must be positive, got 0x720"))
+	test.That(t, videosource.SetDefaultResolution(1280, 720), test.ShouldBeNil)
+	defer func() {
+		test.That(t, videosource.SetDefaultResolution(videosource.DefaultWidth, videosource.DefaultHeight), test.ShouldBeNil)
+	}()
+
+	// the default applies only when the config sets no width or height
+	open(videosource.WebcamConfig{Path: "some label"})
+	test.That(t, track.Width.(prop.IntRanged).Ideal, test.ShouldEqual, 1280)
+	test.That(t, track.Height.(prop.IntRanged).Ideal, test.ShouldEqual, 720)
+
+	open(videosource.WebcamConfig{Path: "some label", Width: 320, Height: 240})
+	test.That(t, track.Width, test.ShouldEqual, prop.IntExact(320))
+	test.That(t, track.Height, test.ShouldEqual, prop.IntExact(240))
+
+	// and is kept within configured bounds
+	open(videosource.WebcamConfig{Path: "some label", MaxWidth: 1024})
+	test.That(t, track.Width.(prop.IntRanged).Ideal, test.ShouldEqual, 1024)
+	test.That(t, track.Height.(prop.IntRanged).Ideal, test.ShouldEqual, 720)
+}
+
+func TestWebcamConstraintMismatchWarning(t *testing.T) {
+	const warning = "webcam negotiated video properties that do not match the requested constraints"
+	props := prop.Video{Width: 1280, Height: 720, FrameFormat: frame.FormatMJPEG, FrameRate: 30.0}
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		return newFakeVideoSource(newFakeDriver(name, []prop.Media{{Video: props}}), props), nil
+	}
+	for _, tc := range []struct {
+		name    string
+		conf    videosource.WebcamConfig
+		warning bool
+	}{
+		{"satisfied", videosource.WebcamConfig{Path: "some label", MaxWidth: 1920}, false},
+		{"too small", videosource.WebcamConfig{Path: "some label", MinWidth: 1920}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			logger, obs := logging.NewObservedTestLogger(t)
+			cam, err := videosource.NewWebcamWithSources(context.Background(), nil, resource.Config{
+				Name:                "webcam",
+				API:                 camera.API,
+				Model:               videosource.ModelWebcam,
+				ConvertedAttributes: &tc.conf,
+			}, testGetDrivers, getSource, logger)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+
+			warnings := obs.FilterMessage(warning).All()
+			if !tc.warning {
+				test.That(t, warnings, test.ShouldBeEmpty)
+				return
+			}
+			test.That(t, warnings, test.ShouldHaveLength, 1)
+			fields := warnings[0].ContextMap()
+			test.That(t, fields["requested_width"], test.ShouldContainSubstring, "1920")
+			test.That(t, fields["negotiated_width"], test.ShouldEqual, 1280)
+			test.That(t, fields["negotiated_height"], test.ShouldEqual, 720)
+		})
+	}
+}
+
+func TestWebcamReconfigureCommand(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	media := []prop.Media{
+		{Video: prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}},
+		{Video: prop.Video{Width: 640, Height: 480, FrameFormat: "some format", FrameRate: 15.0}},
+	}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{newFakeDriver("some label", media)}
+	}
+	// the fake webcam only supports the resolutions of its driver, and delivers the first of them if none is asked for
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		var track mediadevices.MediaTrackConstraints
+		constraints.Video(&track)
+		width, exact := track.Width.(prop.IntExact)
+		if !exact {
+			width = prop.IntExact(media[0].Width)
+		}
+		for _, m := range media {
+			if m.Width == int(width) {
+				return newFakeVideoSource(newFakeDriver(name, media), m.Video), nil
+			}
+		}
+		return nil, errors.New("unsupported resolution")
+	}
+
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label"},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, getDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	resp, err := cam.DoCommand(context.Background(), map[string]interface{}{
+		"command": "reconfigure",
+		"width":   640.0,
+		"height":  480.0,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["width_px"], test.ShouldEqual, 640)
+	test.That(t, resp["frame_rate"], test.ShouldEqual, float32(15))
+	imgs, _, err := cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 640)
+
+	// an unsupported resolution reverts to the previous one
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{
+		"command": "reconfigure",
+		"width":   1280,
+		"height":  720,
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "reverted to previous settings")
+	imgs, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 640)
+
+	// invalid settings are rejected without reopening
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "reconfigure", "width": -1})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "illegal negative dimensions")
+
+	// clearing the resolution goes back to the default
+	resp, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "reconfigure", "width": 0, "height": 0})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp["width_px"], test.ShouldEqual, 320)
+}
+
+func TestWebcamReconnectsAfterFailedRevert(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	media := []prop.Media{{Video: prop.Video{Width: 320, Height: 240, FrameFormat: "some format", FrameRate: 30.0}}}
+	getDrivers := func() []driver.Driver {
+		return []driver.Driver{newFakeDriver("some label", media)}
+	}
+	var unplugged atomic.Bool
+	getSource := func(
+		name string,
+		constraints mediadevices.MediaStreamConstraints,
+		logger logging.Logger,
+	) (gostream.VideoSource, error) {
+		if unplugged.Load() {
+			return nil, errors.New("no such device")
+		}
+		return newFakeVideoSource(newFakeDriver(name, media), media[0].Video), nil
+	}
+
+	conf := resource.Config{
+		Name:                "webcam",
+		API:                 camera.API,
+		Model:               videosource.ModelWebcam,
+		ConvertedAttributes: &videosource.WebcamConfig{Path: "some label", ReconnectIntervalMs: 10},
+	}
+	cam, err := videosource.NewWebcamWithSources(context.Background(), nil, conf, getDrivers, getSource, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, cam.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	// the device goes away while it is being reopened, so neither the new nor the previous settings can be applied
+	unplugged.Store(true)
+	_, err = cam.DoCommand(context.Background(), map[string]interface{}{"command": "reconfigure", "width": 640.0, "height": 480.0})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot reopen webcam with previous settings")
+	_, _, err = cam.Images(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "camera is disconnected")
+
+	// once it is back, the camera reopens it with the previous settings without being reconfigured
+	unplugged.Store(false)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		imgs, _, err := cam.Images(context.Background())
+		test.That(tb, err, test.ShouldBeNil)
+		if err != nil {
+			return
+		}
+		test.That(tb, imgs[0].Image.Bounds().Dx(), test.ShouldEqual, 320)
+	})
+}
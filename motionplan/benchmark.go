@@ -0,0 +1,98 @@
+package motionplan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"time"
+
+	"go.viam.com/rdk/motionplan/ik"
+)
+
+// BenchmarkResult summarizes the plans produced by planning the same request repeatedly with BenchmarkPlan.
+type BenchmarkResult struct {
+	// Runs is the number of times the request was planned.
+	Runs int
+	// Successes is the number of runs which produced a plan.
+	Successes int
+	// MeanDuration is the mean wall time of a run, whether or not it produced a plan.
+	MeanDuration time.Duration
+	// P95Duration is the 95th percentile wall time of a run, whether or not it produced a plan.
+	P95Duration time.Duration
+	// MeanPathLength is the mean length of the plans produced, measured as the L2 distance travelled through the inputs of their
+	// trajectories. It is zero if no run produced a plan.
+	MeanPathLength float64
+}
+
+// SuccessRate returns the fraction of runs which produced a plan.
+func (r BenchmarkResult) SuccessRate() float64 {
+	if r.Runs == 0 {
+		return 0
+	}
+	return float64(r.Successes) / float64(r.Runs)
+}
+
+// ReportMetrics reports the result as custom metrics of a Go benchmark, and is meant to be passed a *testing.B.
+func (r BenchmarkResult) ReportMetrics(b interface{ ReportMetric(n float64, unit string) }) {
+	b.ReportMetric(float64(r.MeanDuration.Milliseconds()), "mean-ms/plan")
+	b.ReportMetric(float64(r.P95Duration.Milliseconds()), "p95-ms/plan")
+	b.ReportMetric(r.SuccessRate(), "success-rate")
+	b.ReportMetric(r.MeanPathLength, "path-length")
+}
+
+// BenchmarkPlan plans the request the given number of times and summarizes how long planning took and how good the plans were.
+// Each run uses a different random seed, counting up from the request's rseed option, so that the runs sample the behavior of
+// the planner rather than repeat the same plan. A run which fails to plan counts against the success rate; an error is only
+// returned if the request is malformed or ctx is done.
+func BenchmarkPlan(ctx context.Context, request *PlanRequest, runs int) (BenchmarkResult, error) {
+	if runs < 1 {
+		return BenchmarkResult{}, fmt.Errorf("runs must be at least 1, got %d", runs)
+	}
+	if err := request.validatePlanRequest(); err != nil {
+		return BenchmarkResult{}, err
+	}
+	seed, err := randomSeedFromOptions(request.Options)
+	if err != nil {
+		return BenchmarkResult{}, err
+	}
+
+	result := BenchmarkResult{Runs: runs}
+	durations := make([]time.Duration, 0, runs)
+	var totalDuration time.Duration
+	var totalLength float64
+	for i := 0; i < runs; i++ {
+		// copy the request so that the caller's options are left untouched
+		runRequest := *request
+		runRequest.Options = maps.Clone(request.Options)
+		if runRequest.Options == nil {
+			runRequest.Options = map[string]interface{}{}
+		}
+		runRequest.Options["rseed"] = seed + i
+
+		start := time.Now()
+		plan, err := PlanMotion(ctx, &runRequest)
+		duration := time.Since(start)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return BenchmarkResult{}, ctxErr
+		}
+		durations = append(durations, duration)
+		totalDuration += duration
+		if err != nil && !errors.Is(err, ErrPlanSuboptimal) {
+			continue
+		}
+		result.Successes++
+		totalLength += plan.Trajectory().EvaluateCost(ik.L2InputMetric)
+	}
+
+	slices.Sort(durations)
+	result.MeanDuration = totalDuration / time.Duration(runs)
+	// nearest rank percentile
+	result.P95Duration = durations[int(math.Ceil(0.95*float64(runs)))-1]
+	if result.Successes > 0 {
+		result.MeanPathLength = totalLength / float64(result.Successes)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/logging"
+	frame "go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// obstacleAvoidanceRequest returns a request for a 2D base to drive around a box placed between it and its goal.
+func obstacleAvoidanceRequest(tb testing.TB) *PlanRequest {
+	tb.Helper()
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(tb, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(tb, err, test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(tb, err, test.ShouldBeNil)
+	worldState, err := frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(tb, err, test.ShouldBeNil)
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(tb, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	return &PlanRequest{
+		Logger:             logging.NewTestLogger(tb),
+		Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})),
+		Frame:              model,
+		StartConfiguration: map[string][]frame.Input{model.Name(): make([]frame.Input, 3)},
+		FrameSystem:        fs,
+		WorldState:         worldState,
+		Options:            map[string]interface{}{"rseed": 5},
+	}
+}
+
+func TestBenchmarkPlan(t *testing.T) {
+	request := obstacleAvoidanceRequest(t)
+
+	_, err := BenchmarkPlan(context.Background(), request, 0)
+	test.That(t, err, test.ShouldBeError, "runs must be at least 1, got 0")
+
+	result, err := BenchmarkPlan(context.Background(), request, 2)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, result.Runs, test.ShouldEqual, 2)
+	test.That(t, result.Successes, test.ShouldEqual, 2)
+	test.That(t, result.SuccessRate(), test.ShouldEqual, 1)
+	test.That(t, result.MeanDuration, test.ShouldBeGreaterThan, 0)
+	test.That(t, result.P95Duration, test.ShouldBeGreaterThan, 0)
+	// the base has to go around the box, so the path is longer than the straight line to the goal
+	test.That(t, result.MeanPathLength, test.ShouldBeGreaterThan, 100)
+
+	// the caller's options are left untouched
+	test.That(t, request.Options, test.ShouldResemble, map[string]interface{}{"rseed": 5})
+
+	// malformed requests are errors rather than failed runs
+	request.Goal = nil
+	_, err = BenchmarkPlan(context.Background(), request, 3)
+	test.That(t, err, test.ShouldBeError, "PlanRequest cannot have nil goal")
+
+	test.That(t, BenchmarkResult{}.SuccessRate(), test.ShouldEqual, 0)
+}
+
+func BenchmarkPlanObstacleAvoidance(b *testing.B) {
+	result, err := BenchmarkPlan(context.Background(), obstacleAvoidanceRequest(b), b.N)
+	test.That(b, err, test.ShouldBeNil)
+	result.ReportMetrics(b)
+}
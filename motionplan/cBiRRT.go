@@ -169,6 +169,13 @@ func (mp *cBiRRTMotionPlanner) rrtBackgroundRunner(
 			return
 		default:
 		}
+		if mp.nodeLimitReached() {
+			mp.logger.CDebugf(ctx, "CBiRRT reached the node limit after %d iterations", i)
+			rrt.solutionChan <- &rrtSolution{err: ErrNodeLimitReached, maps: rrt.maps}
+			return
+		}
+		mp.iterations.Add(1)
+		mp.planOpts.progress.addIteration()
 
 		tryExtend := func(target node) (node, node, error) {
 			// attempt to extend maps 1 and 2 towards the target
@@ -236,12 +243,13 @@ func (mp *cBiRRTMotionPlanner) rrtBackgroundRunner(
 			mp.logger.CDebugf(ctx, "CBiRRT found solution after %d iterations", i)
 			cancel()
 			path := extractPath(rrt.maps.startMap, rrt.maps.goalMap, &nodePair{map1reached, map2reached}, true)
+			mp.planOpts.progress.setBestPath(path)
 			rrt.solutionChan <- &rrtSolution{steps: path, maps: rrt.maps}
 			return
 		}
 
 		// sample near map 1 and switch which map is which to keep adding to them even
-		target, err = mp.sample(map1reached, i)
+		target, err = mp.goalBiasedSample(map1reached, i, rrt.maps.optNode)
 		if err != nil {
 			rrt.solutionChan <- &rrtSolution{err: err, maps: rrt.maps}
 			return
@@ -320,6 +328,9 @@ func (mp *cBiRRTMotionPlanner) constrainedExtend(
 				doubled = false
 			}
 			// constrainNear will ensure path between oldNear and newNear satisfies constraints along the way
+			if !mp.reserveNode() {
+				break
+			}
 			near = &basicNode{q: newNear}
 			rrtMap[near] = oldNear
 		} else {
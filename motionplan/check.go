@@ -192,6 +192,7 @@ func checkPlanRelative(
 		startingInputs,
 		worldState,
 		nil,
+		nil,
 		nil, // no pb.Constraints
 		nil, // no plannOpts
 	); err != nil {
@@ -299,6 +300,7 @@ func checkPlanAbsolute(
 		startingInputs,
 		worldState,
 		nil,
+		nil,
 		nil, // no pb.Constraints
 		nil, // no plannOpts
 	); err != nil {
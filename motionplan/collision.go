@@ -19,6 +19,16 @@ type Collision struct {
 	penetrationDepth float64
 }
 
+// Names returns the names of the two Geometry objects in collision.
+func (c Collision) Names() (string, string) {
+	return c.name1, c.name2
+}
+
+// String describes which Geometry objects are in collision.
+func (c Collision) String() string {
+	return fmt.Sprintf("%s collided with %s", c.name1, c.name2)
+}
+
 // collisionsAlmostEqual compares two Collisions and returns if they are almost equal.
 func collisionsAlmostEqual(c1, c2 Collision) bool {
 	return ((c1.name1 == c2.name1 && c1.name2 == c2.name2) || (c1.name1 == c2.name2 && c1.name2 == c2.name1)) &&
@@ -135,6 +145,30 @@ func collisionSpecifications(
 	return allowedCollisions, nil
 }
 
+// adjacentLinkCollisions returns the pairs of link geometries in a model's kinematic chain which are joined to one another, and so may
+// always be touching. Frames which are not a SimpleModel have no known chain, and nil is returned.
+func adjacentLinkCollisions(f referenceframe.Frame) []*Collision {
+	model, ok := f.(*referenceframe.SimpleModel)
+	if !ok {
+		return nil
+	}
+	var collisions []*Collision
+	previous := ""
+	for _, transform := range model.OrdTransforms {
+		geometriesInFrame, err := transform.Geometries(make([]referenceframe.Input, len(transform.DoF())))
+		if err != nil || len(geometriesInFrame.Geometries()) == 0 {
+			continue
+		}
+		// models label their geometries by prefixing the model name, and only the first geometry of each frame is used
+		name := model.Name() + ":" + geometriesInFrame.Geometries()[0].Label()
+		if previous != "" {
+			collisions = append(collisions, &Collision{name1: previous, name2: name})
+		}
+		previous = name
+	}
+	return collisions
+}
+
 // geometryGraph is a struct that stores distance relationships between sets of geometries.
 type geometryGraph struct {
 	// x and y are the two sets of geometries, each of which will be compared to the geometries in the other set
@@ -220,6 +254,11 @@ func newCollisionGraph(x, y []spatial.Geometry,
 	var distance float64
 	for xName, xGeometry := range cg.x {
 		for yName, yGeometry := range cg.y {
+			if !reportDistances && separated(xGeometry, yGeometry, collisionBufferMM) {
+				// when only the presence of a collision matters, pairs whose bounding boxes are too far apart to collide are skipped
+				// before anything else, and no distance is stored for them since a pair without one is never considered in collision
+				continue
+			}
 			if _, ok := cg.getDistance(xName, yName); ok || xGeometry == yGeometry {
 				// geometry pair already has distance information associated with it, or is comparing with itself - skip to next pair
 				continue
@@ -241,6 +280,23 @@ func newCollisionGraph(x, y []spatial.Geometry,
 	return cg, nil
 }
 
+// separated returns true if the axis aligned bounding boxes of the two geometries are more than collisionBufferMM apart along some
+// axis, in which case the geometries themselves cannot be in collision. This is much cheaper than an exact check. Geometries which
+// cannot be bounded, such as pointclouds, are never considered separated.
+func separated(x, y spatial.Geometry, collisionBufferMM float64) bool {
+	xMin, xMax, err := spatial.BoundingBox(x)
+	if err != nil {
+		return false
+	}
+	yMin, yMax, err := spatial.BoundingBox(y)
+	if err != nil {
+		return false
+	}
+	return xMin.X-yMax.X > collisionBufferMM || yMin.X-xMax.X > collisionBufferMM ||
+		xMin.Y-yMax.Y > collisionBufferMM || yMin.Y-xMax.Y > collisionBufferMM ||
+		xMin.Z-yMax.Z > collisionBufferMM || yMin.Z-xMax.Z > collisionBufferMM
+}
+
 // checkCollision takes a pair of geometries and returns the distance between them.
 // If this number is less than the CollisionBuffer they can be considered to be in collision.
 func (cg *collisionGraph) checkCollision(x, y spatial.Geometry, collisionBufferMM float64) (float64, error) {
@@ -291,6 +347,31 @@ func (cg *collisionGraph) collisions(collisionBufferMM float64) []Collision {
 	return collisions
 }
 
+// MinObstacleDistance returns the minimum distance between any geometry of the frame f at the given inputs and any of the obstacles,
+// which must be expressed in the frame's parent frame. The distance is negative if a geometry penetrates an obstacle, and is
+// positive infinity if either the frame or the obstacles have no geometries. No intermediate collision graph is built, so this is
+// cheap enough to call for every node a planner considers.
+func MinObstacleDistance(f referenceframe.Frame, inputs []referenceframe.Input, obstacles []spatial.Geometry) (float64, error) {
+	geometries, err := f.Geometries(inputs)
+	if err != nil {
+		return math.NaN(), err
+	}
+	minDistance := math.Inf(1)
+	for _, geometry := range geometries.Geometries() {
+		for _, obstacle := range obstacles {
+			// defer to the obstacle if the frame's geometry does not know how to measure against it, as in checkCollision
+			distance, err := geometry.DistanceFrom(obstacle)
+			if err != nil {
+				if distance, err = obstacle.DistanceFrom(geometry); err != nil {
+					return math.NaN(), err
+				}
+			}
+			minDistance = math.Min(minDistance, distance)
+		}
+	}
+	return minDistance, nil
+}
+
 // addCollisionSpecification marks the two objects specified as colliding.
 func (cg *collisionGraph) addCollisionSpecification(specification *Collision) {
 	cg.setDistance(specification.name1, specification.name2, math.Inf(-1))
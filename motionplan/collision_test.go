@@ -1,11 +1,15 @@
 package motionplan
 
 import (
+	"math"
+	"math/rand"
+	"strconv"
 	"testing"
 
 	"github.com/golang/geo/r3"
 	"go.viam.com/test"
 
+	"go.viam.com/rdk/motionplan/ik"
 	frame "go.viam.com/rdk/referenceframe"
 	spatial "go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/utils"
@@ -141,3 +145,112 @@ func TestUniqueCollisions(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, collisionListsAlmostEqual(cg.collisions(defaultCollisionBufferMM), expectedCollisions[:1]), test.ShouldBeTrue)
 }
+
+func TestMinObstacleDistance(t *testing.T) {
+	sphere, err := spatial.NewSphere(spatial.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame("test", []frame.Limit{{-100, 100}, {-100, 100}}, sphere)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{50, 0, 0}), r3.Vector{20, 20, 20}, "box")
+	test.That(t, err, test.ShouldBeNil)
+	farSphere, err := spatial.NewSphere(spatial.NewPoseFromPoint(r3.Vector{0, 80, 0}), 5, "far")
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := []spatial.Geometry{box, farSphere}
+
+	distance, err := MinObstacleDistance(model, frame.FloatsToInputs([]float64{0, 0}), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, distance, test.ShouldAlmostEqual, 30, 1e-6)
+
+	// penetrating the box gives a negative distance
+	distance, err = MinObstacleDistance(model, frame.FloatsToInputs([]float64{35, 0}), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, distance, test.ShouldAlmostEqual, -5, 1e-6)
+
+	// the nearest obstacle changes as the frame moves
+	distance, err = MinObstacleDistance(model, frame.FloatsToInputs([]float64{0, 60}), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, distance, test.ShouldAlmostEqual, 5, 1e-6)
+
+	distance, err = MinObstacleDistance(model, frame.FloatsToInputs([]float64{0, 0}), nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, math.IsInf(distance, 1), test.ShouldBeTrue)
+
+	_, err = MinObstacleDistance(model, frame.FloatsToInputs([]float64{0}), obstacles)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+// clutteredScene returns n obstacles of random shape, size and pose scattered around the workspace of an arm at the origin.
+func clutteredScene(tb testing.TB, rseed *rand.Rand, n int) []spatial.Geometry {
+	tb.Helper()
+	obstacles := make([]spatial.Geometry, 0, n)
+	for i := 0; i < n; i++ {
+		pose := spatial.NewPose(
+			r3.Vector{X: 1600*rseed.Float64() - 800, Y: 1600*rseed.Float64() - 800, Z: 800 * rseed.Float64()},
+			&spatial.OrientationVector{OX: rseed.Float64(), OY: rseed.Float64(), OZ: rseed.Float64() + 0.1, Theta: rseed.Float64()},
+		)
+		size := 20 + 100*rseed.Float64()
+		label := "obstacle" + strconv.Itoa(i)
+		var obstacle spatial.Geometry
+		var err error
+		switch i % 3 {
+		case 0:
+			obstacle, err = spatial.NewBox(pose, r3.Vector{X: size, Y: size / 2, Z: size * 2}, label)
+		case 1:
+			obstacle, err = spatial.NewSphere(pose, size/2, label)
+		default:
+			obstacle, err = spatial.NewCapsule(pose, size/4, size*2, label)
+		}
+		test.That(tb, err, test.ShouldBeNil)
+		obstacles = append(obstacles, obstacle)
+	}
+	return obstacles
+}
+
+func TestCollisionBroadphase(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	rseed := rand.New(rand.NewSource(1))
+	obstacles := clutteredScene(t, rseed, 60)
+
+	// collisions found with bounding boxes pruning the pairs to check must match those found by measuring every pair
+	var collided, free int
+	for i := 0; i < 200; i++ {
+		gf, err := m.Geometries(frame.FloatsToInputs(frame.GenerateRandomConfiguration(m, rseed)))
+		test.That(t, err, test.ShouldBeNil)
+		pruned, err := newCollisionGraph(gf.Geometries(), obstacles, nil, false, defaultCollisionBufferMM)
+		test.That(t, err, test.ShouldBeNil)
+		exhaustive, err := newCollisionGraph(gf.Geometries(), obstacles, nil, true, defaultCollisionBufferMM)
+		test.That(t, err, test.ShouldBeNil)
+		inCollision := len(exhaustive.collisions(defaultCollisionBufferMM)) > 0
+		test.That(t, len(pruned.collisions(defaultCollisionBufferMM)) > 0, test.ShouldEqual, inCollision)
+		if inCollision {
+			collided++
+		} else {
+			free++
+		}
+	}
+	test.That(t, collided, test.ShouldBeGreaterThan, 0)
+	test.That(t, free, test.ShouldBeGreaterThan, 0)
+}
+
+func BenchmarkCollisionConstraintManyObstacles(b *testing.B) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(b, err, test.ShouldBeNil)
+	rseed := rand.New(rand.NewSource(1))
+	obstacles := clutteredScene(b, rseed, 60)
+	gf, err := m.Geometries(make([]frame.Input, len(m.DoF())))
+	test.That(b, err, test.ShouldBeNil)
+	constraint, err := NewCollisionConstraint(gf.Geometries(), obstacles, nil, false, defaultCollisionBufferMM)
+	test.That(b, err, test.ShouldBeNil)
+	states := make([]*ik.State, 100)
+	for i := range states {
+		states[i] = &ik.State{Configuration: frame.FloatsToInputs(frame.GenerateRandomConfiguration(m, rseed)), Frame: m}
+	}
+
+	b.ResetTimer()
+	var valid bool
+	for n := 0; n < b.N; n++ {
+		valid = constraint(states[n%len(states)])
+	}
+	bt = valid
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/golang/geo/r3"
 	motionpb "go.viam.com/api/service/motion/v1"
@@ -14,6 +15,7 @@ import (
 	"go.viam.com/rdk/pointcloud"
 	"go.viam.com/rdk/referenceframe"
 	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
 )
 
 // Given a constraint input with only frames and input positions, calculates the corresponding poses as needed.
@@ -237,6 +239,7 @@ func createAllCollisionConstraints(
 	movingRobotGeometries, staticRobotGeometries, worldGeometries, boundingRegions []spatial.Geometry,
 	allowedCollisions []*Collision,
 	collisionBufferMM float64,
+	obstacleBuffersMM map[string]float64,
 ) (map[string]StateConstraint, error) {
 	constraintMap := map[string]StateConstraint{}
 	var err error
@@ -264,7 +267,14 @@ func createAllCollisionConstraints(
 		}
 
 		// create constraint to keep moving geometries from hitting world state obstacles
-		obstacleConstraint, err := NewCollisionConstraint(movingRobotGeometries, worldGeometries, allowedCollisions, false, collisionBufferMM)
+		obstacleConstraint, err := NewPaddedCollisionConstraint(
+			movingRobotGeometries,
+			worldGeometries,
+			allowedCollisions,
+			false,
+			collisionBufferMM,
+			obstacleBuffersMM,
+		)
 		if err != nil {
 			return nil, err
 		}
@@ -334,31 +344,96 @@ func NewCollisionConstraint(
 
 	// create constraint from reference collision graph
 	constraint := func(state *ik.State) bool {
-		var internalGeoms []spatial.Geometry
-		switch {
-		case state.Configuration != nil:
-			internal, err := state.Frame.Geometries(state.Configuration)
-			if err != nil {
-				return false
-			}
-			internalGeoms = internal.Geometries()
-		case state.Position != nil:
-			// TODO(RSDK-5391): remove this case
-			// If we didn't pass a Configuration, but we do have a Position, then get the geometries at the zero state and
-			// transform them to the Position
-			internal, err := state.Frame.Geometries(make([]referenceframe.Input, len(state.Frame.DoF())))
-			if err != nil {
+		internalGeoms, err := stateGeometries(state)
+		if err != nil {
+			return false
+		}
+		cg, err := newCollisionGraph(internalGeoms, static, zeroCG, reportDistances, collisionBufferMM)
+		if err != nil {
+			return false
+		}
+		return len(cg.collisions(collisionBufferMM)) == 0
+	}
+	return constraint, nil
+}
+
+// NewPaddedCollisionConstraint is like NewCollisionConstraint, but allows the clearance kept from individual static geometries to
+// differ from collisionBufferMM. obstacleBuffersMM maps the labels of static geometries to the buffer to keep from each of them; all
+// other static geometries use collisionBufferMM. Every label given must match a static geometry.
+func NewPaddedCollisionConstraint(
+	moving, static []spatial.Geometry,
+	collisionSpecifications []*Collision,
+	reportDistances bool,
+	collisionBufferMM float64,
+	obstacleBuffersMM map[string]float64,
+) (StateConstraint, error) {
+	// group the static geometries by the buffer they use, so that each group can be checked with an ordinary collision constraint
+	groups := map[float64][]spatial.Geometry{}
+	found := map[string]bool{}
+	for _, geometry := range static {
+		buffer := collisionBufferMM
+		if override, ok := obstacleBuffersMM[geometry.Label()]; ok {
+			buffer = override
+			found[geometry.Label()] = true
+		}
+		groups[buffer] = append(groups[buffer], geometry)
+	}
+	for label, buffer := range obstacleBuffersMM {
+		if !found[label] {
+			return nil, fmt.Errorf("cannot set a collision buffer for %q, no obstacle has that label", label)
+		}
+		if buffer < 0 {
+			return nil, fmt.Errorf("collision buffer for %q can't be negative, got %v", label, buffer)
+		}
+	}
+	if len(groups) <= 1 {
+		return NewCollisionConstraint(moving, static, collisionSpecifications, reportDistances, collisionBufferMM)
+	}
+
+	constraints := make([]StateConstraint, 0, len(groups))
+	for buffer, geometries := range groups {
+		constraint, err := NewCollisionConstraint(moving, geometries, collisionSpecifications, reportDistances, buffer)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return func(state *ik.State) bool {
+		for _, constraint := range constraints {
+			if !constraint(state) {
 				return false
 			}
-			movedGeoms := internal.Geometries()
-			for _, geom := range movedGeoms {
-				internalGeoms = append(internalGeoms, geom.Transform(state.Position))
-			}
-		default:
-			return false
 		}
+		return true
+	}, nil
+}
 
-		cg, err := newCollisionGraph(internalGeoms, static, zeroCG, reportDistances, collisionBufferMM)
+// NewSelfCollisionConstraint creates a constraint which is violated if any two link geometries of the model collide with one another.
+// Links which are adjacent in the model's kinematic chain are expected to touch and are excluded, regardless of the starting state.
+func NewSelfCollisionConstraint(model referenceframe.Frame, collisionBufferMM float64) (StateConstraint, error) {
+	geometriesInFrame, err := model.Geometries(make([]referenceframe.Input, len(model.DoF())))
+	if err != nil {
+		return nil, err
+	}
+	geometries, err := createUniqueCollisionMap(geometriesInFrame.Geometries())
+	if err != nil {
+		return nil, err
+	}
+
+	// the reference graph records only the adjacent links as colliding, so that every other pair is always checked
+	reference := &collisionGraph{geometryGraph: newGeometryGraph(geometries, geometries)}
+	for _, adjacent := range adjacentLinkCollisions(model) {
+		if _, ok := geometries[adjacent.name1]; ok {
+			reference.addCollisionSpecification(adjacent)
+		}
+	}
+
+	constraint := func(state *ik.State) bool {
+		internalGeoms, err := stateGeometries(state)
+		if err != nil {
+			return false
+		}
+		cg, err := newCollisionGraph(internalGeoms, nil, reference, false, collisionBufferMM)
 		if err != nil {
 			return false
 		}
@@ -367,6 +442,67 @@ func NewCollisionConstraint(
 	return constraint, nil
 }
 
+// CollisionReporter returns every collision a state is in, beyond those present in the reference state or explicitly allowed.
+// An empty result means the state is collision free.
+type CollisionReporter func(*ik.State) ([]Collision, error)
+
+// NewCollisionReporter creates a CollisionReporter which checks states the same way as a constraint from NewCollisionConstraint,
+// but names each pair of geometries in collision rather than only reporting pass/fail. The collisions are sorted by name.
+func NewCollisionReporter(
+	moving, static []spatial.Geometry,
+	collisionSpecifications []*Collision,
+	collisionBufferMM float64,
+) (CollisionReporter, error) {
+	zeroCG, err := setupZeroCG(moving, static, collisionSpecifications, collisionBufferMM)
+	if err != nil {
+		return nil, err
+	}
+
+	reporter := func(state *ik.State) ([]Collision, error) {
+		internalGeoms, err := stateGeometries(state)
+		if err != nil {
+			return nil, err
+		}
+		cg, err := newCollisionGraph(internalGeoms, static, zeroCG, true, collisionBufferMM)
+		if err != nil {
+			return nil, err
+		}
+		collisions := cg.collisions(collisionBufferMM)
+		sort.Slice(collisions, func(i, j int) bool {
+			return collisions[i].String() < collisions[j].String()
+		})
+		return collisions, nil
+	}
+	return reporter, nil
+}
+
+// stateGeometries returns the geometries of the state's frame, placed according to its configuration or position.
+func stateGeometries(state *ik.State) ([]spatial.Geometry, error) {
+	switch {
+	case state.Configuration != nil:
+		internal, err := state.Frame.Geometries(state.Configuration)
+		if err != nil {
+			return nil, err
+		}
+		return internal.Geometries(), nil
+	case state.Position != nil:
+		// TODO(RSDK-5391): remove this case
+		// If we didn't pass a Configuration, but we do have a Position, then get the geometries at the zero state and
+		// transform them to the Position
+		internal, err := state.Frame.Geometries(make([]referenceframe.Input, len(state.Frame.DoF())))
+		if err != nil {
+			return nil, err
+		}
+		var internalGeoms []spatial.Geometry
+		for _, geom := range internal.Geometries() {
+			internalGeoms = append(internalGeoms, geom.Transform(state.Position))
+		}
+		return internalGeoms, nil
+	default:
+		return nil, errInvalidConstraint
+	}
+}
+
 // NewAbsoluteLinearInterpolatingConstraint provides a Constraint whose valid manifold allows a specified amount of deviation from the
 // shortest straight-line path between the start and the goal. linTol is the allowed linear deviation in mm, orientTol is the allowed
 // orientation deviation measured by norm of the R3AA orientation difference to the slerp path between start/goal orientations.
@@ -419,6 +555,24 @@ func NewSlerpOrientationConstraint(start, goal spatial.Pose, tolerance float64)
 	return validFunc, gradFunc
 }
 
+// NewFixedOrientationConstraint returns a constraint which is satisfied when the orientation of a state is within `tolerance`
+// radians of the target orientation, as well as a metric which returns the distance in degrees to that orientation.
+func NewFixedOrientationConstraint(target spatial.Orientation, tolerance float64) (StateConstraint, ik.StateMetric) {
+	gradFunc := func(state *ik.State) float64 {
+		return ik.OrientDist(target, state.Position.Orientation())
+	}
+
+	validFunc := func(state *ik.State) bool {
+		err := resolveStatesToPositions(state)
+		if err != nil {
+			return false
+		}
+		return gradFunc(state) <= utils.RadToDeg(tolerance)
+	}
+
+	return validFunc, gradFunc
+}
+
 // NewPlaneConstraint is used to define a constraint space for a plane, and will return 1) a constraint
 // function which will determine whether a point is on the plane and in a valid orientation, and 2) a distance function
 // which will bring a pose into the valid constraint space. The plane normal is assumed to point towards the valid area.
@@ -503,7 +657,8 @@ func NewOctreeCollisionConstraint(octree *pointcloud.BasicOctree, threshold int,
 }
 
 // NewBoundingRegionConstraint will determine if the given list of robot geometries are in collision with the
-// given list of bounding regions.
+// given list of bounding regions. A state only needs to touch one of the bounding regions to be valid; to require that the robot
+// stays entirely within a region, use NewKeepInConstraint instead.
 func NewBoundingRegionConstraint(robotGeoms, boundingRegions []spatial.Geometry, collisionBufferMM float64) StateConstraint {
 	return func(state *ik.State) bool {
 		var internalGeoms []spatial.Geometry
@@ -537,6 +692,107 @@ func NewBoundingRegionConstraint(robotGeoms, boundingRegions []spatial.Geometry,
 	}
 }
 
+// NewKeepInConstraint returns a constraint which is satisfied only when every geometry of the frame, as well as the pose of the end
+// of the frame, lies entirely inside the workspace geometry. This is the opposite of an obstacle: obstacles are keep-out regions
+// that the robot may not touch, while a keep-in region is one that the robot may not leave.
+func NewKeepInConstraint(workspace spatial.Geometry) StateConstraint {
+	return func(state *ik.State) bool {
+		if err := resolveStatesToPositions(state); err != nil {
+			return false
+		}
+		var geometries []spatial.Geometry
+		if state.Configuration != nil {
+			gif, err := state.Frame.Geometries(state.Configuration)
+			if err != nil {
+				return false
+			}
+			geometries = gif.Geometries()
+		}
+		geometries = append(geometries, spatial.NewPoint(state.Position.Point(), ""))
+		for _, geometry := range geometries {
+			inside, err := geometry.EncompassedBy(workspace)
+			if err != nil || !inside {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// NewHalfSpaceConstraint returns a constraint which is satisfied only when every geometry of the frame, as well as the pose of the
+// end of the frame, lies entirely inside the half space. A floor at z = 0 is a half space through the origin with normal +Z.
+func NewHalfSpaceConstraint(halfSpace *spatial.HalfSpace) StateConstraint {
+	return func(state *ik.State) bool {
+		if err := resolveStatesToPositions(state); err != nil {
+			return false
+		}
+		if halfSpace.SignedDistance(state.Position.Point()) < 0 {
+			return false
+		}
+		if state.Configuration == nil {
+			return true
+		}
+		gif, err := state.Frame.Geometries(state.Configuration)
+		if err != nil {
+			return false
+		}
+		for _, geometry := range gif.Geometries() {
+			inside, err := halfSpace.Encompasses(geometry)
+			if err != nil || !inside {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// NewMinHeightConstraint returns a constraint which is satisfied only when the end of the frame is at a height, its Z coordinate in
+// the frame the plan is solved in, of at least minHeight. Unlike NewHalfSpaceConstraint, the geometries of the frame are not checked.
+func NewMinHeightConstraint(minHeight float64) StateConstraint {
+	return func(state *ik.State) bool {
+		if err := resolveStatesToPositions(state); err != nil {
+			return false
+		}
+		return state.Position.Point().Z >= minHeight
+	}
+}
+
+// NewManipulabilityConstraint returns a constraint which is satisfied only when every model moved by the frame has a manipulability,
+// as computed by Manipulability, of at least minManipulability. This keeps the path away from kinematic singularities, where
+// following it would call for wild joint velocities. Frames that are not models are not constrained.
+func NewManipulabilityConstraint(minManipulability float64) StateConstraint {
+	return func(state *ik.State) bool {
+		manipulability, err := minFrameManipulability(state.Frame, state.Configuration)
+		return err == nil && manipulability >= minManipulability
+	}
+}
+
+// minFrameManipulability returns the lowest manipulability of the models moved by the frame at the given inputs, which is infinite
+// if it moves no models.
+func minFrameManipulability(f referenceframe.Frame, inputs []referenceframe.Input) (float64, error) {
+	switch f := f.(type) {
+	case referenceframe.Model:
+		return Manipulability(f, inputs)
+	case *solverFrame:
+		inputMap := f.sliceToMap(inputs)
+		lowest := math.Inf(1)
+		for _, frame := range f.frames {
+			model, ok := frame.(referenceframe.Model)
+			if !ok || len(model.DoF()) == 0 {
+				continue
+			}
+			manipulability, err := Manipulability(model, inputMap[model.Name()])
+			if err != nil {
+				return 0, err
+			}
+			lowest = math.Min(lowest, manipulability)
+		}
+		return lowest, nil
+	default:
+		return math.Inf(1), nil
+	}
+}
+
 // LinearConstraint specifies that the component being moved should move linearly relative to its goal.
 // It does not constrain the motion of components other than the `component_name` specified in motion.Move.
 type LinearConstraint struct {
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
 
@@ -249,6 +250,7 @@ func TestCollisionConstraints(t *testing.T) {
 		worldGeometries.Geometries(),
 		nil, nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 	test.That(t, err, test.ShouldBeNil)
 	for name, constraint := range collisionConstraints {
@@ -317,6 +319,7 @@ func BenchmarkCollisionConstraints(b *testing.B) {
 		worldGeometries.Geometries(),
 		nil, nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 	test.That(b, err, test.ShouldBeNil)
 	for name, constraint := range collisionConstraints {
@@ -334,6 +337,327 @@ func BenchmarkCollisionConstraints(b *testing.B) {
 	bt = b1
 }
 
+func TestCollisionReporter(t *testing.T) {
+	link, err := spatial.NewBox(spatial.NewZeroPose(), r3.Vector{10, 10, 10}, "link3")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.NewTranslationalFrameWithGeometry("arm", r3.Vector{X: 1}, frame.Limit{Min: -500, Max: 500}, link)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: 200}), r3.Vector{20, 20, 20}, "box")
+	test.That(t, err, test.ShouldBeNil)
+
+	startGeoms, err := model.Geometries(frame.FloatsToInputs([]float64{0}))
+	test.That(t, err, test.ShouldBeNil)
+	reporter, err := NewCollisionReporter(startGeoms.Geometries(), []spatial.Geometry{box}, nil, defaultCollisionBufferMM)
+	test.That(t, err, test.ShouldBeNil)
+
+	collisions, err := reporter(&ik.State{Configuration: frame.FloatsToInputs([]float64{0}), Frame: model})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collisions, test.ShouldBeEmpty)
+
+	collisions, err = reporter(&ik.State{Configuration: frame.FloatsToInputs([]float64{200}), Frame: model})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collisions, test.ShouldHaveLength, 1)
+	name1, name2 := collisions[0].Names()
+	test.That(t, name1, test.ShouldEqual, "link3")
+	test.That(t, name2, test.ShouldEqual, "box")
+	test.That(t, collisions[0].String(), test.ShouldEqual, "link3 collided with box")
+
+	_, err = reporter(&ik.State{Frame: model})
+	test.That(t, err, test.ShouldBeError, errInvalidConstraint)
+}
+
+func TestCollisionConstraintsWithPrimitiveObstacles(t *testing.T) {
+	link, err := spatial.NewBox(spatial.NewZeroPose(), r3.Vector{10, 10, 10}, "link3")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.NewTranslationalFrameWithGeometry("arm", r3.Vector{X: 1}, frame.Limit{Min: -500, Max: 500}, link)
+	test.That(t, err, test.ShouldBeNil)
+	sphere, err := spatial.NewSphere(spatial.NewPoseFromPoint(r3.Vector{X: 200}), 10, "sphere")
+	test.That(t, err, test.ShouldBeNil)
+	capsule, err := spatial.NewCapsule(spatial.NewPoseFromPoint(r3.Vector{X: -200}), 10, 100, "capsule")
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := []spatial.Geometry{sphere, capsule}
+
+	startGeoms, err := model.Geometries(frame.FloatsToInputs([]float64{0}))
+	test.That(t, err, test.ShouldBeNil)
+	constraint, err := NewCollisionConstraint(startGeoms.Geometries(), obstacles, nil, false, defaultCollisionBufferMM)
+	test.That(t, err, test.ShouldBeNil)
+	reporter, err := NewCollisionReporter(startGeoms.Geometries(), obstacles, nil, defaultCollisionBufferMM)
+	test.That(t, err, test.ShouldBeNil)
+
+	cases := []struct {
+		input     float64
+		collision string
+	}{
+		{0, ""},
+		// the link's face is 5mm from its center, so it just clears the sphere's surface at 190mm
+		{184, ""},
+		{190, "link3 collided with sphere"},
+		{-184, ""},
+		{-190, "link3 collided with capsule"},
+	}
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("position %.0f", c.input), func(t *testing.T) {
+			state := &ik.State{Configuration: frame.FloatsToInputs([]float64{c.input}), Frame: model}
+			test.That(t, constraint(state), test.ShouldEqual, c.collision == "")
+			collisions, err := reporter(state)
+			test.That(t, err, test.ShouldBeNil)
+			if c.collision == "" {
+				test.That(t, collisions, test.ShouldBeEmpty)
+			} else {
+				test.That(t, collisions, test.ShouldHaveLength, 1)
+				test.That(t, collisions[0].String(), test.ShouldEqual, c.collision)
+			}
+		})
+	}
+}
+
+func TestSelfCollisionConstraint(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	// adjacency is derived from the order of links in the kinematic chain
+	adjacent := []string{}
+	for _, collision := range adjacentLinkCollisions(m) {
+		adjacent = append(adjacent, collision.String())
+	}
+	test.That(t, adjacent, test.ShouldResemble, []string{
+		"xArm6:base_top collided with xArm6:upper_arm",
+		"xArm6:upper_arm collided with xArm6:upper_forearm",
+		"xArm6:upper_forearm collided with xArm6:lower_forearm",
+		"xArm6:lower_forearm collided with xArm6:wrist_link",
+	})
+	test.That(t, adjacentLinkCollisions(frame.NewZeroStaticFrame("static")), test.ShouldBeNil)
+
+	constraint, err := NewSelfCollisionConstraint(m, defaultCollisionBufferMM)
+	test.That(t, err, test.ShouldBeNil)
+
+	// adjacent links touch at home, which is allowed
+	test.That(t, constraint(&ik.State{Configuration: home6, Frame: m}), test.ShouldBeTrue)
+
+	// folding the wrist back brings the wrist link into the upper forearm
+	folded := frame.FloatsToInputs([]float64{0, 0, 0, 0, 3, 0})
+	test.That(t, constraint(&ik.State{Configuration: folded, Frame: m}), test.ShouldBeFalse)
+}
+
+func TestFixedOrientationConstraint(t *testing.T) {
+	level := &spatial.OrientationVectorDegrees{OZ: -1}
+	constraint, metric := NewFixedOrientationConstraint(level, utils.DegToRad(5))
+
+	tilted := func(degs float64) *ik.State {
+		tilt := &spatial.R4AA{Theta: utils.DegToRad(degs), RX: 1}
+		return &ik.State{Position: spatial.Compose(spatial.NewPoseFromOrientation(level), spatial.NewPoseFromOrientation(tilt))}
+	}
+
+	test.That(t, constraint(tilted(0)), test.ShouldBeTrue)
+	test.That(t, metric(tilted(0)), test.ShouldAlmostEqual, 0)
+	test.That(t, constraint(tilted(4)), test.ShouldBeTrue)
+	test.That(t, metric(tilted(4)), test.ShouldAlmostEqual, 4)
+	test.That(t, constraint(tilted(6)), test.ShouldBeFalse)
+	test.That(t, metric(tilted(6)), test.ShouldAlmostEqual, 6)
+
+	// States which cannot be resolved to a position are not valid
+	test.That(t, constraint(&ik.State{}), test.ShouldBeFalse)
+
+	// The helper on the planner options applies the constraint to fallbacks as well
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	opt := newBasicPlannerOptions(m)
+	opt.Fallback = newBasicPlannerOptions(m)
+	opt.AddOrientationConstraint(level, utils.DegToRad(5))
+	test.That(t, opt.StateConstraints(), test.ShouldContain, defaultFixedOrientationConstraintDesc)
+	test.That(t, opt.Fallback.StateConstraints(), test.ShouldContain, defaultFixedOrientationConstraintDesc)
+}
+
+func TestKeepInConstraint(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	state := &ik.State{Configuration: home6, Frame: m}
+
+	room, err := spatial.NewBox(spatial.NewZeroPose(), r3.Vector{4000, 4000, 4000}, "room")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, NewKeepInConstraint(room)(state), test.ShouldBeTrue)
+
+	// a region which the arm intersects is not enough, the arm must be entirely inside it
+	pose, err := m.Transform(home6)
+	test.That(t, err, test.ShouldBeNil)
+	aroundEnd, err := spatial.NewBox(pose, r3.Vector{100, 100, 100}, "aroundEnd")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, NewBoundingRegionConstraint(nil, []spatial.Geometry{aroundEnd}, defaultCollisionBufferMM)(state), test.ShouldBeTrue)
+	test.That(t, NewKeepInConstraint(aroundEnd)(state), test.ShouldBeFalse)
+
+	// plans may not leave the workspace, even if the goal is inside it
+	logger := logging.NewTestLogger(t)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal := spatial.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatial.OrientationVectorDegrees{OZ: -1})
+	aroundGoal, err := spatial.NewBox(goal, r3.Vector{100, 100, 100}, "aroundGoal")
+	test.That(t, err, test.ShouldBeNil)
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		Workspace:          aroundGoal,
+	}
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, defaultKeepInConstraintDesc)
+
+	request.Workspace = spatial.NewPoint(r3.Vector{X: -300}, "elsewhere")
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeError, errors.New("destination was not within the workspace"))
+
+	request.Workspace = room
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+}
+
+func TestHalfSpaceConstraint(t *testing.T) {
+	floor, err := spatial.NewHalfSpace(r3.Vector{}, r3.Vector{Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	constraint := NewHalfSpaceConstraint(floor)
+
+	// a frame with no geometry is checked by its end position alone
+	point, err := frame.NewStaticFrame("point", spatial.NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, constraint(&ik.State{Position: spatial.NewPoseFromPoint(r3.Vector{Z: 10}), Frame: point}), test.ShouldBeTrue)
+	test.That(t, constraint(&ik.State{Position: spatial.NewPoseFromPoint(r3.Vector{Z: -10}), Frame: point}), test.ShouldBeFalse)
+
+	// a geometry which crosses the plane is outside the half space, even though its center is above it
+	for _, tc := range []struct {
+		height   float64
+		expected bool
+	}{{10, false}, {30, true}} {
+		sphere, err := spatial.NewSphere(spatial.NewPoseFromPoint(r3.Vector{Z: tc.height}), 20, "ball")
+		test.That(t, err, test.ShouldBeNil)
+		ball, err := frame.NewStaticFrameWithGeometry("ball", spatial.NewZeroPose(), sphere)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, constraint(&ik.State{Configuration: []frame.Input{}, Frame: ball}), test.ShouldEqual, tc.expected)
+	}
+
+	// plans may not reach below the floor
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal := spatial.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatial.OrientationVectorDegrees{OZ: -1})
+	request := &PlanRequest{
+		Logger:             logging.NewTestLogger(t),
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		HalfSpaces:         []*spatial.HalfSpace{floor},
+	}
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+	for _, step := range plan.Trajectory() {
+		state := &ik.State{Configuration: step[m.Name()], Frame: m}
+		test.That(t, constraint(state), test.ShouldBeTrue)
+	}
+
+	aboveGoal, err := spatial.NewHalfSpace(r3.Vector{Z: 250}, r3.Vector{Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	request.HalfSpaces = []*spatial.HalfSpace{floor, aboveGoal}
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeError, errors.New("destination was not within every half space"))
+}
+
+func TestMinHeightConstraint(t *testing.T) {
+	// a pendulum whose tip hangs 100mm below its pivot, so that swinging it dips the tip
+	pendulum := frame.NewSimpleModel("pendulum")
+	pivot, err := frame.NewRotationalFrame("pivot", spatial.R4AA{RY: 1}, frame.Limit{Min: -math.Pi, Max: math.Pi})
+	test.That(t, err, test.ShouldBeNil)
+	tip, err := frame.NewStaticFrame("tip", spatial.NewPoseFromPoint(r3.Vector{Z: -100}))
+	test.That(t, err, test.ShouldBeNil)
+	pendulum.OrdTransforms = []frame.Frame{pivot, tip}
+
+	constraint := NewMinHeightConstraint(-80)
+	left := []frame.Input{{-math.Pi / 4}}
+	right := []frame.Input{{math.Pi / 4}}
+	test.That(t, constraint(&ik.State{Configuration: left, Frame: pendulum}), test.ShouldBeTrue)
+	test.That(t, constraint(&ik.State{Configuration: right, Frame: pendulum}), test.ShouldBeTrue)
+	test.That(t, constraint(&ik.State{Configuration: []frame.Input{{0}}, Frame: pendulum}), test.ShouldBeFalse)
+
+	// a straight line between two valid configurations is rejected when the tip dips below the height on the way
+	handler := &ConstraintHandler{}
+	handler.AddStateConstraint(defaultMinHeightConstraintDesc, constraint)
+	ok, _ := handler.CheckStateConstraintsAcrossSegment(
+		&ik.Segment{StartConfiguration: left, EndConfiguration: right, Frame: pendulum}, defaultResolution)
+	test.That(t, ok, test.ShouldBeFalse)
+	ok, _ = handler.CheckStateConstraintsAcrossSegment(
+		&ik.Segment{StartConfiguration: left, EndConfiguration: []frame.Input{{-math.Pi / 3}}, Frame: pendulum}, defaultResolution)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// plans keep the end of the arm above the height, which the goal must be above
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal := spatial.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatial.OrientationVectorDegrees{OZ: -1})
+	request := &PlanRequest{
+		Logger:             logging.NewTestLogger(t),
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		Options:            map[string]interface{}{"min_height_mm": 100.},
+	}
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+	armConstraint := NewMinHeightConstraint(100)
+	for _, step := range plan.Trajectory() {
+		test.That(t, armConstraint(&ik.State{Configuration: step[m.Name()], Frame: m}), test.ShouldBeTrue)
+	}
+
+	request.Options = map[string]interface{}{"min_height_mm": 250.}
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeError, errors.New("goal height 200 is below min_height_mm 250"))
+}
+
+func TestManipulabilityConstraint(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("referenceframe/testjson/ur5eDH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	// fully stretched out, the arm is at a singularity
+	singular := frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0})
+	bent := frame.FloatsToInputs([]float64{0.3, -1.2, 1.1, -0.4, 0.9, 2.1})
+
+	manipulability, err := Manipulability(m, singular)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, manipulability, test.ShouldAlmostEqual, 0, 1e-6)
+	manipulability, err = Manipulability(m, bent)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, manipulability, test.ShouldBeGreaterThan, 1e6)
+
+	constraint := NewManipulabilityConstraint(1e5)
+	test.That(t, constraint(&ik.State{Configuration: singular, Frame: m}), test.ShouldBeFalse)
+	test.That(t, constraint(&ik.State{Configuration: bent, Frame: m}), test.ShouldBeTrue)
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal, err := m.Transform(frame.FloatsToInputs([]float64{-0.2, -1.0, 1.4, -0.6, 1.2, 1.5}))
+	test.That(t, err, test.ShouldBeNil)
+	request := &PlanRequest{
+		Logger:             logging.NewTestLogger(t),
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): bent},
+		Options:            map[string]interface{}{"min_manipulability": -1},
+	}
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeError, errors.New("min_manipulability can't be negative, got -1"))
+
+	// plans keep clear of singularities along the way
+	request.Options["min_manipulability"] = 1e5
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+	for _, step := range plan.Trajectory() {
+		test.That(t, constraint(&ik.State{Configuration: step[m.Name()], Frame: m}), test.ShouldBeTrue)
+	}
+}
+
 func TestConstraintConstructors(t *testing.T) {
 	c := NewEmptyConstraints()
 
@@ -377,3 +701,51 @@ func TestConstraintConstructors(t *testing.T) {
 	pbToRDKConstraint := ConstraintsFromProtobuf(pbConstraint)
 	test.That(t, c, test.ShouldResemble, pbToRDKConstraint)
 }
+
+func TestPaddedCollisionConstraint(t *testing.T) {
+	sphere, err := spatial.NewSphere(spatial.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame("test", []frame.Limit{{-100, 100}, {-100, 100}}, sphere)
+	test.That(t, err, test.ShouldBeNil)
+	// collisions at the starting configuration are allowed, so start well away from the obstacles
+	moving, err := model.Geometries(frame.FloatsToInputs([]float64{-80, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	// at the origin, the near box is 20mm from the sphere, and the far box is 80mm from it
+	near, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: 35}), r3.Vector{10, 10, 10}, "near")
+	test.That(t, err, test.ShouldBeNil)
+	far, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{Y: 95}), r3.Vector{10, 10, 10}, "far")
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := []spatial.Geometry{near, far}
+	state := &ik.State{Configuration: make([]frame.Input, 2), Frame: model}
+
+	for _, tc := range []struct {
+		name      string
+		bufferMM  float64
+		overrides map[string]float64
+		expected  bool
+	}{
+		{"default buffer", defaultCollisionBufferMM, nil, true},
+		{"uniform margin", 25, nil, false},
+		{"override below clearance", defaultCollisionBufferMM, map[string]float64{"near": 15}, true},
+		{"override above clearance", defaultCollisionBufferMM, map[string]float64{"near": 25}, false},
+		{"override relaxes margin", 25, map[string]float64{"near": 15}, true},
+		{"override on far obstacle", defaultCollisionBufferMM, map[string]float64{"far": 90}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			constraint, err := NewPaddedCollisionConstraint(moving.Geometries(), obstacles, nil, false, tc.bufferMM, tc.overrides)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, constraint(state), test.ShouldEqual, tc.expected)
+		})
+	}
+
+	_, err = NewPaddedCollisionConstraint(moving.Geometries(), obstacles, nil, false, 0, map[string]float64{"missing": 5})
+	test.That(t, err, test.ShouldBeError, errors.New(`cannot set a collision buffer for "missing", no obstacle has that label`))
+	_, err = NewPaddedCollisionConstraint(moving.Geometries(), obstacles, nil, false, 0, map[string]float64{"near": -5})
+	test.That(t, err, test.ShouldBeError, errors.New(`collision buffer for "near" can't be negative, got -5`))
+
+	buffers, err := obstacleBuffersFromOptions(map[string]interface{}{"obstacle_buffers_mm": map[string]interface{}{"near": 20.}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, buffers, test.ShouldResemble, map[string]float64{"near": 20})
+	_, err = obstacleBuffersFromOptions(map[string]interface{}{"obstacle_buffers_mm": map[string]interface{}{"near": "wide"}})
+	test.That(t, err, test.ShouldBeError, errors.New(`could not interpret obstacle_buffers_mm value for "near" as float64`))
+}
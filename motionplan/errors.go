@@ -6,6 +6,13 @@ import (
 )
 
 var (
+	// ErrPlanSuboptimal is returned alongside the best path found so far when a planner's deadline passes, or max_nodes is
+	// reached, before it finished optimizing the path.
+	ErrPlanSuboptimal = errors.New("planning was cut short before the path was optimized, returning best path found so far")
+
+	// ErrNodeLimitReached is returned when the planners' search trees reach max_nodes before a path is found.
+	ErrNodeLimitReached = errors.New("node limit reached before a path was found, raise max_nodes to let the planner search further")
+
 	errIKSolve = errors.New("zero IK solutions produced, goal positions appears to be physically unreachable")
 
 	errPlannerFailed = errors.New("motion planner failed to find path")
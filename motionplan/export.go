@@ -0,0 +1,93 @@
+package motionplan
+
+import (
+	"encoding/json"
+	"os"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// TrajectoryExportVersion is the version of the format written by ExportTrajectoryJSON. It only changes if the format changes in a
+// way that readers of earlier versions cannot handle; fields may be added without changing it.
+const TrajectoryExportVersion = 1
+
+// ExportedTrajectory is the document written by ExportTrajectoryJSON, for example:
+//
+//	{
+//	  "version": 1,
+//	  "frame": "xArm6",
+//	  "waypoints": [
+//	    {
+//	      "inputs": [0, 0.5, 0, 0, 0, 0],
+//	      "pose": {"x": 300, "y": 0, "z": 200, "o_x": 0, "o_y": 0, "o_z": -1, "theta": 0}
+//	    }
+//	  ]
+//	}
+type ExportedTrajectory struct {
+	Version   int                `json:"version"`
+	Frame     string             `json:"frame"`
+	Waypoints []ExportedWaypoint `json:"waypoints"`
+}
+
+// ExportedWaypoint is one step of an ExportedTrajectory. Inputs are the frame's inputs at the step, in radians for revolute
+// joints and mm for prismatic ones. Pose is where those inputs place the frame relative to its parent.
+type ExportedWaypoint struct {
+	Inputs []float64    `json:"inputs"`
+	Pose   ExportedPose `json:"pose"`
+}
+
+// ExportedPose is a pose given as a point in mm and an orientation vector in degrees, named as in a protobuf Pose.
+type ExportedPose struct {
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Z     float64 `json:"z"`
+	OX    float64 `json:"o_x"`
+	OY    float64 `json:"o_y"`
+	OZ    float64 `json:"o_z"`
+	Theta float64 `json:"theta"`
+}
+
+// NewExportedTrajectory returns the inputs of the given frame at each step of the plan, along with the pose they place the frame at.
+func NewExportedTrajectory(plan Plan, f referenceframe.Frame) (*ExportedTrajectory, error) {
+	steps, err := plan.Trajectory().GetFrameInputs(f.Name())
+	if err != nil {
+		return nil, err
+	}
+	exported := &ExportedTrajectory{
+		Version:   TrajectoryExportVersion,
+		Frame:     f.Name(),
+		Waypoints: make([]ExportedWaypoint, 0, len(steps)),
+	}
+	for _, inputs := range steps {
+		pose, err := f.Transform(inputs)
+		if err != nil {
+			return nil, err
+		}
+		exported.Waypoints = append(exported.Waypoints, ExportedWaypoint{
+			Inputs: referenceframe.InputsToFloats(inputs),
+			Pose:   newExportedPose(pose),
+		})
+	}
+	return exported, nil
+}
+
+func newExportedPose(pose spatialmath.Pose) ExportedPose {
+	pt := pose.Point()
+	ov := pose.Orientation().OrientationVectorDegrees()
+	return ExportedPose{X: pt.X, Y: pt.Y, Z: pt.Z, OX: ov.OX, OY: ov.OY, OZ: ov.OZ, Theta: ov.Theta}
+}
+
+// ExportTrajectoryJSON writes the trajectory of the given frame through the plan to a JSON file, in the format of
+// ExportedTrajectory, so that it can be visualized or inspected by tools outside of this package.
+func ExportTrajectoryJSON(filename string, plan Plan, f referenceframe.Frame) error {
+	exported, err := NewExportedTrajectory(plan, f)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
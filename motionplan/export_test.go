@@ -0,0 +1,54 @@
+package motionplan
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	frame "go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func TestExportTrajectoryJSON(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	end := frame.FloatsToInputs([]float64{0.5, 0, 0, 0, 0, 0})
+	plan := NewSimplePlan(nil, Trajectory{{m.Name(): home6}, {m.Name(): end}})
+
+	filename := filepath.Join(t.TempDir(), "trajectory.json")
+	test.That(t, ExportTrajectoryJSON(filename, plan, m), test.ShouldBeNil)
+	data, err := os.ReadFile(filename)
+	test.That(t, err, test.ShouldBeNil)
+	var exported ExportedTrajectory
+	test.That(t, json.Unmarshal(data, &exported), test.ShouldBeNil)
+
+	test.That(t, exported.Version, test.ShouldEqual, TrajectoryExportVersion)
+	test.That(t, exported.Frame, test.ShouldEqual, m.Name())
+	test.That(t, exported.Waypoints, test.ShouldHaveLength, 2)
+	test.That(t, exported.Waypoints[1].Inputs, test.ShouldResemble, []float64{0.5, 0, 0, 0, 0, 0})
+	for i, inputs := range [][]frame.Input{home6, end} {
+		expected, err := m.Transform(inputs)
+		test.That(t, err, test.ShouldBeNil)
+		pose := exported.Waypoints[i].Pose
+		actual := spatialmath.NewPose(
+			r3.Vector{X: pose.X, Y: pose.Y, Z: pose.Z},
+			&spatialmath.OrientationVectorDegrees{OX: pose.OX, OY: pose.OY, OZ: pose.OZ, Theta: pose.Theta},
+		)
+		test.That(t, spatialmath.PoseAlmostEqual(actual, expected), test.ShouldBeTrue)
+	}
+
+	// the field names are part of the format
+	var raw map[string]interface{}
+	test.That(t, json.Unmarshal(data, &raw), test.ShouldBeNil)
+	waypoint := raw["waypoints"].([]interface{})[0].(map[string]interface{})
+	test.That(t, waypoint, test.ShouldContainKey, "inputs")
+	test.That(t, waypoint["pose"], test.ShouldContainKey, "o_z")
+
+	_, err = NewExportedTrajectory(plan, frame.NewZeroStaticFrame("missing"))
+	test.That(t, err, test.ShouldNotBeNil)
+}
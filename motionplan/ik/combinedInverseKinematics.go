@@ -41,6 +41,25 @@ func CreateCombinedIKSolver(model referenceframe.Frame, logger logging.Logger, n
 	return ik, nil
 }
 
+// SetMaxIterations sets the number of iterations each child solver will run before giving up. Values less than 1 are ignored.
+func (ik *CombinedIK) SetMaxIterations(iter int) {
+	for _, solver := range ik.solvers {
+		if nlopt, ok := solver.(*NloptIK); ok {
+			nlopt.SetMaxIterations(iter)
+		}
+	}
+}
+
+// SetTolerance sets the score below which each child solver considers a solution to have reached the goal. Values less than or
+// equal to zero are ignored.
+func (ik *CombinedIK) SetTolerance(tolerance float64) {
+	for _, solver := range ik.solvers {
+		if nlopt, ok := solver.(*NloptIK); ok {
+			nlopt.SetTolerance(tolerance)
+		}
+	}
+}
+
 // Solve will initiate solving for the given position in all child solvers, seeding with the specified initial joint
 // positions. If unable to solve, the returned error will be non-nil.
 func (ik *CombinedIK) Solve(ctx context.Context,
@@ -109,6 +109,18 @@ func NewSquaredNormMetric(goal spatial.Pose) StateMetric {
 	return weightedSqNormDist
 }
 
+// NewGoalToleranceMetric returns a metric like NewSquaredNormMetric, except that it is zero for any state within linTol mm and
+// angTol radians of the goal, so that solving stops as soon as a state within tolerance of the goal is found. Only the distance
+// beyond each tolerance is counted.
+func NewGoalToleranceMetric(goal spatial.Pose, linTol, angTol float64) StateMetric {
+	return func(query *State) float64 {
+		delta := spatial.PoseDelta(goal, query.Position)
+		linExcess := math.Max(0, delta.Point().Norm()-linTol)
+		angExcess := math.Max(0, spatial.QuatToR3AA(delta.Orientation().Quaternion()).Norm()-angTol) * orientationDistanceScaling
+		return linExcess*linExcess + angExcess*angExcess
+	}
+}
+
 // NewScaledSquaredNormMetric is a distance function between two poses. It allows the user to scale the contribution of orientation.
 func NewScaledSquaredNormMetric(goal spatial.Pose, orientationDistanceScale float64) StateMetric {
 	weightedSqNormDist := func(query *State) float64 {
@@ -173,6 +185,31 @@ func L2InputMetric(segment *Segment) float64 {
 	return referenceframe.InputsL2Distance(segment.StartConfiguration, segment.EndConfiguration)
 }
 
+// NewWeightedL2InputMetric returns a metric like L2InputMetric, but which scales the squared difference in each input by the weight
+// at the same index before summing them, so that motions of heavily weighted inputs are measured as longer.
+func NewWeightedL2InputMetric(weights []float64) SegmentMetric {
+	return func(segment *Segment) float64 {
+		sum := 0.
+		for i, start := range segment.StartConfiguration {
+			diff := start.Value - segment.EndConfiguration[i].Value
+			sum += weights[i] * diff * diff
+		}
+		return math.Sqrt(sum)
+	}
+}
+
+// NewTimeInputMetric returns a metric which measures how long a segment takes when every input moves at the velocity at the same
+// index, in units per second, and all inputs start and stop together. This is the time taken by the slowest input.
+func NewTimeInputMetric(maxVelocities []float64) SegmentMetric {
+	return func(segment *Segment) float64 {
+		duration := 0.
+		for i, start := range segment.StartConfiguration {
+			duration = math.Max(duration, math.Abs(start.Value-segment.EndConfiguration[i].Value)/maxVelocities[i])
+		}
+		return duration
+	}
+}
+
 // NewSquaredNormSegmentMetric returns a metric which will return the cartesian distance between the two positions.
 // It allows the caller to choose the scaling level of orientation.
 func NewSquaredNormSegmentMetric(orientationScaleFactor float64) SegmentMetric {
@@ -7,6 +7,7 @@ import (
 	"github.com/golang/geo/r3"
 	"go.viam.com/test"
 
+	"go.viam.com/rdk/referenceframe"
 	spatial "go.viam.com/rdk/spatialmath"
 )
 
@@ -34,6 +35,42 @@ func TestBasicMetric(t *testing.T) {
 	test.That(t, d2, test.ShouldAlmostEqual, 100)
 }
 
+func TestWeightedL2InputMetric(t *testing.T) {
+	segment := &Segment{
+		StartConfiguration: referenceframe.FloatsToInputs([]float64{0, 0, 0}),
+		EndConfiguration:   referenceframe.FloatsToInputs([]float64{1, 2, 2}),
+	}
+	test.That(t, NewWeightedL2InputMetric([]float64{1, 1, 1})(segment), test.ShouldAlmostEqual, L2InputMetric(segment))
+	test.That(t, NewWeightedL2InputMetric([]float64{4, 1, 0})(segment), test.ShouldAlmostEqual, math.Sqrt(8))
+}
+
+func TestTimeInputMetric(t *testing.T) {
+	segment := &Segment{
+		StartConfiguration: referenceframe.FloatsToInputs([]float64{0, 0, 0}),
+		EndConfiguration:   referenceframe.FloatsToInputs([]float64{1, -2, 2}),
+	}
+	// the second input is the slowest to reach its end
+	test.That(t, NewTimeInputMetric([]float64{1, 1, 4})(segment), test.ShouldAlmostEqual, 2)
+	test.That(t, NewTimeInputMetric([]float64{0.5, 4, 4})(segment), test.ShouldAlmostEqual, 2)
+	test.That(t, NewTimeInputMetric([]float64{1, 1, 1})(&Segment{
+		StartConfiguration: segment.StartConfiguration,
+		EndConfiguration:   segment.StartConfiguration,
+	}), test.ShouldEqual, 0)
+}
+
+func TestGoalToleranceMetric(t *testing.T) {
+	goal := spatial.NewZeroPose()
+	metric := NewGoalToleranceMetric(goal, 5, 0.1)
+	test.That(t, metric(&State{Position: spatial.NewPoseFromPoint(r3.Vector{0, 3, 4})}), test.ShouldAlmostEqual, 0)
+	test.That(t, metric(&State{Position: spatial.NewPoseFromPoint(r3.Vector{0, 6, 8})}), test.ShouldAlmostEqual, 25)
+	test.That(t, metric(&State{Position: spatial.NewPoseFromOrientation(&spatial.R4AA{Theta: 0.05, RZ: 1})}), test.ShouldAlmostEqual, 0)
+	test.That(t,
+		metric(&State{Position: spatial.NewPoseFromOrientation(&spatial.R4AA{Theta: 0.3, RZ: 1})}),
+		test.ShouldAlmostEqual,
+		math.Pow(0.2*orientationDistanceScaling, 2),
+	)
+}
+
 var (
 	ov     = &spatial.OrientationVector{math.Pi / 2, 0, 0, -1}
 	p1b    = &State{Position: spatial.NewPose(r3.Vector{1, 2, 3}, ov)}
@@ -80,6 +80,21 @@ func CreateNloptIKSolver(mdl referenceframe.Frame, logger logging.Logger, iter i
 	return ik, nil
 }
 
+// SetMaxIterations sets the number of iterations the solver will run before giving up. Values less than 1 are ignored.
+func (ik *NloptIK) SetMaxIterations(iter int) {
+	if iter > 0 {
+		ik.maxIterations = iter
+	}
+}
+
+// SetTolerance sets the score, as measured by the solve metric, below which a solution is considered to have reached the goal.
+// Values less than or equal to zero are ignored.
+func (ik *NloptIK) SetTolerance(tolerance float64) {
+	if tolerance > 0 {
+		ik.epsilon = tolerance
+	}
+}
+
 // Solve runs the actual solver and sends any solutions found to the given channel.
 func (ik *NloptIK) Solve(ctx context.Context,
 	solutionChan chan<- *Solution,
@@ -241,15 +256,15 @@ func (ik *NloptIK) Solve(ctx context.Context,
 		}
 
 		if result < ik.epsilon || (solutionRaw != nil && !ik.exact) {
+			// Give up on sending if cancelled, as nothing may be left to receive the solution.
 			select {
 			case <-ctx.Done():
 				return err
-			default:
-			}
-			solutionChan <- &Solution{
+			case solutionChan <- &Solution{
 				Configuration: referenceframe.FloatsToInputs(solutionRaw),
 				Score:         result,
 				Exact:         result < ik.epsilon,
+			}:
 			}
 			solutionsFound++
 		}
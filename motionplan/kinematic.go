@@ -7,6 +7,7 @@ import (
 	"github.com/pkg/errors"
 	pb "go.viam.com/api/component/arm/v1"
 	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/mat"
 	"gonum.org/v1/gonum/num/quat"
 
 	"go.viam.com/rdk/referenceframe"
@@ -109,3 +110,23 @@ func L2Distance(q1, q2 []float64) float64 {
 	// 2 is the L value returning a standard L2 Normalization
 	return floats.Norm(q1, 2)
 }
+
+// Manipulability returns the Yoshikawa manipulability of the model at the given inputs, which is the product of the singular values
+// of its Jacobian. It falls to zero as the model approaches a kinematic singularity, where it loses the ability to move its end in
+// some direction and small end motions call for very large joint motions. Its scale depends on the model, as the Jacobian relates
+// inputs to mm of linear motion and radians of angular motion.
+func Manipulability(model referenceframe.Model, inputs []referenceframe.Input) (float64, error) {
+	jac, err := model.Jacobian(inputs)
+	if err != nil {
+		return 0, err
+	}
+	var svd mat.SVD
+	if !svd.Factorize(jac, mat.SVDNone) {
+		return 0, errors.New("could not factorize jacobian")
+	}
+	manipulability := 1.
+	for _, value := range svd.Values(nil) {
+		manipulability *= value
+	}
+	return manipulability, nil
+}
@@ -6,9 +6,11 @@ package motionplan
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -34,14 +36,55 @@ type motionPlanner interface {
 	getSolutions(context.Context, []frame.Input) ([]node, error)
 	opt() *plannerOptions
 	sample(node, int) (node, error)
+	stats() PlanStats
+	reserveNode() bool
 }
 
 type plannerConstructor func(frame.Frame, *rand.Rand, logging.Logger, *plannerOptions) (motionPlanner, error)
 
+// Names of the planning algorithms which may be selected with the "planning_alg" option.
+const (
+	// CBiRRTPlanningAlg is a constrained bidirectional RRT, the default for frames which are not TP-space frames.
+	CBiRRTPlanningAlg = "cbirrt"
+	// RRTStarPlanningAlg is an RRT*-Connect planner, which cannot follow a motion profile.
+	RRTStarPlanningAlg = "rrtstar"
+)
+
+// plannerConstructors are the planners which may be selected by name.
+var plannerConstructors = map[string]plannerConstructor{
+	CBiRRTPlanningAlg:  newCBiRRTMotionPlanner,
+	RRTStarPlanningAlg: newRRTStarConnectMotionPlanner,
+}
+
+// PlanningAlgorithms returns the sorted names of the planning algorithms which may be given as the "planning_alg" option.
+func PlanningAlgorithms() []string {
+	names := make([]string, 0, len(plannerConstructors))
+	for name := range plannerConstructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// plannerConstructorFromName returns the constructor of the planning algorithm with the given name.
+func plannerConstructorFromName(name string) (plannerConstructor, error) {
+	constructor, ok := plannerConstructors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown planning_alg %q, must be one of %v", name, PlanningAlgorithms())
+	}
+	return constructor, nil
+}
+
 // PlanRequest is a struct to store all the data necessary to make a call to PlanMotion.
 type PlanRequest struct {
-	Logger             logging.Logger
-	Goal               *frame.PoseInFrame
+	Logger logging.Logger
+	Goal   *frame.PoseInFrame
+	// GoalConfiguration, if set, is a goal in joint space: the plan ends with Frame at exactly these inputs, which are used as they are
+	// rather than solved for with IK. It replaces Goal, which must then be nil. Any other frames moved by the plan end as they started.
+	GoalConfiguration []frame.Input
+	// AlternateGoals are further poses, any of which is as acceptable an end to the plan as Goal. If set, the plan ends at whichever
+	// of the goals is cheapest to reach. They must share Goal's parent frame.
+	AlternateGoals     []*frame.PoseInFrame
 	Frame              frame.Frame
 	FrameSystem        frame.FrameSystem
 	StartPose          spatialmath.Pose
@@ -50,6 +93,21 @@ type PlanRequest struct {
 	BoundingRegions    []spatialmath.Geometry
 	Constraints        *Constraints
 	Options            map[string]interface{}
+	// Workspace, if set, is a keep-in region: the moving geometries must remain entirely inside it for the whole plan. This differs
+	// from BoundingRegions, which are satisfied by merely intersecting the robot.
+	Workspace spatialmath.Geometry
+	// HalfSpaces are keep-in regions bounded by a plane, such as the space above a floor: the moving geometries must remain entirely
+	// inside each of them for the whole plan.
+	HalfSpaces []*spatialmath.HalfSpace
+	// Progress, if set, is called periodically while planning with the iterations run and the best path found so far. It is called
+	// from a separate goroutine, never more often than once per ProgressInterval, and never after planning has returned.
+	Progress func(PlanProgress)
+	// ProgressInterval is how often Progress is called. If zero, a default of 500ms is used.
+	ProgressInterval time.Duration
+	// PriorPlan, if set, is a plan found earlier between a similar start and goal, such as the opposite leg of a motion repeated back
+	// and forth. Its waypoints seed the search, so that planning can converge faster, but each of them is checked against the
+	// constraints and obstacles of this request first. It is not supported when planning for PTGs.
+	PriorPlan Plan
 }
 
 // validatePlanRequest ensures PlanRequests are not malformed.
@@ -70,14 +128,32 @@ func (req *PlanRequest) validatePlanRequest() error {
 		return frame.NewFrameMissingError(req.Frame.Name())
 	}
 
-	if req.Goal == nil {
+	if req.GoalConfiguration != nil {
+		if req.Goal != nil {
+			return errors.New("PlanRequest cannot have both a goal and a goal configuration")
+		}
+		if len(req.AlternateGoals) > 0 {
+			return errors.New("PlanRequest cannot have alternate goals with a goal configuration")
+		}
+		if len(req.GoalConfiguration) != len(req.Frame.DoF()) {
+			return frame.NewIncorrectInputLengthError(len(req.GoalConfiguration), len(req.Frame.DoF()))
+		}
+	} else if req.Goal == nil {
 		return errors.New("PlanRequest cannot have nil goal")
 	}
 
-	goalParentFrame := req.Goal.Parent()
+	goalParentFrame := req.goalParent()
 	if req.FrameSystem.Frame(goalParentFrame) == nil {
 		return frame.NewParentFrameMissingError(req.Goal.Name(), goalParentFrame)
 	}
+	for i, goal := range req.AlternateGoals {
+		if goal == nil {
+			return fmt.Errorf("PlanRequest cannot have nil alternate goal at index %d", i)
+		}
+		if goal.Parent() != goalParentFrame {
+			return fmt.Errorf("alternate goal at index %d has parent frame %q, must match goal parent frame %q", i, goal.Parent(), goalParentFrame)
+		}
+	}
 
 	if len(req.BoundingRegions) > 0 {
 		buffer, ok := req.Options["collision_buffer_mm"].(float64)
@@ -98,11 +174,36 @@ func (req *PlanRequest) validatePlanRequest() error {
 			return fmt.Errorf("frame named %s is not within the provided bounding regions", req.Frame.Name())
 		}
 
-		// check that the destination is within or in collision with the bounding regions
-		destinationAsGeom := []spatialmath.Geometry{spatialmath.NewPoint(req.Goal.Pose().Point(), "")}
-		destinationBoundingRegionCheck := NewBoundingRegionConstraint(destinationAsGeom, req.BoundingRegions, buffer)
-		if !destinationBoundingRegionCheck(&ik.State{}) {
-			return errors.New("destination was not within the provided bounding regions")
+		// check that the destination is within or in collision with the bounding regions. A goal configuration is instead checked
+		// along with the rest of the constraints when planning.
+		if req.Goal != nil {
+			destinationAsGeom := []spatialmath.Geometry{spatialmath.NewPoint(req.Goal.Pose().Point(), "")}
+			destinationBoundingRegionCheck := NewBoundingRegionConstraint(destinationAsGeom, req.BoundingRegions, buffer)
+			if !destinationBoundingRegionCheck(&ik.State{}) {
+				return errors.New("destination was not within the provided bounding regions")
+			}
+		}
+	}
+
+	if req.ProgressInterval < 0 {
+		return fmt.Errorf("progress interval must not be negative, got %v", req.ProgressInterval)
+	}
+
+	if req.Workspace != nil && req.Goal != nil {
+		inside, err := spatialmath.NewPoint(req.Goal.Pose().Point(), "").EncompassedBy(req.Workspace)
+		if err != nil {
+			return err
+		}
+		if !inside {
+			return errors.New("destination was not within the workspace")
+		}
+	}
+	for _, halfSpace := range req.HalfSpaces {
+		if halfSpace == nil {
+			return errors.New("PlanRequest cannot have a nil half space")
+		}
+		if req.Goal != nil && halfSpace.SignedDistance(req.Goal.Pose().Point()) < 0 {
+			return errors.New("destination was not within every half space")
 		}
 	}
 
@@ -122,14 +223,42 @@ func (req *PlanRequest) validatePlanRequest() error {
 	return nil
 }
 
-// PlanMotion plans a motion from a provided plan request.
+// goalParent returns the frame the goal of the request is expressed in. Goal configurations are taken to be in the world frame.
+func (req *PlanRequest) goalParent() string {
+	if req.Goal == nil {
+		return frame.World
+	}
+	return req.Goal.Parent()
+}
+
+// PlanMotion plans a motion from a provided plan request. If planning is cut short before the path was optimized, the best path
+// found is returned along with ErrPlanSuboptimal.
 func PlanMotion(ctx context.Context, request *PlanRequest) (Plan, error) {
 	// Calls Replan but without a seed plan
 	return Replan(ctx, request, nil, 0)
 }
 
+// randomSeedFromOptions returns the random seed to plan with, taken from the "rseed" option. The seed may be given as an int, or as a
+// whole float64 as happens when options are decoded from JSON or protobuf.
+func randomSeedFromOptions(options map[string]interface{}) (int, error) {
+	switch seed := options["rseed"].(type) {
+	case nil:
+		return defaultRandomSeed, nil
+	case int:
+		return seed, nil
+	case float64:
+		if seed != math.Trunc(seed) {
+			return 0, fmt.Errorf("rseed must be a whole number, got %v", seed)
+		}
+		return int(seed), nil
+	default:
+		return 0, fmt.Errorf("rseed must be a whole number, got %T", seed)
+	}
+}
+
 // PlanFrameMotion plans a motion to destination for a given frame with no frame system. It will create a new FS just for the plan.
-// WorldState is not supported in the absence of a real frame system.
+// WorldState is not supported in the absence of a real frame system. A path that was not fully optimized is returned along with
+// ErrPlanSuboptimal.
 func PlanFrameMotion(ctx context.Context,
 	logger logging.Logger,
 	dst spatialmath.Pose,
@@ -152,43 +281,27 @@ func PlanFrameMotion(ctx context.Context,
 		Constraints:        constraints,
 		Options:            planningOpts,
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrPlanSuboptimal) {
 		return nil, err
 	}
-	return plan.Trajectory().GetFrameInputs(f.Name())
+	inputs, trajErr := plan.Trajectory().GetFrameInputs(f.Name())
+	if trajErr != nil {
+		return nil, trajErr
+	}
+	return inputs, err
 }
 
 // Replan plans a motion from a provided plan request, and then will return that plan only if its cost is better than the cost of the
-// passed-in plan multiplied by `replanCostFactor`.
+// passed-in plan multiplied by `replanCostFactor`. As with PlanMotion, a path that was not fully optimized is returned along with
+// ErrPlanSuboptimal.
 func Replan(ctx context.Context, request *PlanRequest, currentPlan Plan, replanCostFactor float64) (Plan, error) {
-	// make sure request is well formed and not missing vital information
-	if err := request.validatePlanRequest(); err != nil {
-		return nil, err
-	}
-
-	// Create a frame to solve for, and an IK solver with that frame.
-	sf, err := newSolverFrame(request.FrameSystem, request.Frame.Name(), request.Goal.Parent(), request.StartConfiguration)
-	if err != nil {
-		return nil, err
-	}
-	if len(sf.DoF()) == 0 {
-		return nil, errors.New("solver frame has no degrees of freedom, cannot perform inverse kinematics")
-	}
-
-	request.Logger.CDebugf(ctx, "constraint specs for this step: %v", request.Constraints)
-	request.Logger.CDebugf(ctx, "motion config for this step: %v", request.Options)
-
-	rseed := defaultRandomSeed
-	if seed, ok := request.Options["rseed"].(int); ok {
-		rseed = seed
-	}
-	sfPlanner, err := newPlanManager(sf, request.Logger, rseed)
+	sfPlanner, err := planManagerFromRequest(ctx, request)
 	if err != nil {
 		return nil, err
 	}
 
 	newPlan, err := sfPlanner.PlanSingleWaypoint(ctx, request, currentPlan)
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrPlanSuboptimal) {
 		return nil, err
 	}
 
@@ -205,7 +318,69 @@ func Replan(ctx context.Context, request *PlanRequest, currentPlan Plan, replanC
 		}
 	}
 
-	return newPlan, nil
+	return newPlan, err
+}
+
+// RepairPlan adapts a previously computed plan to a changed world, such as an obstacle which has moved, without planning the whole
+// motion again. The request must describe the motion previous was planned for, with its WorldState updated. If previous is still
+// valid it is returned as is. Otherwise the stretch of its trajectory which became invalid is replanned between the nearest valid
+// waypoints on either side and spliced in, and the repaired plan is returned once it has been checked end to end. If the plan cannot
+// be repaired, the motion is planned again from scratch.
+func RepairPlan(ctx context.Context, request *PlanRequest, previous Plan) (Plan, error) {
+	if previous == nil || len(previous.Trajectory()) < 2 {
+		return nil, errors.New("cannot repair a plan with fewer than two waypoints")
+	}
+	pm, err := planManagerFromRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	repaired, err := pm.repairPlan(ctx, request, previous)
+	if err == nil || errors.Is(err, ErrPlanSuboptimal) {
+		return repaired, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	request.Logger.CDebugf(ctx, "unable to repair plan, planning from scratch: %v", err)
+	return PlanMotion(ctx, request)
+}
+
+// IsReachable reports whether the goal of the request can be reached at all, by checking for an IK solution which satisfies the same
+// constraints, including collisions with the world state, as a full plan would. It is much quicker than planning, so it can be used to
+// reject unreachable goals up front. If no solution is found before the request's "timeout" option, or one second if that is unset,
+// the goal is reported as unreachable. A goal configuration needs no IK, so it is reachable if it satisfies the constraints.
+func IsReachable(ctx context.Context, request *PlanRequest) (bool, error) {
+	pm, err := planManagerFromRequest(ctx, request)
+	if err != nil {
+		return false, err
+	}
+	return pm.goalReachable(ctx, request)
+}
+
+// planManagerFromRequest validates the request and builds a plan manager for the frame it is solving for.
+func planManagerFromRequest(ctx context.Context, request *PlanRequest) (*planManager, error) {
+	// make sure request is well formed and not missing vital information
+	if err := request.validatePlanRequest(); err != nil {
+		return nil, err
+	}
+
+	// Create a frame to solve for, and an IK solver with that frame.
+	sf, err := newSolverFrame(request.FrameSystem, request.Frame.Name(), request.goalParent(), request.StartConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	if len(sf.DoF()) == 0 {
+		return nil, errors.New("solver frame has no degrees of freedom, cannot perform inverse kinematics")
+	}
+
+	request.Logger.CDebugf(ctx, "constraint specs for this step: %v", request.Constraints)
+	request.Logger.CDebugf(ctx, "motion config for this step: %v", request.Options)
+
+	rseed, err := randomSeedFromOptions(request.Options)
+	if err != nil {
+		return nil, err
+	}
+	return newPlanManager(sf, request.Logger, rseed)
 }
 
 type planner struct {
@@ -215,10 +390,15 @@ type planner struct {
 	randseed *rand.Rand
 	start    time.Time
 	planOpts *plannerOptions
+
+	// iterations and nodesExpanded count the work done by this planner, and are reported via PlanStats.
+	iterations    atomic.Int64
+	nodesExpanded atomic.Int64
 }
 
 func newPlanner(frame frame.Frame, seed *rand.Rand, logger logging.Logger, opt *plannerOptions) (*planner, error) {
-	solver, err := ik.CreateCombinedIKSolver(frame, logger, opt.NumThreads, opt.GoalThreshold)
+	opt.NumThreads = workerCount(opt.NumThreads)
+	solver, err := newIKSolver(frame, logger, opt)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +412,28 @@ func newPlanner(frame frame.Frame, seed *rand.Rand, logger logging.Logger, opt *
 	return mp, nil
 }
 
+// newIKSolver creates the IK solver selected by the planner options.
+func newIKSolver(frame frame.Frame, logger logging.Logger, opt *plannerOptions) (ik.InverseKinematics, error) {
+	if err := ValidateIKSolver(opt.IKSolver); err != nil {
+		return nil, err
+	}
+	if opt.IKSolver == NloptIKSolver {
+		solver, err := ik.CreateNloptIKSolver(frame, logger, opt.IKMaxIterations, true, true)
+		if err != nil {
+			return nil, err
+		}
+		solver.SetTolerance(opt.IKTolerance)
+		return solver, nil
+	}
+	solver, err := ik.CreateCombinedIKSolver(frame, logger, opt.NumThreads, opt.GoalThreshold)
+	if err != nil {
+		return nil, err
+	}
+	solver.SetMaxIterations(opt.IKMaxIterations)
+	solver.SetTolerance(opt.IKTolerance)
+	return solver, nil
+}
+
 func (mp *planner) checkInputs(inputs []frame.Input) bool {
 	ok, _ := mp.planOpts.CheckStateConstraints(&ik.State{
 		Configuration: inputs,
@@ -266,10 +468,49 @@ func (mp *planner) sample(rSeed node, sampleNum int) (node, error) {
 	return newConfigurationNode(q), nil
 }
 
+// goalBiasedSample returns the goal with the probability given by the GoalBias option, and a sample as from sample otherwise.
+func (mp *planner) goalBiasedSample(rSeed node, sampleNum int, goal node) (node, error) {
+	if mp.planOpts.GoalBias > 0 && mp.randseed.Float64() < mp.planOpts.GoalBias {
+		return newConfigurationNode(goal.Q()), nil
+	}
+	return mp.sample(rSeed, sampleNum)
+}
+
+// reserveNode counts a node about to be added to a search tree toward max_nodes. It returns false, counting nothing, if the
+// limit has been reached, in which case the node must not be added.
+func (mp *planner) reserveNode() bool {
+	if count := mp.planOpts.nodeCount.Add(1); mp.planOpts.MaxNodes > 0 && count > int64(mp.planOpts.MaxNodes) {
+		mp.planOpts.nodeCount.Add(-1)
+		return false
+	}
+	mp.nodesExpanded.Add(1)
+	return true
+}
+
+// nodeLimitReached returns whether the search trees hold as many nodes as max_nodes allows.
+func (mp *planner) nodeLimitReached() bool {
+	return mp.planOpts.MaxNodes > 0 && mp.planOpts.nodeCount.Load() >= int64(mp.planOpts.MaxNodes)
+}
+
 func (mp *planner) opt() *plannerOptions {
 	return mp.planOpts
 }
 
+// stats returns the number of iterations run and nodes expanded by the planner so far.
+func (mp *planner) stats() PlanStats {
+	return PlanStats{
+		Iterations:    int(mp.iterations.Load()),
+		NodesExpanded: int(mp.nodesExpanded.Load()),
+	}
+}
+
+// addStats accumulates the iterations and nodes expanded by another planner into this one.
+func (mp *planner) addStats(other motionPlanner) {
+	stats := other.stats()
+	mp.iterations.Add(int64(stats.Iterations))
+	mp.nodesExpanded.Add(int64(stats.NodesExpanded))
+}
+
 // smoothPath will try to naively smooth the path by picking points partway between waypoints and seeing if it can interpolate
 // directly between them. This will significantly improve paths from RRT*, as it will shortcut the randomly-selected configurations.
 // This will only ever improve paths (or leave them untouched), and runs very quickly.
@@ -284,10 +525,23 @@ func (mp *planner) smoothPath(ctx context.Context, path []node) []node {
 		return path
 	}
 
+	return shortcutPath(ctx, mp.frame, path, mp.planOpts.SmoothIter, mp.randseed, mp.checkPath)
+}
+
+// shortcutPath repeatedly picks two points partway along random edges of the path and, if checkPath finds the segment between them
+// and the partial edges around it valid, replaces the section of path between them with that segment.
+func shortcutPath(
+	ctx context.Context,
+	f frame.Frame,
+	path []node,
+	iterations int,
+	randseed *rand.Rand,
+	checkPath func([]frame.Input, []frame.Input) bool,
+) []node {
 	// Randomly pick which quarter of motion to check from; this increases flexibility of smoothing.
 	waypoints := []float64{0.25, 0.5, 0.75}
 
-	for i := 0; i < mp.planOpts.SmoothIter; i++ {
+	for i := 0; i < iterations && len(path) > 2; i++ {
 		select {
 		case <-ctx.Done():
 			return path
@@ -295,19 +549,23 @@ func (mp *planner) smoothPath(ctx context.Context, path []node) []node {
 		}
 		// get start node of first edge. Cannot be either the last or second-to-last node.
 		// Intn will return an int in the half-open interval half-open interval [0,n)
-		firstEdge := mp.randseed.Intn(len(path) - 2)
-		secondEdge := firstEdge + 1 + mp.randseed.Intn((len(path)-2)-firstEdge)
+		firstEdge := randseed.Intn(len(path) - 2)
+		secondEdge := firstEdge + 1 + randseed.Intn((len(path)-2)-firstEdge)
 
-		wayPoint1, err := mp.frame.Interpolate(path[firstEdge].Q(), path[firstEdge+1].Q(), waypoints[mp.randseed.Intn(3)])
+		wayPoint1, err := f.Interpolate(path[firstEdge].Q(), path[firstEdge+1].Q(), waypoints[randseed.Intn(3)])
 		if err != nil {
 			return path
 		}
-		wayPoint2, err := mp.frame.Interpolate(path[secondEdge].Q(), path[secondEdge+1].Q(), waypoints[mp.randseed.Intn(3)])
+		wayPoint2, err := f.Interpolate(path[secondEdge].Q(), path[secondEdge+1].Q(), waypoints[randseed.Intn(3)])
 		if err != nil {
 			return path
 		}
 
-		if mp.checkPath(wayPoint1, wayPoint2) {
+		// The partial edges leading into and out of the shortcut are re-checked as well, since a segment which passed at one
+		// discretization may not pass when resampled from a point partway along it.
+		if checkPath(wayPoint1, wayPoint2) &&
+			checkPath(path[firstEdge].Q(), wayPoint1) &&
+			checkPath(wayPoint2, path[secondEdge+1].Q()) {
 			newpath := []node{}
 			newpath = append(newpath, path[:firstEdge+1]...)
 			newpath = append(newpath, newConfigurationNode(wayPoint1), newConfigurationNode(wayPoint2))
@@ -319,6 +577,52 @@ func (mp *planner) smoothPath(ctx context.Context, path []node) []node {
 	return path
 }
 
+// SmoothPath shortcuts a path of inputs for the given frame, returning a new path of inputs which is no longer, measured in joint
+// space, than the input path. Shortcuts are only taken when every state along them, checked every `resolution` mm or degrees,
+// satisfies the given constraints, so smoothing never introduces a collision that the constraints guard against. The same seed always
+// produces the same path.
+func SmoothPath(
+	ctx context.Context,
+	f frame.Frame,
+	path [][]frame.Input,
+	constraints *ConstraintHandler,
+	iterations int,
+	resolution float64,
+	seed int64,
+) ([][]frame.Input, error) {
+	if iterations < 0 {
+		return nil, fmt.Errorf("smoothing iterations must not be negative, got %d", iterations)
+	}
+	if resolution <= 0 {
+		return nil, fmt.Errorf("smoothing resolution must be positive, got %v", resolution)
+	}
+	if constraints == nil {
+		constraints = &ConstraintHandler{}
+	}
+	nodes := make([]node, 0, len(path))
+	for _, inputs := range path {
+		if len(inputs) != len(f.DoF()) {
+			return nil, frame.NewIncorrectInputLengthError(len(inputs), len(f.DoF()))
+		}
+		nodes = append(nodes, newConfigurationNode(inputs))
+	}
+	checkPath := func(start, end []frame.Input) bool {
+		ok, _ := constraints.CheckSegmentAndStateValidity(
+			&ik.Segment{StartConfiguration: start, EndConfiguration: end, Frame: f},
+			resolution,
+		)
+		return ok
+	}
+	//nolint: gosec
+	nodes = shortcutPath(ctx, f, nodes, iterations, rand.New(rand.NewSource(seed)), checkPath)
+
+	smoothed := make([][]frame.Input, 0, len(nodes))
+	for _, n := range nodes {
+		smoothed = append(smoothed, n.Q())
+	}
+	return smoothed, nil
+}
+
 // getSolutions will initiate an IK solver for the given position and seed, collect solutions, and score them by constraints.
 // If maxSolutions is positive, once that many solutions have been collected, the solver will terminate and return that many solutions.
 // If minScore is positive, if a solution scoring below that amount is found, the solver will terminate and return that one solution.
@@ -337,13 +641,14 @@ func (mp *planner) getSolutions(ctx context.Context, seed []frame.Input) ([]node
 		return nil, errors.New("metric is nil")
 	}
 
+	// The solver is cancelled before it is waited on, so that it is torn down however this returns.
+	var activeSolvers sync.WaitGroup
+	defer activeSolvers.Wait()
 	ctxWithCancel, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	solutionGen := make(chan *ik.Solution, mp.planOpts.NumThreads*2)
 	ikErr := make(chan error, 1)
-	var activeSolvers sync.WaitGroup
-	defer activeSolvers.Wait()
 	activeSolvers.Add(1)
 	// Spawn the IK solver to generate solutions until done
 	utils.PanicCapturingGo(func() {
@@ -4,7 +4,11 @@ import (
 	"context"
 	"math"
 	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/golang/geo/r3"
 	"github.com/pkg/errors"
@@ -252,6 +256,7 @@ func simple2DMap() (*planConfig, error) {
 		worldGeometries.Geometries(),
 		nil, nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -323,6 +328,7 @@ func simpleXArmMotion() (*planConfig, error) {
 		nil,
 		nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -391,6 +397,7 @@ func simpleUR5eMotion() (*planConfig, error) {
 		nil,
 		nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 	if err != nil {
 		return nil, err
@@ -685,6 +692,372 @@ func TestPlanMapMotion(t *testing.T) {
 	test.That(t, len(plan), test.ShouldBeGreaterThan, 2)
 }
 
+func TestPlanStats(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	worldState, err := frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	plan, err := PlanMotion(context.Background(), &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})),
+		Frame:              model,
+		StartConfiguration: map[string][]frame.Input{model.Name(): make([]frame.Input, 3)},
+		FrameSystem:        fs,
+		WorldState:         worldState,
+	})
+	test.That(t, err, test.ShouldBeNil)
+
+	stats, ok := GetPlanStats(plan)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, stats.Iterations, test.ShouldBeGreaterThan, 0)
+	test.That(t, stats.NodesExpanded, test.ShouldBeGreaterThan, 0)
+	test.That(t, stats.Duration, test.ShouldBeGreaterThan, 0)
+	test.That(t, stats.Cost, test.ShouldBeGreaterThan, 0)
+
+	// Statistics describe the planning and are kept when the plan is manipulated afterwards
+	offsetStats, ok := GetPlanStats(OffsetPlan(plan, spatialmath.NewPoseFromPoint(r3.Vector{1, 0, 0})))
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, offsetStats, test.ShouldResemble, stats)
+
+	_, ok = GetPlanStats(NewSimplePlan(plan.Path(), plan.Trajectory()))
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestMaxNodes(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	worldState, err := frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	request := func(maxNodes int) *PlanRequest {
+		return &PlanRequest{
+			Logger:             logger,
+			Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})),
+			Frame:              model,
+			StartConfiguration: map[string][]frame.Input{model.Name(): make([]frame.Input, 3)},
+			FrameSystem:        fs,
+			WorldState:         worldState,
+			Options:            map[string]interface{}{"max_nodes": maxNodes},
+		}
+	}
+
+	// the obstacle can't be planned around without growing the trees
+	_, err = PlanMotion(context.Background(), request(2))
+	test.That(t, errors.Is(err, ErrNodeLimitReached), test.ShouldBeTrue)
+
+	plan, err := PlanMotion(context.Background(), request(10000))
+	test.That(t, err, test.ShouldBeNil)
+	stats, ok := GetPlanStats(plan)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, stats.NodesExpanded, test.ShouldBeBetweenOrEqual, 1, 10000)
+
+	_, err = PlanMotion(context.Background(), request(-1))
+	test.That(t, err, test.ShouldBeError, errors.New("max_nodes can't be negative, got -1"))
+
+	// the limit holds across planners adding nodes in parallel
+	opt := newBasicPlannerOptions(model)
+	opt.MaxNodes = 100
+	mp, err := newPlanner(model, rand.New(rand.NewSource(1)), logger, opt)
+	test.That(t, err, test.ShouldBeNil)
+	var reserved atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if mp.reserveNode() {
+					reserved.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	test.That(t, reserved.Load(), test.ShouldEqual, 100)
+	test.That(t, mp.nodeLimitReached(), test.ShouldBeTrue)
+	test.That(t, mp.stats().NodesExpanded, test.ShouldEqual, 100)
+}
+
+func TestPlanSuboptimal(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	worldState, err := frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})),
+		Frame:              model,
+		StartConfiguration: map[string][]frame.Input{model.Name(): make([]frame.Input, 3)},
+		FrameSystem:        fs,
+		WorldState:         worldState,
+		Options:            map[string]interface{}{"planning_alg": RRTStarPlanningAlg, "timeout": 2.0, "rseed": 1},
+	}
+
+	// the deadline passes before RRT* is done optimizing the path around the obstacle, which is still returned
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, errors.Is(err, ErrPlanSuboptimal), test.ShouldBeTrue)
+	test.That(t, plan, test.ShouldNotBeNil)
+	test.That(t, plan.Trajectory(), test.ShouldNotBeEmpty)
+
+	plan, err = Replan(context.Background(), request, nil, 0)
+	test.That(t, errors.Is(err, ErrPlanSuboptimal), test.ShouldBeTrue)
+	test.That(t, plan, test.ShouldNotBeNil)
+	test.That(t, plan.Trajectory(), test.ShouldNotBeEmpty)
+}
+
+func TestRandomSeedFromOptions(t *testing.T) {
+	seed, err := randomSeedFromOptions(nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, seed, test.ShouldEqual, defaultRandomSeed)
+
+	seed, err = randomSeedFromOptions(map[string]interface{}{"rseed": 42})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, seed, test.ShouldEqual, 42)
+
+	// options decoded from JSON will carry the seed as a float64
+	seed, err = randomSeedFromOptions(map[string]interface{}{"rseed": 42.})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, seed, test.ShouldEqual, 42)
+
+	_, err = randomSeedFromOptions(map[string]interface{}{"rseed": 4.2})
+	test.That(t, err, test.ShouldBeError, errors.New("rseed must be a whole number, got 4.2"))
+
+	_, err = randomSeedFromOptions(map[string]interface{}{"rseed": "42"})
+	test.That(t, err, test.ShouldBeError, errors.New("rseed must be a whole number, got string"))
+}
+
+func TestLinearMotionWaypoints(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	seed := frame.FloatsToInputs([]float64{0, -math.Pi / 4, -math.Pi / 4, 0, math.Pi / 2, 0})
+	start, err := m.Transform(seed)
+	test.That(t, err, test.ShouldBeNil)
+	goal := spatialmath.Compose(start, spatialmath.NewPoseFromPoint(r3.Vector{Y: 100}))
+
+	t.Run("waypoint density", func(t *testing.T) {
+		opts := map[string]interface{}{"motion_profile": LinearMotionProfile, "path_step_size": 25.}
+		steps, err := PlanFrameMotion(context.Background(), logger, goal, m, seed, nil, opts)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(steps), test.ShouldBeGreaterThanOrEqualTo, PathStepCount(start, goal, 25.))
+	})
+
+	t.Run("invalid step size", func(t *testing.T) {
+		opts := map[string]interface{}{"motion_profile": LinearMotionProfile, "path_step_size": -1.}
+		_, err := PlanFrameMotion(context.Background(), logger, goal, m, seed, nil, opts)
+		test.That(t, err, test.ShouldBeError, errors.New("path_step_size must be positive, got -1"))
+	})
+
+	t.Run("unreachable goal", func(t *testing.T) {
+		opts := map[string]interface{}{"motion_profile": LinearMotionProfile, "timeout": 5.}
+		farGoal := spatialmath.Compose(start, spatialmath.NewPoseFromPoint(r3.Vector{Y: 5000}))
+		_, err := PlanFrameMotion(context.Background(), logger, farGoal, m, seed, nil, opts)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "goal is unreachable")
+	})
+}
+
+func TestPlanAlternateGoals(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+
+	unreachable := frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{X: 5000}))
+	reachable := spatialmath.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatialmath.OrientationVectorDegrees{OZ: -1})
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               unreachable,
+		AlternateGoals:     []*frame.PoseInFrame{frame.NewPoseInFrame(frame.World, reachable)},
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+	}
+
+	// the plan should end at the only goal which can be reached
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+	steps, err := plan.Trajectory().GetFrameInputs(m.Name())
+	test.That(t, err, test.ShouldBeNil)
+	end, err := m.Transform(steps[len(steps)-1])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostEqualTol(end, reachable, 1, 0.01), test.ShouldBeTrue)
+
+	t.Run("invalid alternate goals", func(t *testing.T) {
+		request.AlternateGoals = []*frame.PoseInFrame{nil}
+		_, err := PlanMotion(context.Background(), request)
+		test.That(t, err, test.ShouldBeError, errors.New("PlanRequest cannot have nil alternate goal at index 0"))
+
+		request.AlternateGoals = []*frame.PoseInFrame{frame.NewPoseInFrame(m.Name(), reachable)}
+		_, err = PlanMotion(context.Background(), request)
+		test.That(t, err, test.ShouldBeError,
+			errors.New("alternate goal at index 0 has parent frame \"xArm6\", must match goal parent frame \"world\""))
+
+		request.AlternateGoals = []*frame.PoseInFrame{frame.NewPoseInFrame(frame.World, reachable)}
+		request.Options = map[string]interface{}{"motion_profile": LinearMotionProfile}
+		_, err = PlanMotion(context.Background(), request)
+		test.That(t, err, test.ShouldBeError, errors.New("alternate goals are not supported with linear motion"))
+	})
+}
+
+func TestPlanGoalTolerance(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+
+	goal := spatialmath.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatialmath.OrientationVectorDegrees{OZ: -1})
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		Options: map[string]interface{}{
+			"goal_position_tolerance_mm":      10.,
+			"goal_orientation_tolerance_degs": 5.,
+		},
+	}
+
+	// the plan ends within tolerance of the goal
+	plan, err := PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeNil)
+	steps, err := plan.Trajectory().GetFrameInputs(m.Name())
+	test.That(t, err, test.ShouldBeNil)
+	end, err := m.Transform(steps[len(steps)-1])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostEqualTol(end, goal, 10.01, utils.DegToRad(5.01)), test.ShouldBeTrue)
+
+	request.Options = map[string]interface{}{"goal_position_tolerance_mm": -1.}
+	_, err = PlanMotion(context.Background(), request)
+	test.That(t, err, test.ShouldBeError,
+		errors.New("goal_position_tolerance_mm and goal_orientation_tolerance_degs can't be negative"))
+}
+
+func TestIKSolverSelection(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	goal := spatialmath.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatialmath.OrientationVectorDegrees{OZ: -1})
+
+	steps, err := PlanFrameMotion(context.Background(), logger, goal, m, home6, nil, map[string]interface{}{
+		"ik_solver":         NloptIKSolver,
+		"ik_max_iterations": 2000,
+	})
+	test.That(t, err, test.ShouldBeNil)
+	end, err := m.Transform(steps[len(steps)-1])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostEqualTol(end, goal, 1, 0.01), test.ShouldBeTrue)
+
+	_, err = PlanFrameMotion(context.Background(), logger, goal, m, home6, nil, map[string]interface{}{"ik_solver": "newton"})
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown ik solver "newton", must be one of "nlopt" or "combined"`))
+}
+
+func TestSmoothPath(t *testing.T) {
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame("test", []frame.Limit{{-200, 200}, {-200, 200}}, sphere)
+	test.That(t, err, test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+
+	startGeoms, err := model.Geometries(make([]frame.Input, 2))
+	test.That(t, err, test.ShouldBeNil)
+	collisionConstraint, err := NewCollisionConstraint(
+		startGeoms.Geometries(), []spatialmath.Geometry{box}, nil, false, defaultCollisionBufferMM,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	handler := &ConstraintHandler{}
+	handler.AddStateConstraint("collision", collisionConstraint)
+
+	// a jagged path around the left of the obstacle
+	path := [][]frame.Input{}
+	for _, pt := range [][]float64{{0, 0}, {-30, 10}, {-60, 20}, {-40, 40}, {-70, 60}, {-50, 80}, {-20, 90}, {0, 100}} {
+		path = append(path, frame.FloatsToInputs(pt))
+	}
+	pathLength := func(path [][]frame.Input) float64 {
+		length := 0.
+		for i := 1; i < len(path); i++ {
+			length += ik.L2InputMetric(&ik.Segment{StartConfiguration: path[i-1], EndConfiguration: path[i]})
+		}
+		return length
+	}
+
+	smoothed, err := SmoothPath(context.Background(), model, path, handler, 200, defaultResolution, defaultRandomSeed)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, smoothed[0], test.ShouldResemble, path[0])
+	test.That(t, smoothed[len(smoothed)-1], test.ShouldResemble, path[len(path)-1])
+	test.That(t, pathLength(smoothed), test.ShouldBeLessThan, pathLength(path))
+	for i := 1; i < len(smoothed); i++ {
+		ok, _ := handler.CheckSegmentAndStateValidity(
+			&ik.Segment{StartConfiguration: smoothed[i-1], EndConfiguration: smoothed[i], Frame: model},
+			defaultResolution,
+		)
+		test.That(t, ok, test.ShouldBeTrue)
+	}
+
+	again, err := SmoothPath(context.Background(), model, path, handler, 200, defaultResolution, defaultRandomSeed)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, again, test.ShouldResemble, smoothed)
+
+	_, err = SmoothPath(context.Background(), model, path, handler, -1, defaultResolution, defaultRandomSeed)
+	test.That(t, err, test.ShouldBeError, errors.New("smoothing iterations must not be negative, got -1"))
+	_, err = SmoothPath(context.Background(), model, [][]frame.Input{{{0}}}, handler, 1, defaultResolution, defaultRandomSeed)
+	test.That(t, err, test.ShouldBeError, frame.NewIncorrectInputLengthError(1, 2))
+}
+
 func TestSliceUniq(t *testing.T) {
 	fs := makeTestFS(t)
 	slice := []frame.Frame{}
@@ -892,6 +1265,315 @@ func TestMovementWithGripper(t *testing.T) {
 	test.That(t, solution, test.ShouldNotBeNil)
 }
 
+func TestJointWeights(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	fs := makeTestFS(t)
+	sf, err := newSolverFrame(fs, "xArm6", frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	pm, err := newPlanManager(sf, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := sf.sliceToMap(make([]frame.Input, len(sf.DoF())))
+	setup := func(opts map[string]interface{}) (*plannerOptions, error) {
+		return pm.plannerSetupFromMoveRequest(
+			spatialmath.NewZeroPose(), spatialmath.NewZeroPose(), seedMap, nil, nil, nil, nil, opts,
+		)
+	}
+	// a segment which only rotates the base of the arm
+	segment := &ik.Segment{
+		StartConfiguration: frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0}),
+		EndConfiguration:   frame.FloatsToInputs([]float64{1, 0, 0, 0, 0, 0}),
+	}
+
+	// weights default to uniform
+	opt, err := setup(map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.DistanceFunc(segment), test.ShouldAlmostEqual, 1)
+
+	opt, err = setup(map[string]interface{}{"joint_weights": map[string]interface{}{"xArm6": []interface{}{4., 1., 1., 1., 1., 1.}}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.DistanceFunc(segment), test.ShouldAlmostEqual, 2)
+	test.That(t, opt.ScoreFunc(segment), test.ShouldAlmostEqual, 2)
+
+	_, err = setup(map[string]interface{}{"joint_weights": map[string]interface{}{"xArm6": []interface{}{4.}}})
+	test.That(t, err, test.ShouldBeError, errors.New("frame xArm6 has 6 inputs but 1 joint weights were given"))
+	_, err = setup(map[string]interface{}{"joint_weights": map[string][]float64{"xArm6": {-1, 1, 1, 1, 1, 1}}})
+	test.That(t, err, test.ShouldBeError, errors.New("joint weights for frame xArm6 cannot be negative"))
+	_, err = setup(map[string]interface{}{"joint_weights": map[string][]float64{"nonexistent": {1}}})
+	test.That(t, err, test.ShouldBeError, errors.New("joint weights were given for nonexistent, which is not a moving frame of the plan"))
+	_, err = setup(map[string]interface{}{"joint_weights": map[string]interface{}{"xArm6": "heavy"}})
+	test.That(t, err, test.ShouldBeError, errors.New(`could not interpret joint_weights value for "xArm6" as a list of float64`))
+	_, err = setup(map[string]interface{}{"joint_weights": []float64{1}})
+	test.That(t, err, test.ShouldBeError, errors.New("could not interpret joint_weights field as a map of frame names to lists of float64"))
+}
+
+func TestTimeCostMetric(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	fs := makeTestFS(t)
+	sf, err := newSolverFrame(fs, "xArm6", frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	pm, err := newPlanManager(sf, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := sf.sliceToMap(make([]frame.Input, len(sf.DoF())))
+	setup := func(opts map[string]interface{}) (*plannerOptions, error) {
+		return pm.plannerSetupFromMoveRequest(
+			spatialmath.NewZeroPose(), spatialmath.NewZeroPose(), seedMap, nil, nil, nil, nil, opts,
+		)
+	}
+	// a segment which rotates the base of the arm further than the wrist, though the wrist is slower
+	segment := &ik.Segment{
+		StartConfiguration: frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0}),
+		EndConfiguration:   frame.FloatsToInputs([]float64{2, 0, 0, 0, 0, 1}),
+	}
+	// the arm is mounted on a gantry, which moves with it
+	limits := map[string]interface{}{
+		"xArm6":   []interface{}{2., 2., 2., 2., 2., 0.5},
+		"gantryX": []interface{}{100.},
+		"gantryY": []interface{}{100.},
+	}
+
+	// path length is the default, and isn't affected by velocity limits
+	for _, metric := range []string{"", PathLengthCostMetric} {
+		opt, err := setup(map[string]interface{}{"cost_metric": metric, "joint_velocity_limits": limits})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, opt.DistanceFunc(segment), test.ShouldAlmostEqual, math.Sqrt(5))
+	}
+
+	opt, err := setup(map[string]interface{}{"cost_metric": TimeCostMetric, "joint_velocity_limits": limits})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.DistanceFunc(segment), test.ShouldAlmostEqual, 2)
+	test.That(t, opt.ScoreFunc(segment), test.ShouldAlmostEqual, 2)
+
+	_, err = setup(map[string]interface{}{"cost_metric": "energy"})
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown cost_metric "energy", must be one of "path_length" or "time"`))
+	_, err = setup(map[string]interface{}{"cost_metric": TimeCostMetric})
+	test.That(t, err, test.ShouldBeError, errors.New("the time cost_metric requires joint_velocity_limits to be set"))
+	_, err = setup(map[string]interface{}{
+		"cost_metric":           TimeCostMetric,
+		"joint_velocity_limits": limits,
+		"joint_weights":         map[string][]float64{"xArm6": {1, 1, 1, 1, 1, 1}},
+	})
+	test.That(t, err, test.ShouldBeError, errors.New("joint_weights cannot be used with the time cost_metric"))
+	_, err = setup(map[string]interface{}{
+		"cost_metric":           TimeCostMetric,
+		"joint_velocity_limits": map[string][]float64{"xArm6": {1, 1, 1, 1, 1, 1}},
+	})
+	test.That(t, err, test.ShouldBeError, errors.New("no joint velocity limits were given for frame gantryY"))
+	_, err = setup(map[string]interface{}{
+		"cost_metric":           TimeCostMetric,
+		"joint_velocity_limits": map[string][]float64{"xArm6": {1, 1, 1, 1, 1, 0}, "gantryX": {1}, "gantryY": {1}},
+	})
+	test.That(t, err, test.ShouldBeError, errors.New("joint velocity limits for frame xArm6 must be positive"))
+	_, err = setup(map[string]interface{}{
+		"cost_metric":           TimeCostMetric,
+		"joint_velocity_limits": map[string]interface{}{"xArm6": "fast"},
+	})
+	test.That(t, err, test.ShouldBeError, errors.New(`could not interpret joint_velocity_limits value for "xArm6" as a list of float64`))
+}
+
+func TestPrismaticJoints(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.UnmarshalModelJSON([]byte(`{
+		"name": "rail_arm",
+		"links": [
+			{"id": "carriage", "parent": "rail", "translation": {"x": 0, "y": 0, "z": 50}},
+			{"id": "arm", "parent": "shoulder", "translation": {"x": 300, "y": 0, "z": 0}}
+		],
+		"joints": [
+			{"id": "rail", "type": "prismatic", "parent": "world", "axis": {"x": 1, "y": 0, "z": 0}, "min": -500, "max": 1500},
+			{"id": "shoulder", "type": "revolute", "parent": "carriage", "axis": {"x": 0, "y": 0, "z": 1}, "min": -90, "max": 90}
+		]
+	}`), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	sf, err := newSolverFrame(fs, m.Name(), frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	pm, err := newPlanManager(sf, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := sf.sliceToMap(make([]frame.Input, len(sf.DoF())))
+	setup := func(opts map[string]interface{}) (*plannerOptions, error) {
+		return pm.plannerSetupFromMoveRequest(
+			spatialmath.NewZeroPose(), spatialmath.NewZeroPose(), seedMap, nil, nil, nil, nil, opts,
+		)
+	}
+
+	// moving the rail across its whole range counts as far as turning the shoulder a full circle
+	traverse := &ik.Segment{
+		StartConfiguration: frame.FloatsToInputs([]float64{-500, 0}),
+		EndConfiguration:   frame.FloatsToInputs([]float64{1500, 0}),
+	}
+	turn := &ik.Segment{
+		StartConfiguration: frame.FloatsToInputs([]float64{0, 0}),
+		EndConfiguration:   frame.FloatsToInputs([]float64{0, 1}),
+	}
+	opt, err := setup(map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.DistanceFunc(traverse), test.ShouldAlmostEqual, 2*math.Pi)
+	test.That(t, opt.ScoreFunc(turn), test.ShouldAlmostEqual, 1)
+
+	// joint weights are applied on top of the scaling
+	opt, err = setup(map[string]interface{}{"joint_weights": map[string][]float64{m.Name(): {4, 1}}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.DistanceFunc(traverse), test.ShouldAlmostEqual, 4*math.Pi)
+	test.That(t, opt.DistanceFunc(turn), test.ShouldAlmostEqual, 1)
+
+	// reaching the goal requires moving along the rail as well as turning the shoulder
+	goal, err := m.Transform(frame.FloatsToInputs([]float64{1000, math.Pi / 2}))
+	test.That(t, err, test.ShouldBeNil)
+	plan, err := PlanMotion(context.Background(), &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		FrameSystem:        fs,
+		StartConfiguration: map[string][]frame.Input{m.Name(): frame.FloatsToInputs([]float64{0, 0})},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	steps, err := plan.Trajectory().GetFrameInputs(m.Name())
+	test.That(t, err, test.ShouldBeNil)
+	end, err := m.Transform(steps[len(steps)-1])
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.PoseAlmostCoincidentEps(end, goal, 1), test.ShouldBeTrue)
+	for _, step := range steps {
+		test.That(t, frame.InputsToFloats(step)[0], test.ShouldBeBetweenOrEqual, -500, 1500)
+	}
+}
+
+func TestPriorPlan(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame("test", []frame.Limit{{-100, 100}, {-100, 100}}, sphere)
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+	worldStateWith := func(boxes ...spatialmath.Geometry) *frame.WorldState {
+		ws, err := frame.NewWorldState([]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, boxes)}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		return ws
+	}
+	wall, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{60, 25, 25}, "wall")
+	test.That(t, err, test.ShouldBeNil)
+	there := spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})
+	request := func(start []float64, goal spatialmath.Pose, ws *frame.WorldState, prior Plan) *PlanRequest {
+		return &PlanRequest{
+			Logger:             logger,
+			Goal:               frame.NewPoseInFrame(frame.World, goal),
+			Frame:              model,
+			StartConfiguration: map[string][]frame.Input{model.Name(): frame.FloatsToInputs(start)},
+			FrameSystem:        fs,
+			WorldState:         ws,
+			PriorPlan:          prior,
+			Options:            map[string]interface{}{"rseed": 1, "planning_alg": CBiRRTPlanningAlg},
+		}
+	}
+	collides := func(plan Plan, obstacle spatialmath.Geometry) bool {
+		steps, err := plan.Trajectory().GetFrameInputs(model.Name())
+		test.That(t, err, test.ShouldBeNil)
+		for i := 1; i < len(steps); i++ {
+			for _, by := range []float64{0, 0.25, 0.5, 0.75, 1} {
+				inputs, err := model.Interpolate(steps[i-1], steps[i], by)
+				test.That(t, err, test.ShouldBeNil)
+				geoms, err := model.Geometries(inputs)
+				test.That(t, err, test.ShouldBeNil)
+				collide, err := geoms.Geometries()[0].CollidesWith(obstacle, 0)
+				test.That(t, err, test.ShouldBeNil)
+				if collide {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	forth, err := PlanMotion(context.Background(), request([]float64{0, 0}, there, worldStateWith(wall), nil))
+	test.That(t, err, test.ShouldBeNil)
+
+	// planning back along the prior plan, which is followed from its far end, converges much faster than planning from scratch
+	back, err := PlanMotion(context.Background(), request([]float64{0, 100}, spatialmath.NewZeroPose(), worldStateWith(wall), forth))
+	test.That(t, err, test.ShouldBeNil)
+	warmStats, ok := GetPlanStats(back)
+	test.That(t, ok, test.ShouldBeTrue)
+	cold, err := PlanMotion(context.Background(), request([]float64{0, 100}, spatialmath.NewZeroPose(), worldStateWith(wall), nil))
+	test.That(t, err, test.ShouldBeNil)
+	coldStats, ok := GetPlanStats(cold)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, warmStats.Iterations, test.ShouldBeLessThan, coldStats.Iterations)
+
+	// the prior plan is checked against the obstacles of the new request, so one in the way of it is avoided
+	steps, err := forth.Trajectory().GetFrameInputs(model.Name())
+	test.That(t, err, test.ShouldBeNil)
+	midway := frame.InputsToFloats(steps[len(steps)/2])
+	blocker, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: midway[0], Y: midway[1]}), r3.Vector{10, 10, 10}, "blocker")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collides(forth, blocker), test.ShouldBeTrue)
+	back, err = PlanMotion(context.Background(), request([]float64{0, 100}, spatialmath.NewZeroPose(), worldStateWith(wall, blocker), forth))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, collides(back, blocker), test.ShouldBeFalse)
+	test.That(t, collides(back, wall), test.ShouldBeFalse)
+
+	other := NewSimplePlan(nil, Trajectory{{"other": frame.FloatsToInputs([]float64{0, 0})}})
+	_, err = PlanMotion(context.Background(), request([]float64{0, 100}, spatialmath.NewZeroPose(), worldStateWith(wall), other))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot use prior plan")
+}
+
+func TestGoalBias(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	fs := makeTestFS(t)
+	sf, err := newSolverFrame(fs, "xArm6", frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	pm, err := newPlanManager(sf, logger, 1)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := sf.sliceToMap(make([]frame.Input, len(sf.DoF())))
+	setup := func(opts map[string]interface{}) (*plannerOptions, error) {
+		return pm.plannerSetupFromMoveRequest(
+			spatialmath.NewZeroPose(), spatialmath.NewZeroPose(), seedMap, nil, nil, nil, nil, opts,
+		)
+	}
+
+	opt, err := setup(map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, opt.GoalBias, test.ShouldEqual, 0)
+	for _, bias := range []float64{-0.1, 1.5} {
+		_, err = setup(map[string]interface{}{"goal_bias": bias})
+		test.That(t, err, test.ShouldBeError, errors.Errorf("goal_bias must be between 0 and 1, got %v", bias))
+	}
+
+	// a bias of 1 always samples the goal, while no bias never does
+	opt, err = setup(map[string]interface{}{"goal_bias": 1})
+	test.That(t, err, test.ShouldBeNil)
+	mp, err := newCBiRRTMotionPlanner(sf, rand.New(rand.NewSource(1)), logger, opt)
+	test.That(t, err, test.ShouldBeNil)
+	cbirrt, ok := mp.(*cBiRRTMotionPlanner)
+	test.That(t, ok, test.ShouldBeTrue)
+	seed := newConfigurationNode(make([]frame.Input, len(sf.DoF())))
+	goal := newConfigurationNode(frame.FloatsToInputs([]float64{1, 1, 1, 1, 1, 1}))
+	for i := 0; i < 10; i++ {
+		sample, err := cbirrt.goalBiasedSample(seed, i, goal)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, sample.Q(), test.ShouldResemble, goal.Q())
+	}
+	opt.GoalBias = 0
+	for i := 0; i < 10; i++ {
+		sample, err := cbirrt.goalBiasedSample(seed, i, goal)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, sample.Q(), test.ShouldNotResemble, goal.Q())
+	}
+
+	// planning with a goal bias still reaches the goal
+	goalPose := spatialmath.NewPoseFromPoint(r3.Vector{X: 300, Y: 200, Z: 200})
+	plan, err := PlanMotion(context.Background(), &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, goalPose),
+		Frame:              fs.Frame("xArm6"),
+		FrameSystem:        fs,
+		StartConfiguration: frame.StartPositions(fs),
+		Options:            map[string]interface{}{"goal_bias": 0.2},
+	})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Trajectory(), test.ShouldNotBeEmpty)
+}
+
 func TestReplanValidations(t *testing.T) {
 	ctx := context.Background()
 	logger := logging.NewTestLogger(t)
@@ -1280,3 +1962,394 @@ func TestArmGantryCheckPlan(t *testing.T) {
 		test.That(t, err, test.ShouldNotBeNil)
 	})
 }
+
+func TestPlanProgress(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal := spatialmath.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatialmath.OrientationVectorDegrees{OZ: -1})
+
+	var mu sync.Mutex
+	var reports []PlanProgress
+	returned := false
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, goal),
+		Frame:              m,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		FrameSystem:        fs,
+		Options:            map[string]interface{}{"planning_alg": RRTStarPlanningAlg},
+		Progress: func(progress PlanProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			test.That(t, returned, test.ShouldBeFalse)
+			reports = append(reports, progress)
+		},
+		ProgressInterval: time.Millisecond,
+	}
+
+	t.Run("reports until planning returns", func(t *testing.T) {
+		_, err := PlanMotion(context.Background(), request)
+		test.That(t, err, test.ShouldBeNil)
+		mu.Lock()
+		returned = true
+		test.That(t, reports, test.ShouldNotBeEmpty)
+		for i := 1; i < len(reports); i++ {
+			test.That(t, reports[i].Iterations, test.ShouldBeGreaterThanOrEqualTo, reports[i-1].Iterations)
+		}
+		mu.Unlock()
+		// no further reports may arrive once planning has returned
+		time.Sleep(10 * time.Millisecond)
+	})
+
+	t.Run("negative interval", func(t *testing.T) {
+		badRequest := *request
+		badRequest.ProgressInterval = -time.Second
+		_, err := PlanMotion(context.Background(), &badRequest)
+		test.That(t, err, test.ShouldBeError, errors.New("progress interval must not be negative, got -1s"))
+	})
+}
+
+func TestProgressTrackerReport(t *testing.T) {
+	m, err := frame.New2DMobileModelFrame("test", []frame.Limit{{-200, 200}, {-200, 200}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	sf, err := newSolverFrame(fs, m.Name(), frame.World, map[string][]frame.Input{m.Name(): frame.FloatsToInputs([]float64{0, 0})})
+	test.That(t, err, test.ShouldBeNil)
+
+	pt := &progressTracker{}
+	reports := make(chan PlanProgress, 100)
+	stop := pt.report(context.Background(), sf, time.Millisecond, func(progress PlanProgress) {
+		select {
+		case reports <- progress:
+		default:
+		}
+	})
+	pt.addIteration()
+	pt.addIteration()
+	pt.setBestPath([]node{
+		newConfigurationNode(frame.FloatsToInputs([]float64{0, 0})),
+		newConfigurationNode(frame.FloatsToInputs([]float64{10, 20})),
+	})
+	for progress := range reports {
+		if progress.BestPath != nil {
+			test.That(t, progress.Iterations, test.ShouldEqual, 2)
+			test.That(t, progress.BestPath, test.ShouldHaveLength, 2)
+			test.That(t, progress.BestPath[1][m.Name()], test.ShouldResemble, frame.FloatsToInputs([]float64{10, 20}))
+			break
+		}
+	}
+	stop()
+	for len(reports) > 0 {
+		<-reports
+	}
+	time.Sleep(10 * time.Millisecond)
+	test.That(t, reports, test.ShouldBeEmpty)
+
+	// reporting also stops when the context is cancelled, and stopping afterwards does not block
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := make(chan struct{}, 100)
+	stop = pt.report(ctx, sf, time.Millisecond, func(PlanProgress) { calls <- struct{}{} })
+	<-calls
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	for len(calls) > 0 {
+		<-calls
+	}
+	time.Sleep(10 * time.Millisecond)
+	test.That(t, calls, test.ShouldBeEmpty)
+	stop()
+
+	// a nil tracker ignores updates
+	var nilTracker *progressTracker
+	nilTracker.addIteration()
+	nilTracker.setBestPath(nil)
+}
+
+// newMotionPlanner builds the named planner for the frame with default options, using nCPU threads and a fixed seed. An nCPU of
+// zero uses every CPU.
+func newMotionPlanner(name string, f frame.Frame, nCPU int, logger logging.Logger) (motionPlanner, error) {
+	constructor, err := plannerConstructorFromName(name)
+	if err != nil {
+		return nil, err
+	}
+	opt := newBasicPlannerOptions(f)
+	opt.NumThreads = nCPU
+	//nolint: gosec
+	return constructor(f, rand.New(rand.NewSource(1)), logger, opt)
+}
+
+func TestPlanningAlgorithms(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	test.That(t, PlanningAlgorithms(), test.ShouldResemble, []string{CBiRRTPlanningAlg, RRTStarPlanningAlg})
+
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	for _, name := range PlanningAlgorithms() {
+		mp, err := newMotionPlanner(name, m, 2, logger)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mp.opt().NumThreads, test.ShouldEqual, workerCount(2))
+	}
+
+	_, err = newMotionPlanner("rrt", m, 2, logger)
+	test.That(t, err, test.ShouldBeError, errors.New(`unknown planning_alg "rrt", must be one of [cbirrt rrtstar]`))
+
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	_, err = PlanMotion(context.Background(), &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{X: 300, Y: 200, Z: 200})),
+		Frame:              m,
+		StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+		FrameSystem:        fs,
+		Options:            map[string]interface{}{"planning_alg": "rrt"},
+	})
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `unknown planning_alg "rrt"`)
+}
+
+func TestPlannerWorkerCount(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	nCPU := runtime.NumCPU()
+	test.That(t, workerCount(0), test.ShouldEqual, nCPU)
+	test.That(t, workerCount(-1), test.ShouldEqual, nCPU)
+	test.That(t, workerCount(1), test.ShouldEqual, 1)
+	test.That(t, workerCount(nCPU+10), test.ShouldEqual, nCPU)
+
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	mp, err := newMotionPlanner(RRTStarPlanningAlg, m, 0, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mp.opt().NumThreads, test.ShouldEqual, nCPU)
+
+	// the IK workers are torn down when the context is cancelled, which is checked for leaks when the tests finish
+	mp.opt().SetGoal(spatialmath.NewPoseFromPoint(r3.Vector{X: 5000}))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = mp.getSolutions(ctx, home6)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestIsReachable(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(m, fs.World()), test.ShouldBeNil)
+	goal := spatialmath.NewPose(r3.Vector{X: 300, Y: 200, Z: 200}, &spatialmath.OrientationVectorDegrees{OZ: -1})
+	request := func(goal spatialmath.Pose, worldState *frame.WorldState) *PlanRequest {
+		return &PlanRequest{
+			Logger:             logger,
+			Goal:               frame.NewPoseInFrame(frame.World, goal),
+			Frame:              m,
+			StartConfiguration: map[string][]frame.Input{m.Name(): home6},
+			FrameSystem:        fs,
+			WorldState:         worldState,
+		}
+	}
+
+	reachable, err := IsReachable(context.Background(), request(goal, nil))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reachable, test.ShouldBeTrue)
+
+	// out of the arm's reach
+	reachable, err = IsReachable(context.Background(), request(spatialmath.NewPoseFromPoint(r3.Vector{X: 5000}), nil))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reachable, test.ShouldBeFalse)
+
+	// in reach, but inside an obstacle
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(goal.Point()), r3.Vector{X: 200, Y: 200, Z: 200}, "box")
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := []*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})}
+	worldState, err := frame.NewWorldState(obstacles, nil)
+	test.That(t, err, test.ShouldBeNil)
+	reachable, err = IsReachable(context.Background(), request(goal, worldState))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reachable, test.ShouldBeFalse)
+
+	// a cancelled context is an error rather than an unreachable goal
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = IsReachable(ctx, request(goal, nil))
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+}
+
+func TestRepairPlan(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+	startConfiguration := map[string][]frame.Input{model.Name(): make([]frame.Input, 3)}
+
+	// a straight drive to the goal, planned before the box was in the way
+	sf, err := newSolverFrame(fs, model.Name(), frame.World, startConfiguration)
+	test.That(t, err, test.ShouldBeNil)
+	var nodes []node
+	for y := 0.; y <= 100; y += 20 {
+		nodes = append(nodes, newConfigurationNode(frame.FloatsToInputs([]float64{0, y, 0})))
+	}
+	previous, err := newRRTPlan(nodes, sf, false)
+	test.That(t, err, test.ShouldBeNil)
+
+	request := &PlanRequest{
+		Logger:             logger,
+		Goal:               frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0})),
+		Frame:              model,
+		StartConfiguration: startConfiguration,
+		FrameSystem:        fs,
+	}
+
+	_, err = RepairPlan(context.Background(), request, nil)
+	test.That(t, err, test.ShouldBeError, "cannot repair a plan with fewer than two waypoints")
+
+	// a plan which is still valid is kept as is
+	repaired, err := RepairPlan(context.Background(), request, previous)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, repaired, test.ShouldEqual, previous)
+
+	// the box now blocks the middle of the drive, which is replanned around it while the rest of the plan is kept
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	request.WorldState, err = frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	repaired, err = RepairPlan(context.Background(), request, previous)
+	test.That(t, err, test.ShouldBeNil)
+	inputs, err := repaired.Trajectory().GetFrameInputs(model.Name())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(inputs), test.ShouldBeGreaterThan, 4)
+	test.That(t, inputs[:2], test.ShouldResemble, [][]frame.Input{
+		frame.FloatsToInputs([]float64{0, 0, 0}),
+		frame.FloatsToInputs([]float64{0, 20, 0}),
+	})
+	test.That(t, inputs[len(inputs)-2:], test.ShouldResemble, [][]frame.Input{
+		frame.FloatsToInputs([]float64{0, 80, 0}),
+		frame.FloatsToInputs([]float64{0, 100, 0}),
+	})
+
+	// the repaired plan is collision free end to end
+	executionState, err := NewExecutionState(
+		repaired,
+		0,
+		repaired.Trajectory()[0],
+		map[string]*frame.PoseInFrame{model.Name(): repaired.Path()[0][model.Name()]},
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, CheckPlan(model, executionState, request.WorldState, fs, math.Inf(1), logger), test.ShouldBeNil)
+
+	// a box on the final configuration ends the plan at the goal position through another configuration
+	offset, err := spatialmath.NewSphere(spatialmath.NewPoseFromPoint(r3.Vector{15, 0, 0}), 5, "arm")
+	test.That(t, err, test.ShouldBeNil)
+	model, err = frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		offset,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	fs = frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+	sf, err = newSolverFrame(fs, model.Name(), frame.World, startConfiguration)
+	test.That(t, err, test.ShouldBeNil)
+	previous, err = newRRTPlan(nodes, sf, false)
+	test.That(t, err, test.ShouldBeNil)
+	box, err = spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{18, 100, 0}), r3.Vector{6, 6, 6}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	request.WorldState, err = frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	request.Frame = model
+	request.FrameSystem = fs
+	request.Options = map[string]interface{}{"motion_profile": PositionOnlyMotionProfile}
+	repaired, err = RepairPlan(context.Background(), request, previous)
+	test.That(t, err, test.ShouldBeNil)
+	inputs, err = repaired.Trajectory().GetFrameInputs(model.Name())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inputs[0], test.ShouldResemble, frame.FloatsToInputs([]float64{0, 0, 0}))
+	final := inputs[len(inputs)-1]
+	test.That(t, final, test.ShouldNotResemble, frame.FloatsToInputs([]float64{0, 100, 0}))
+	test.That(t, final[0].Value, test.ShouldAlmostEqual, 0, 1e-3)
+	test.That(t, final[1].Value, test.ShouldAlmostEqual, 100, 1e-3)
+	executionState, err = NewExecutionState(
+		repaired,
+		0,
+		repaired.Trajectory()[0],
+		map[string]*frame.PoseInFrame{model.Name(): repaired.Path()[0][model.Name()]},
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, CheckPlan(model, executionState, request.WorldState, fs, math.Inf(1), logger), test.ShouldBeNil)
+}
+
+func TestPlanGoalConfiguration(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "base")
+	test.That(t, err, test.ShouldBeNil)
+	model, err := frame.New2DMobileModelFrame(
+		"test",
+		[]frame.Limit{{-100, 100}, {-100, 100}, {-2 * math.Pi, 2 * math.Pi}},
+		sphere,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptyFrameSystem("")
+	test.That(t, fs.AddFrame(model, fs.World()), test.ShouldBeNil)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{0, 50, 0}), r3.Vector{25, 25, 25}, "impediment")
+	test.That(t, err, test.ShouldBeNil)
+	worldState, err := frame.NewWorldState(
+		[]*frame.GeometriesInFrame{frame.NewGeometriesInFrame(frame.World, []spatialmath.Geometry{box})},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	request := func(goal []float64) *PlanRequest {
+		return &PlanRequest{
+			Logger:             logger,
+			GoalConfiguration:  frame.FloatsToInputs(goal),
+			Frame:              model,
+			StartConfiguration: map[string][]frame.Input{model.Name(): make([]frame.Input, 3)},
+			FrameSystem:        fs,
+			WorldState:         worldState,
+		}
+	}
+
+	// the plan goes around the box and ends at exactly the goal configuration
+	goal := []float64{0, 100, 0.5}
+	plan, err := PlanMotion(context.Background(), request(goal))
+	test.That(t, err, test.ShouldBeNil)
+	inputs, err := plan.Trajectory().GetFrameInputs(model.Name())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(inputs), test.ShouldBeGreaterThan, 2)
+	test.That(t, inputs[len(inputs)-1], test.ShouldResemble, frame.FloatsToInputs(goal))
+
+	reachable, err := IsReachable(context.Background(), request(goal))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reachable, test.ShouldBeTrue)
+
+	// a goal configuration inside the box is rejected without planning
+	_, err = PlanMotion(context.Background(), request([]float64{0, 50, 0}))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "goal configuration violates constraint")
+	reachable, err = IsReachable(context.Background(), request([]float64{0, 50, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, reachable, test.ShouldBeFalse)
+
+	// a goal configuration replaces the goal pose
+	both := request(goal)
+	both.Goal = frame.NewPoseInFrame(frame.World, spatialmath.NewPoseFromPoint(r3.Vector{0, 100, 0}))
+	_, err = PlanMotion(context.Background(), both)
+	test.That(t, err, test.ShouldBeError, "PlanRequest cannot have both a goal and a goal configuration")
+
+	_, err = PlanMotion(context.Background(), request([]float64{0, 100}))
+	test.That(t, err, test.ShouldBeError, frame.NewIncorrectInputLengthError(2, 3))
+}
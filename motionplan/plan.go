@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sort"
+	"time"
 
 	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
@@ -37,7 +39,7 @@ func RemainingPlan(plan Plan, waypointIndex int) (Plan, error) {
 	}
 	simplePlan := NewSimplePlan(path[waypointIndex:], traj[waypointIndex:])
 	if rrt, ok := plan.(*rrtPlan); ok {
-		return &rrtPlan{SimplePlan: *simplePlan, nodes: rrt.nodes[waypointIndex:]}, nil
+		return &rrtPlan{SimplePlan: *simplePlan, nodes: rrt.nodes[waypointIndex:], planStats: rrt.planStats}, nil
 	}
 	return simplePlan, nil
 }
@@ -59,11 +61,32 @@ func OffsetPlan(plan Plan, offset spatialmath.Pose) Plan {
 	}
 	simplePlan := NewSimplePlan(newPath, plan.Trajectory())
 	if rrt, ok := plan.(*rrtPlan); ok {
-		return &rrtPlan{SimplePlan: *simplePlan, nodes: rrt.nodes}
+		return &rrtPlan{SimplePlan: *simplePlan, nodes: rrt.nodes, planStats: rrt.planStats}
 	}
 	return simplePlan
 }
 
+// PlanStats describes how much work the planner did to produce a Plan, and how good the result is.
+type PlanStats struct {
+	// Iterations is the number of iterations run by the planners, including any fallbacks.
+	Iterations int
+	// NodesExpanded is the number of nodes added to the planners' search trees.
+	NodesExpanded int
+	// Duration is the wall time spent planning.
+	Duration time.Duration
+	// Cost is the cost of the final trajectory as measured by the planner's score function.
+	Cost float64
+}
+
+// GetPlanStats returns the statistics recorded while planning the given Plan. The second return value is false if the Plan
+// did not come from a planner which records statistics.
+func GetPlanStats(plan Plan) (PlanStats, bool) {
+	if rrt, ok := plan.(*rrtPlan); ok && rrt.planStats != nil {
+		return *rrt.planStats, true
+	}
+	return PlanStats{}, false
+}
+
 // Trajectory is a slice of maps describing a series of Inputs for a robot to travel to in the course of following a Plan.
 // Each item in this slice maps a Frame's name (found by calling frame.Name()) to the Inputs that Frame should be modified by.
 type Trajectory []map[string][]referenceframe.Input
@@ -113,6 +136,97 @@ func (traj Trajectory) EvaluateCost(distFunc ik.SegmentMetric) float64 {
 	return totalCost
 }
 
+// PathJointDistance returns how far the inputs travel over a trajectory, summed over each step between consecutive waypoints. Each
+// step is measured as the L2 distance between the inputs of every frame that moves in it, which is the metric the planner uses.
+// If jointWeights is given, it maps frame names to a weight for each of the frame's inputs, as in the joint_weights planning
+// option, and the squared difference in each input is scaled by its weight as the planner does. Inputs of unlisted frames have
+// a weight of 1.
+func PathJointDistance(traj Trajectory, jointWeights map[string][]float64) (float64, error) {
+	var total float64
+	for i := 1; i < len(traj); i++ {
+		frames, err := movingFrameNames(traj[i-1], traj[i])
+		if err != nil {
+			return 0, err
+		}
+		var start, end []referenceframe.Input
+		var weights []float64
+		for _, frame := range frames {
+			from, to := traj[i-1][frame], traj[i][frame]
+			frameWeights, ok := jointWeights[frame]
+			if !ok {
+				frameWeights = make([]float64, len(from))
+				for j := range frameWeights {
+					frameWeights[j] = 1
+				}
+			}
+			if len(frameWeights) != len(from) {
+				return 0, fmt.Errorf("frame %s has %d inputs but %d joint weights were given", frame, len(from), len(frameWeights))
+			}
+			for _, weight := range frameWeights {
+				if weight < 0 {
+					return 0, fmt.Errorf("joint weights for frame %s cannot be negative", frame)
+				}
+			}
+			start = append(start, from...)
+			end = append(end, to...)
+			weights = append(weights, frameWeights...)
+		}
+		total += ik.NewWeightedL2InputMetric(weights)(&ik.Segment{StartConfiguration: start, EndConfiguration: end})
+	}
+	return total, nil
+}
+
+// EstimateDuration returns roughly how long following a trajectory takes when the inputs of each frame move no faster than the
+// given maximum velocities, which map frame names to a velocity for each of the frame's inputs in units of the input (radians or
+// mm) per second. Every input is assumed to start and stop together at each waypoint, moving at a constant velocity set by the
+// input which takes longest, so time spent accelerating is not accounted for.
+func EstimateDuration(traj Trajectory, maxVelocities map[string][]float64) (time.Duration, error) {
+	var total float64
+	for i := 1; i < len(traj); i++ {
+		frames, err := movingFrameNames(traj[i-1], traj[i])
+		if err != nil {
+			return 0, err
+		}
+		var stepTime float64
+		for _, frame := range frames {
+			from, to := traj[i-1][frame], traj[i][frame]
+			velocities, ok := maxVelocities[frame]
+			if !ok {
+				return 0, fmt.Errorf("no max velocities were given for frame %s", frame)
+			}
+			if len(velocities) != len(from) {
+				return 0, fmt.Errorf("frame %s has %d inputs but %d max velocities were given", frame, len(from), len(velocities))
+			}
+			for j, velocity := range velocities {
+				if velocity <= 0 {
+					return 0, fmt.Errorf("max velocity of input %d of frame %s must be positive, got %v", j, frame, velocity)
+				}
+				stepTime = math.Max(stepTime, math.Abs(to[j].Value-from[j].Value)/velocity)
+			}
+		}
+		total += stepTime
+	}
+	return time.Duration(total * float64(time.Second)), nil
+}
+
+// movingFrameNames returns the sorted names of the frames with inputs in both of two consecutive waypoints of a trajectory.
+// It returns an error if a frame has a different number of inputs in each.
+func movingFrameNames(from, to map[string][]referenceframe.Input) ([]string, error) {
+	names := make([]string, 0, len(to))
+	for name, inputs := range to {
+		fromInputs, ok := from[name]
+		if !ok || len(inputs) == 0 {
+			continue
+		}
+		if len(fromInputs) != len(inputs) {
+			return nil, referenceframe.NewIncorrectInputLengthError(len(inputs), len(fromInputs))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 // Path is a slice of PathSteps describing a series of Poses for a robot to travel to in the course of following a Plan.
 // The pose of the PathStep is the pose at the end of the corresponding set of inputs in the Trajectory.
 type Path []PathStep
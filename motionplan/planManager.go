@@ -9,7 +9,9 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"go.viam.com/utils"
@@ -19,11 +21,13 @@ import (
 	"go.viam.com/rdk/motionplan/tpspace"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/spatialmath"
+	rutils "go.viam.com/rdk/utils"
 )
 
 const (
 	defaultOptimalityMultiple      = 2.0
 	defaultFallbackTimeout         = 1.5
+	defaultReachableTimeout        = 1.
 	defaultTPspaceOrientationScale = 500.
 )
 
@@ -34,6 +38,15 @@ type planManager struct {
 	*planner
 	frame                   *solverFrame
 	activeBackgroundWorkers sync.WaitGroup
+	progress                *progressTracker
+	// goalConfiguration is the configuration of the solver frame to plan to, if the request has a joint space goal.
+	goalConfiguration []referenceframe.Input
+	// priorPath holds the configurations of the solver frame along the prior plan of the request, if it has one.
+	priorPath [][]referenceframe.Input
+	// halfSpaces are the half spaces of the request, which the frame must stay inside.
+	halfSpaces []*spatialmath.HalfSpace
+	// nodeCount counts the nodes added to the search trees of every planner run for the request, toward max_nodes.
+	nodeCount atomic.Int64
 
 	useTPspace bool
 }
@@ -54,10 +67,46 @@ func newPlanManager(
 // PlanSingleWaypoint will solve the solver frame to one individual pose. If you have multiple waypoints to hit, call this multiple times.
 // Any constraints, etc, will be held for the entire motion.
 func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequest, seedPlan Plan) (Plan, error) {
+	start := time.Now()
+	pm.halfSpaces = request.HalfSpaces
+	if request.Progress != nil {
+		pm.progress = &progressTracker{}
+		stop := pm.progress.report(ctx, pm.frame, request.ProgressInterval, request.Progress)
+		defer stop()
+	}
+	var plan Plan
+	var err error
 	if pm.useTPspace {
-		return pm.planRelativeWaypoint(ctx, request, seedPlan)
+		if len(request.AlternateGoals) > 0 {
+			return nil, errors.New("alternate goals are not supported when planning for PTGs")
+		}
+		if request.GoalConfiguration != nil {
+			return nil, errors.New("goal configurations are not supported when planning for PTGs")
+		}
+		if request.PriorPlan != nil {
+			return nil, errors.New("prior plans are not supported when planning for PTGs")
+		}
+		plan, err = pm.planRelativeWaypoint(ctx, request, seedPlan)
+	} else {
+		plan, err = pm.planAbsoluteWaypoint(ctx, request, seedPlan)
+	}
+	// a plan cut short by its deadline is still returned, along with ErrPlanSuboptimal
+	if err != nil && !errors.Is(err, ErrPlanSuboptimal) {
+		return nil, err
 	}
+	if rrt, ok := plan.(*rrtPlan); ok {
+		stats := pm.stats()
+		stats.Duration = time.Since(start)
+		stats.Cost = plan.Trajectory().EvaluateCost(pm.opt().ScoreFunc)
+		rrt.planStats = &stats
+		request.Logger.CDebugf(ctx, "planning stats: %+v", stats)
+	}
+	return plan, err
+}
 
+// planAbsoluteWaypoint plans a motion for a solver frame whose inputs directly describe its configuration, breaking the motion
+// into sub-waypoints if requested.
+func (pm *planManager) planAbsoluteWaypoint(ctx context.Context, request *PlanRequest, seedPlan Plan) (Plan, error) {
 	if request.StartPose != nil {
 		request.Logger.Warn("plan request passed a start pose, but non-relative plans will use the pose from transforming StartConfiguration")
 	}
@@ -69,14 +118,17 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 	if err != nil {
 		return nil, err
 	}
-	goalPos := request.Goal.Pose()
+	var goal interface{} = request.GoalConfiguration
+	if request.Goal != nil {
+		goal = referenceframe.PoseInFrameToProtobuf(request.Goal)
+	}
 
 	var cancel func()
 
 	request.Logger.CInfof(ctx,
 		"planning motion for frame %s\nGoal: %v\nStarting seed map %v\n, startPose %v\n, worldstate: %v\n",
 		request.Frame.Name(),
-		referenceframe.PoseInFrameToProtobuf(request.Goal),
+		goal,
 		request.StartConfiguration,
 		spatialmath.PoseToProtobuf(startPose),
 		request.WorldState.String(),
@@ -90,13 +142,45 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 		defer cancel()
 	}
 
-	// If we are world rooted, translate the goal pose into the world frame
-	if pm.frame.worldRooted {
+	// A goal configuration is planned to as is, so its pose is only needed to set up the planners
+	pm.goalConfiguration, err = pm.goalConfigurationFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	pm.priorPath, err = pm.priorPathFromRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	var goalPos spatialmath.Pose
+	switch {
+	case pm.goalConfiguration != nil:
+		goalPos, err = pm.frame.Transform(pm.goalConfiguration)
+		if err != nil {
+			return nil, err
+		}
+	case pm.frame.worldRooted:
+		// If we are world rooted, translate the goal pose into the world frame
 		tf, err := pm.frame.fss.Transform(request.StartConfiguration, request.Goal, referenceframe.World)
 		if err != nil {
 			return nil, err
 		}
 		goalPos = tf.(*referenceframe.PoseInFrame).Pose()
+	default:
+		goalPos = request.Goal.Pose()
+	}
+
+	// Any of the alternate goals is an acceptable end to the plan, so they are all solved for at once
+	goalSet := []spatialmath.Pose{goalPos}
+	for _, alternate := range request.AlternateGoals {
+		alternatePos := alternate.Pose()
+		if pm.frame.worldRooted {
+			tf, err := pm.frame.fss.Transform(request.StartConfiguration, alternate, referenceframe.World)
+			if err != nil {
+				return nil, err
+			}
+			alternatePos = tf.(*referenceframe.PoseInFrame).Pose()
+		}
+		goalSet = append(goalSet, alternatePos)
 	}
 
 	var goals []spatialmath.Pose
@@ -113,16 +197,24 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 		subWaypoints = true
 	}
 
-	// If we are seeding off of a pre-existing plan, we don't need the speedup of subwaypoints
-	if seedPlan != nil {
+	// If we are seeding off of a pre-existing plan, we don't need the speedup of subwaypoints. A goal configuration is reached
+	// directly, as sub-waypoints would have to be solved for with IK.
+	if seedPlan != nil || pm.goalConfiguration != nil {
 		subWaypoints = false
 	}
 
+	if subWaypoints && len(goalSet) > 1 {
+		return nil, errors.New("alternate goals are not supported with linear motion")
+	}
+
 	if subWaypoints {
 		pathStepSize, ok := request.Options["path_step_size"].(float64)
 		if !ok {
 			pathStepSize = defaultPathStepSize
 		}
+		if pathStepSize <= 0 {
+			return nil, fmt.Errorf("path_step_size must be positive, got %v", pathStepSize)
+		}
 		numSteps := PathStepCount(startPose, goalPos, pathStepSize)
 
 		from := startPose
@@ -136,6 +228,7 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 				request.StartConfiguration,
 				request.WorldState,
 				request.BoundingRegions,
+				request.Workspace,
 				request.Constraints,
 				request.Options,
 			)
@@ -156,6 +249,7 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 		request.StartConfiguration,
 		request.WorldState,
 		request.BoundingRegions,
+		request.Workspace,
 		request.Constraints,
 		request.Options,
 	)
@@ -164,6 +258,14 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 	}
 	pm.planOpts = opt
 	opt.SetGoal(goalPos)
+	if len(goalSet) > 1 {
+		opt.SetGoals(goalSet)
+	}
+	if pm.goalConfiguration != nil {
+		if err := checkGoalConfiguration(opt, pm.frame, pm.goalConfiguration); err != nil {
+			return nil, err
+		}
+	}
 	opts = append(opts, opt)
 
 	planners := make([]motionPlanner, 0, len(opts))
@@ -186,21 +288,21 @@ func (pm *planManager) PlanSingleWaypoint(ctx context.Context, request *PlanRequ
 	// If we have multiple sub-waypoints, make sure the final goal is not unreachable.
 	if len(goals) > 1 {
 		// Viability check; ensure that the waypoint is not impossible to reach
-		_, err = planners[0].getSolutions(ctx, seed)
+		_, err = planners[len(planners)-1].getSolutions(ctx, seed)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("goal is unreachable: %w", err)
 		}
 	}
 
 	plan, err := pm.planAtomicWaypoints(ctx, goals, seed, planners, seedPlan)
 	pm.activeBackgroundWorkers.Wait()
-	if err != nil {
+	if err != nil && !errors.Is(err, ErrPlanSuboptimal) {
 		if len(goals) > 1 {
 			err = fmt.Errorf("failed to plan path for valid goal: %w", err)
 		}
 		return nil, err
 	}
-	return plan, nil
+	return plan, err
 }
 
 // planAtomicWaypoints will plan a single motion, which may be composed of one or more waypoints. Waypoints are here used to begin planning
@@ -239,7 +341,7 @@ func (pm *planManager) planAtomicWaypoints(
 		// Plan the single waypoint, and accumulate objects which will be used to constrauct the plan after all planning has finished
 		newseed, future, err := pm.planSingleAtomicWaypoint(ctx, goal, seed, pathPlanner, maps)
 		if err != nil {
-			return nil, err
+			return nil, waypointError(err, i, len(goals))
 		}
 		seed = newseed
 		resultPromises = append(resultPromises, future)
@@ -247,15 +349,36 @@ func (pm *planManager) planAtomicWaypoints(
 
 	// All goals have been submitted for solving. Reconstruct in order
 	resultSlices := []node{}
-	for _, future := range resultPromises {
+	suboptimal := false
+	for i, future := range resultPromises {
 		steps, err := future.result()
-		if err != nil {
-			return nil, err
+		if errors.Is(err, ErrPlanSuboptimal) {
+			suboptimal = true
+		} else if err != nil {
+			return nil, waypointError(err, i, len(resultPromises))
 		}
 		resultSlices = append(resultSlices, steps...)
 	}
+	for _, pathPlanner := range planners {
+		pm.addStats(pathPlanner)
+	}
 
-	return newRRTPlan(resultSlices, pm.frame, pm.useTPspace)
+	plan, err := newRRTPlan(resultSlices, pm.frame, pm.useTPspace)
+	if err != nil {
+		return nil, err
+	}
+	if suboptimal {
+		return plan, ErrPlanSuboptimal
+	}
+	return plan, nil
+}
+
+// waypointError annotates an error planning to one of several intermediate waypoints with which waypoint failed.
+func waypointError(err error, index, count int) error {
+	if count <= 1 {
+		return err
+	}
+	return fmt.Errorf("waypoint %d of %d: %w", index+1, count, err)
 }
 
 // planSingleAtomicWaypoint attempts to plan a single waypoint. It may optionally be pre-seeded with rrt maps; these will be passed to the
@@ -289,7 +412,7 @@ func (pm *planManager) planSingleAtomicWaypoint(
 				return nil, nil, planReturn.err
 			}
 			seed := planReturn.steps[len(planReturn.steps)-1].Q()
-			return seed, &resultPromise{steps: planReturn.steps}, nil
+			return seed, &resultPromise{steps: planReturn.steps, suboptimal: planReturn.suboptimal}, nil
 		}
 	} else {
 		// This ctx is used exclusively for the running of the new planner and timing it out. It may be different from the main `ctx`
@@ -297,7 +420,8 @@ func (pm *planManager) planSingleAtomicWaypoint(
 		plannerctx, cancel := context.WithTimeout(ctx, time.Duration(pathPlanner.opt().Timeout*float64(time.Second)))
 		defer cancel()
 		nodes, err := pathPlanner.plan(plannerctx, goal, seed)
-		if err != nil {
+		suboptimal := errors.Is(err, ErrPlanSuboptimal)
+		if err != nil && !suboptimal {
 			return nil, nil, err
 		}
 
@@ -305,7 +429,7 @@ func (pm *planManager) planSingleAtomicWaypoint(
 
 		// Update seed for the next waypoint to be the final configuration of this waypoint
 		seed = smoothedPath[len(smoothedPath)-1].Q()
-		return seed, &resultPromise{steps: smoothedPath}, nil
+		return seed, &resultPromise{steps: smoothedPath, suboptimal: suboptimal}, nil
 	}
 }
 
@@ -322,14 +446,20 @@ func (pm *planManager) planParallelRRTMotion(
 ) {
 	var rrtBackground sync.WaitGroup
 	var err error
-	// If we don't pass in pre-made maps, initialize and seed with IK solutions here
+	// If we don't pass in pre-made maps, initialize and seed with IK solutions here, or with the goal configuration if there is one
 	if !pm.useTPspace && maps == nil {
-		planSeed := initRRTSolutions(ctx, pathPlanner, seed)
+		var planSeed *rrtSolution
+		if pm.goalConfiguration != nil {
+			planSeed = initRRTSolutionsToConfiguration(pathPlanner, seed, pm.goalConfiguration)
+		} else {
+			planSeed = initRRTSolutions(ctx, pathPlanner, seed)
+		}
 		if planSeed.err != nil || planSeed.steps != nil {
 			solutionChan <- planSeed
 			return
 		}
 		maps = planSeed.maps
+		pm.warmStart(ctx, pathPlanner, maps, seed)
 	}
 
 	// publish endpoint of plan if it is known
@@ -396,6 +526,8 @@ func (pm *planManager) planParallelRRTMotion(
 				if ok, score := pm.goodPlan(finalSteps, pm.opt()); ok {
 					pm.logger.CDebugf(ctx, "got path with score %f, close enough to optimal %f", score, maps.optNode.Cost())
 					fallbackPlanner = nil
+					// the first attempt is meant to be cut short, so a path this good is not suboptimal
+					finalSteps.suboptimal = false
 				} else {
 					pm.logger.CDebugf(ctx, "path with score %f not close enough to optimal %f, falling back", score, maps.optNode.Cost())
 
@@ -439,7 +571,8 @@ func (pm *planManager) planParallelRRTMotion(
 		// If we ran a fallback, retrieve the result and compare to the smoothed path
 		if alternateFuture != nil {
 			alternate, err := alternateFuture.result()
-			if err == nil {
+			alternateSuboptimal := errors.Is(err, ErrPlanSuboptimal)
+			if err == nil || alternateSuboptimal {
 				// If the fallback successfully found a path, check if it is better than our smoothed previous path.
 				// The fallback should emerge pre-smoothed, so that should be a non-issue
 				altCost := pm.frame.nodesToTrajectory(alternate).EvaluateCost(pm.opt().ScoreFunc)
@@ -449,14 +582,30 @@ func (pm *planManager) planParallelRRTMotion(
 				} else {
 					pm.logger.CDebugf(ctx, "fallback path with score %f worse than original score %f; using original", altCost, score)
 				}
+				// the first attempt is meant to be cut short, so whichever path is kept is only as suboptimal as the fallback's
+				finalSteps.suboptimal = alternateSuboptimal
 			}
 		}
+		if fallbackPlanner != nil {
+			pm.addStats(fallbackPlanner)
+		}
 
 		solutionChan <- finalSteps
 		return
 
 	case <-ctx.Done():
 		rrtBackground.Wait()
+		// The planner shares this deadline and may have handed back the best path it found before it passed, which is worth keeping
+		// even though it could not be smoothed.
+		select {
+		case finalSteps := <-plannerChan:
+			if finalSteps.err == nil && finalSteps.steps != nil {
+				finalSteps.suboptimal = true
+				solutionChan <- finalSteps
+				return
+			}
+		default:
+		}
 		solutionChan <- &rrtSolution{err: ctx.Err()}
 		return
 	}
@@ -468,6 +617,7 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	seedMap map[string][]referenceframe.Input,
 	worldState *referenceframe.WorldState,
 	boundingRegions []spatialmath.Geometry,
+	workspace spatialmath.Geometry,
 	constraints *Constraints,
 	planningOpts map[string]interface{},
 ) (*plannerOptions, error) {
@@ -479,6 +629,8 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	// Start with normal options
 	opt := newBasicPlannerOptions(pm.frame)
 	opt.extra = planningOpts
+	opt.progress = pm.progress
+	opt.nodeCount = &pm.nodeCount
 	opt.StartPose = from
 
 	collisionBufferMM := defaultCollisionBufferMM
@@ -492,6 +644,10 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 			return nil, errors.New("collision_buffer_mm can't be negative")
 		}
 	}
+	obstacleBuffersMM, err := obstacleBuffersFromOptions(planningOpts)
+	if err != nil {
+		return nil, err
+	}
 
 	// extract inputs corresponding to the frame
 	frameInputs, err := pm.frame.mapToSlice(seedMap)
@@ -543,6 +699,19 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 		return nil, err
 	}
 
+	// links which are adjacent in the kinematic chain of a moving model are allowed to touch
+	movingNames := make(map[string]bool, len(movingRobotGeometries))
+	for _, geometry := range movingRobotGeometries {
+		movingNames[geometry.Label()] = true
+	}
+	for _, f := range pm.frame.frames {
+		for _, adjacent := range adjacentLinkCollisions(f) {
+			if movingNames[adjacent.name1] && movingNames[adjacent.name2] {
+				allowedCollisions = append(allowedCollisions, adjacent)
+			}
+		}
+	}
+
 	// add collision constraints
 	collisionConstraints, err := createAllCollisionConstraints(
 		movingRobotGeometries,
@@ -551,6 +720,7 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 		boundingRegions,
 		allowedCollisions,
 		collisionBufferMM,
+		obstacleBuffersMM,
 	)
 	if err != nil {
 		return nil, err
@@ -558,10 +728,14 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	for name, constraint := range collisionConstraints {
 		opt.AddStateConstraint(name, constraint)
 	}
+	if workspace != nil {
+		opt.AddKeepInConstraint(workspace)
+	}
+	opt.AddHalfSpaceConstraints(pm.halfSpaces)
 
 	hasTopoConstraint := opt.addPbTopoConstraints(from, to, constraints)
 	if hasTopoConstraint {
-		planAlg = "cbirrt"
+		planAlg = CBiRRTPlanningAlg
 	}
 
 	// error handling around extracting motion_profile information from map[string]interface{}
@@ -583,6 +757,99 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	if err != nil {
 		return nil, err
 	}
+	if err := ValidateIKSolver(opt.IKSolver); err != nil {
+		return nil, err
+	}
+	if opt.MaxNodes < 0 {
+		return nil, fmt.Errorf("max_nodes can't be negative, got %d", opt.MaxNodes)
+	}
+	if opt.GoalBias < 0 || opt.GoalBias > 1 {
+		return nil, fmt.Errorf("goal_bias must be between 0 and 1, got %v", opt.GoalBias)
+	}
+	if opt.GoalPositionTolerance < 0 || opt.GoalOrientationTolerance < 0 {
+		return nil, errors.New("goal_position_tolerance_mm and goal_orientation_tolerance_degs can't be negative")
+	}
+	if opt.GoalPositionTolerance > 0 || opt.GoalOrientationTolerance > 0 {
+		linTol, angTol := opt.GoalPositionTolerance, rutils.DegToRad(opt.GoalOrientationTolerance)
+		opt.goalMetricConstructor = func(goal spatialmath.Pose) ik.StateMetric {
+			return ik.NewGoalToleranceMetric(goal, linTol, angTol)
+		}
+	}
+
+	jointWeights, err := frameValuesFromOptions(planningOpts, "joint_weights")
+	if err != nil {
+		return nil, err
+	}
+	// the inputs of prismatic joints are in mm rather than radians, so they are scaled to be measured alongside angles
+	prismaticWeights, hasPrismatic := pm.frame.prismaticInputWeights()
+	if jointWeights != nil {
+		if pm.useTPspace {
+			return nil, errors.New("joint_weights cannot be used when planning for a TP-space frame")
+		}
+		weights, err := pm.frame.inputWeights(jointWeights)
+		if err != nil {
+			return nil, err
+		}
+		for i := range weights {
+			weights[i] *= prismaticWeights[i]
+		}
+		opt.DistanceFunc = ik.NewWeightedL2InputMetric(weights)
+		opt.ScoreFunc = opt.DistanceFunc
+	} else if hasPrismatic && !pm.useTPspace {
+		opt.DistanceFunc = ik.NewWeightedL2InputMetric(prismaticWeights)
+		opt.ScoreFunc = opt.DistanceFunc
+	}
+	switch opt.CostMetric {
+	case "", PathLengthCostMetric:
+	case TimeCostMetric:
+		if pm.useTPspace {
+			return nil, errors.New("the time cost_metric cannot be used when planning for a TP-space frame")
+		}
+		if jointWeights != nil {
+			return nil, errors.New("joint_weights cannot be used with the time cost_metric")
+		}
+		velocityLimits, err := frameValuesFromOptions(planningOpts, "joint_velocity_limits")
+		if err != nil {
+			return nil, err
+		}
+		if velocityLimits == nil {
+			return nil, errors.New("the time cost_metric requires joint_velocity_limits to be set")
+		}
+		limits, err := pm.frame.inputVelocityLimits(velocityLimits)
+		if err != nil {
+			return nil, err
+		}
+		opt.DistanceFunc = ik.NewTimeInputMetric(limits)
+		opt.ScoreFunc = opt.DistanceFunc
+	default:
+		return nil, fmt.Errorf("unknown cost_metric %q, must be one of %q or %q", opt.CostMetric, PathLengthCostMetric, TimeCostMetric)
+	}
+	if opt.MinManipulability < 0 {
+		return nil, fmt.Errorf("min_manipulability can't be negative, got %v", opt.MinManipulability)
+	}
+	if opt.MinManipulability > 0 {
+		if pm.useTPspace {
+			return nil, errors.New("min_manipulability cannot be used when planning for a TP-space frame")
+		}
+		seed, err := pm.frame.mapToSlice(seedMap)
+		if err != nil {
+			return nil, err
+		}
+		// make sure the manipulability of every moving model can be computed before planning with it
+		if _, err := minFrameManipulability(pm.frame, seed); err != nil {
+			return nil, fmt.Errorf("cannot use min_manipulability: %w", err)
+		}
+		opt.AddStateConstraint(defaultManipulabilityConstraintDesc, NewManipulabilityConstraint(opt.MinManipulability))
+	}
+	if opt.MinHeight != nil {
+		if pm.useTPspace {
+			return nil, errors.New("min_height_mm cannot be used when planning for a TP-space frame")
+		}
+		if to.Point().Z < *opt.MinHeight {
+			return nil, fmt.Errorf("goal height %v is below min_height_mm %v", to.Point().Z, *opt.MinHeight)
+		}
+		opt.AddStateConstraint(defaultMinHeightConstraintDesc, NewMinHeightConstraint(*opt.MinHeight))
+	}
 
 	alg, ok := planningOpts["planning_alg"]
 	if ok {
@@ -593,17 +860,16 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 		if pm.useTPspace && planAlg != "" {
 			return nil, fmt.Errorf("cannot specify a planning_alg when planning for a TP-space frame. alg specified was %s", planAlg)
 		}
-		switch planAlg {
-		// TODO(pl): make these consts
-		case "cbirrt":
-			opt.PlannerConstructor = newCBiRRTMotionPlanner
-		case "rrtstar":
+		if planAlg != "" {
+			opt.PlannerConstructor, err = plannerConstructorFromName(planAlg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if planAlg == RRTStarPlanningAlg {
 			// no motion profiles for RRT*
-			opt.PlannerConstructor = newRRTStarConnectMotionPlanner
 			// TODO(pl): more logic for RRT*?
 			return opt, nil
-		default:
-			// use default, already set
 		}
 	}
 	if pm.useTPspace {
@@ -660,6 +926,12 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 		opt.profile = PositionOnlyMotionProfile
 		if !pm.useTPspace || opt.PositionSeeds <= 0 {
 			opt.goalMetricConstructor = ik.NewPositionOnlyMetric
+			if opt.GoalPositionTolerance > 0 {
+				linTol := opt.GoalPositionTolerance
+				opt.goalMetricConstructor = func(goal spatialmath.Pose) ik.StateMetric {
+					return ik.NewGoalToleranceMetric(goal, linTol, math.Inf(1))
+				}
+			}
 		}
 	case FreeMotionProfile:
 		// No restrictions on motion
@@ -673,8 +945,8 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 
 			// time to run the first planning attempt before falling back
 			try1["timeout"] = defaultFallbackTimeout
-			try1["planning_alg"] = "rrtstar"
-			try1Opt, err := pm.plannerSetupFromMoveRequest(from, to, seedMap, worldState, boundingRegions, constraints, try1)
+			try1["planning_alg"] = RRTStarPlanningAlg
+			try1Opt, err := pm.plannerSetupFromMoveRequest(from, to, seedMap, worldState, boundingRegions, workspace, constraints, try1)
 			if err != nil {
 				return nil, err
 			}
@@ -686,6 +958,63 @@ func (pm *planManager) plannerSetupFromMoveRequest(
 	return opt, nil
 }
 
+// obstacleBuffersFromOptions reads the "obstacle_buffers_mm" option, which maps obstacle labels to the collision buffer to keep from
+// each of them in place of collision_buffer_mm.
+func obstacleBuffersFromOptions(planningOpts map[string]interface{}) (map[string]float64, error) {
+	switch raw := planningOpts["obstacle_buffers_mm"].(type) {
+	case nil:
+		return nil, nil
+	case map[string]float64:
+		return raw, nil
+	case map[string]interface{}:
+		buffers := make(map[string]float64, len(raw))
+		for label, buffer := range raw {
+			value, ok := buffer.(float64)
+			if !ok {
+				return nil, fmt.Errorf("could not interpret obstacle_buffers_mm value for %q as float64", label)
+			}
+			buffers[label] = value
+		}
+		return buffers, nil
+	default:
+		return nil, errors.New("could not interpret obstacle_buffers_mm field as a map of obstacle labels to float64")
+	}
+}
+
+// frameValuesFromOptions reads a planning option which maps frame names to a value for each of the frame's inputs, such as
+// joint_weights, where heavier weighted inputs count for more when measuring how far the frames move so the planner prefers to move
+// them less, or joint_velocity_limits.
+func frameValuesFromOptions(planningOpts map[string]interface{}, key string) (map[string][]float64, error) {
+	switch raw := planningOpts[key].(type) {
+	case nil:
+		return nil, nil
+	case map[string][]float64:
+		return raw, nil
+	case map[string]interface{}:
+		frameValues := make(map[string][]float64, len(raw))
+		for name, rawValues := range raw {
+			switch values := rawValues.(type) {
+			case []float64:
+				frameValues[name] = values
+			case []interface{}:
+				frameValues[name] = make([]float64, 0, len(values))
+				for _, rawValue := range values {
+					value, ok := rawValue.(float64)
+					if !ok {
+						return nil, fmt.Errorf("could not interpret %s value for %q as a list of float64", key, name)
+					}
+					frameValues[name] = append(frameValues[name], value)
+				}
+			default:
+				return nil, fmt.Errorf("could not interpret %s value for %q as a list of float64", key, name)
+			}
+		}
+		return frameValues, nil
+	default:
+		return nil, fmt.Errorf("could not interpret %s field as a map of frame names to lists of float64", key)
+	}
+}
+
 // check whether the solution is within some amount of the optimal.
 func (pm *planManager) goodPlan(pr *rrtSolution, opt *plannerOptions) (bool, float64) {
 	solutionCost := math.Inf(1)
@@ -754,6 +1083,78 @@ func (pm *planManager) planToRRTGoalMap(plan Plan, goal spatialmath.Pose) (*rrtM
 	return maps, nil
 }
 
+// priorPathFromRequest returns the configurations of the solver frame along the prior plan of the request, or nil if it has none.
+func (pm *planManager) priorPathFromRequest(request *PlanRequest) ([][]referenceframe.Input, error) {
+	if request.PriorPlan == nil {
+		return nil, nil
+	}
+	traj := request.PriorPlan.Trajectory()
+	path := make([][]referenceframe.Input, 0, len(traj))
+	for _, step := range traj {
+		config, err := pm.frame.mapToSlice(step)
+		if err != nil {
+			return nil, fmt.Errorf("cannot use prior plan: %w", err)
+		}
+		path = append(path, config)
+	}
+	return path, nil
+}
+
+// warmStart grows the start map from the seed along the prior path, so that the search continues from a path which has already been
+// found. The prior path is followed from whichever of its ends is nearer the seed, so that the prior plan of a motion repeated back
+// and forth can be either leg. Each waypoint is only added if the motion to it from the last one added is valid, as obstacles may
+// have moved since the prior plan was made, and the rest of the path is dropped at the first which is not. If the whole path is
+// followed and it ends at the goal, its end is added to the goal map too, so that the two maps connect right away.
+func (pm *planManager) warmStart(ctx context.Context, pathPlanner motionPlanner, maps *rrtMaps, seed []referenceframe.Input) {
+	if len(pm.priorPath) == 0 {
+		return
+	}
+	path := pm.priorPath
+	distance := func(from, to []referenceframe.Input) float64 {
+		return pathPlanner.opt().DistanceFunc(&ik.Segment{StartConfiguration: from, EndConfiguration: to})
+	}
+	if distance(seed, path[len(path)-1]) < distance(seed, path[0]) {
+		path = make([][]referenceframe.Input, 0, len(pm.priorPath))
+		for i := len(pm.priorPath) - 1; i >= 0; i-- {
+			path = append(path, pm.priorPath[i])
+		}
+	}
+
+	var last node
+	for n, parent := range maps.startMap {
+		if parent == nil {
+			last = n
+		}
+	}
+	added := 0
+	for _, q := range path {
+		cost := distance(last.Q(), q)
+		if cost < defaultJointSolveDist {
+			continue
+		}
+		if !pathPlanner.checkPath(last.Q(), q) || !pathPlanner.reserveNode() {
+			pm.logger.CDebugf(ctx, "warm started from %d of %d waypoints of the prior plan", added, len(path))
+			return
+		}
+		n := &basicNode{q: q, cost: last.Cost() + cost}
+		maps.startMap[n] = last
+		last = n
+		added++
+	}
+	pm.logger.CDebugf(ctx, "warm started from all %d waypoints of the prior plan", len(path))
+
+	if pm.goalConfiguration != nil || pathPlanner.opt().goalMetric == nil {
+		return
+	}
+	state := &ik.State{Configuration: last.Q(), Frame: pm.frame}
+	if err := resolveStatesToPositions(state); err != nil {
+		return
+	}
+	if pathPlanner.opt().goalMetric(state) <= pathPlanner.opt().GoalThreshold && pathPlanner.reserveNode() {
+		maps.goalMap[&basicNode{q: last.Q(), cost: 0}] = nil
+	}
+}
+
 // planRelativeWaypoint will solve the solver frame to one individual pose. This is used for solverframes whose inputs are relative, that
 // is, the pose returned by `Transform` is a transformation rather than an absolute position.
 func (pm *planManager) planRelativeWaypoint(ctx context.Context, request *PlanRequest, seedPlan Plan) (Plan, error) {
@@ -803,7 +1204,8 @@ func (pm *planManager) planRelativeWaypoint(ctx context.Context, request *PlanRe
 	}
 	goalPos := tf.(*referenceframe.PoseInFrame).Pose()
 	opt, err := pm.plannerSetupFromMoveRequest(
-		startPose, goalPos, request.StartConfiguration, request.WorldState, request.BoundingRegions, request.Constraints, request.Options,
+		startPose, goalPos, request.StartConfiguration, request.WorldState, request.BoundingRegions, request.Workspace, request.Constraints,
+		request.Options,
 	)
 	if err != nil {
 		return nil, err
@@ -851,10 +1253,244 @@ func (pm *planManager) planRelativeWaypoint(ctx context.Context, request *PlanRe
 	if err != nil {
 		return nil, err
 	}
+	pm.addStats(pathPlanner)
 
 	return newRRTPlan(steps, pm.frame, pm.useTPspace)
 }
 
+// goalReachable looks for an IK solution to the goals of the request which satisfies the planner's constraints, without planning a path
+// to it. A goal for which none is found before the timeout is reported as unreachable.
+func (pm *planManager) goalReachable(ctx context.Context, request *PlanRequest) (bool, error) {
+	if pm.useTPspace {
+		return false, errors.New("reachability checks are not supported when planning for PTGs")
+	}
+	seed, err := pm.frame.mapToSlice(request.StartConfiguration)
+	if err != nil {
+		return false, err
+	}
+	startPose, err := pm.frame.Transform(seed)
+	if err != nil {
+		return false, err
+	}
+	goalConfiguration, err := pm.goalConfigurationFromRequest(request)
+	if err != nil {
+		return false, err
+	}
+	if goalConfiguration != nil {
+		goalPos, err := pm.frame.Transform(goalConfiguration)
+		if err != nil {
+			return false, err
+		}
+		opt, err := pm.plannerSetupFromMoveRequest(
+			startPose, goalPos, request.StartConfiguration, request.WorldState, request.BoundingRegions, request.Workspace,
+			request.Constraints, request.Options,
+		)
+		if err != nil {
+			return false, err
+		}
+		// no IK is needed, so the goal is reachable as long as it satisfies the constraints
+		if err := checkGoalConfiguration(opt, pm.frame, goalConfiguration); err != nil {
+			request.Logger.CDebugf(ctx, "goal is not reachable: %v", err)
+			return false, nil
+		}
+		return true, nil
+	}
+	goals := make([]spatialmath.Pose, 0, len(request.AlternateGoals)+1)
+	for _, goal := range append([]*referenceframe.PoseInFrame{request.Goal}, request.AlternateGoals...) {
+		goalPos := goal.Pose()
+		if pm.frame.worldRooted {
+			tf, err := pm.frame.fss.Transform(request.StartConfiguration, goal, referenceframe.World)
+			if err != nil {
+				return false, err
+			}
+			goalPos = tf.(*referenceframe.PoseInFrame).Pose()
+		}
+		goals = append(goals, goalPos)
+	}
+
+	opt, err := pm.plannerSetupFromMoveRequest(
+		startPose, goals[0], request.StartConfiguration, request.WorldState, request.BoundingRegions, request.Workspace, request.Constraints,
+		request.Options,
+	)
+	if err != nil {
+		return false, err
+	}
+	opt.SetGoal(goals[0])
+	if len(goals) > 1 {
+		opt.SetGoals(goals)
+	}
+	// A single solution is enough to know the goal is reachable
+	opt.MaxSolutions = 1
+	//nolint: gosec
+	pathPlanner, err := opt.PlannerConstructor(pm.frame, rand.New(rand.NewSource(int64(pm.randseed.Int()))), pm.logger, opt)
+	if err != nil {
+		return false, err
+	}
+
+	timeout, ok := request.Options["timeout"].(float64)
+	if !ok {
+		timeout = defaultReachableTimeout
+	}
+	solveCtx, cancel := context.WithTimeout(ctx, time.Duration(timeout*float64(time.Second)))
+	defer cancel()
+	_, err = pathPlanner.getSolutions(solveCtx, seed)
+	switch {
+	case err == nil:
+		return true, nil
+	case ctx.Err() != nil:
+		return false, ctx.Err()
+	case errors.Is(err, errIKSolve), errors.Is(err, context.DeadlineExceeded), strings.HasPrefix(err.Error(), errIKConstraint):
+		request.Logger.CDebugf(ctx, "goal is not reachable: %v", err)
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// goalConfigurationFromRequest returns the configuration of the solver frame at the goal configuration of the request, or nil if the
+// request has a pose goal. Frames other than the request's frame are left at their start configuration.
+func (pm *planManager) goalConfigurationFromRequest(request *PlanRequest) ([]referenceframe.Input, error) {
+	if request.GoalConfiguration == nil {
+		return nil, nil
+	}
+	goalMap := make(map[string][]referenceframe.Input, len(request.StartConfiguration)+1)
+	for name, inputs := range request.StartConfiguration {
+		goalMap[name] = inputs
+	}
+	goalMap[request.Frame.Name()] = request.GoalConfiguration
+	return pm.frame.mapToSlice(goalMap)
+}
+
+// checkGoalConfiguration returns an error if the goal configuration violates any of the constraints, so that an impossible goal is
+// rejected up front in the same way as a goal pose with no valid IK solution.
+func checkGoalConfiguration(opt *plannerOptions, sf *solverFrame, goal []referenceframe.Input) error {
+	state := &ik.State{Configuration: goal, Frame: sf}
+	if err := resolveStatesToPositions(state); err != nil {
+		return err
+	}
+	if ok, failure := opt.CheckStateConstraints(state); !ok {
+		return fmt.Errorf("goal configuration violates constraint %s", failure)
+	}
+	return nil
+}
+
+// repairPlan replans the stretch of the plan which violates the constraints of the request, between the last valid waypoint before
+// it and the first valid waypoint after it, and checks the spliced plan end to end. The plan is returned unchanged if it is valid.
+func (pm *planManager) repairPlan(ctx context.Context, request *PlanRequest, previous Plan) (Plan, error) {
+	if pm.useTPspace {
+		return nil, errors.New("plans for PTGs cannot be repaired")
+	}
+	traj := previous.Trajectory()
+	configs := make([][]referenceframe.Input, 0, len(traj))
+	for _, step := range traj {
+		config, err := pm.frame.mapToSlice(step)
+		if err != nil {
+			return nil, err
+		}
+		configs = append(configs, config)
+	}
+	startPose, err := pm.frame.Transform(configs[0])
+	if err != nil {
+		return nil, err
+	}
+	goalPose, err := pm.frame.Transform(configs[len(configs)-1])
+	if err != nil {
+		return nil, err
+	}
+	opt, err := pm.plannerSetupFromMoveRequest(
+		startPose, goalPose, traj[0], request.WorldState, request.BoundingRegions, request.Workspace, request.Constraints,
+		request.Options,
+	)
+	if err != nil {
+		return nil, err
+	}
+	segmentValid := func(from, to []referenceframe.Input) bool {
+		valid, _ := opt.CheckSegmentAndStateValidity(
+			&ik.Segment{StartConfiguration: from, EndConfiguration: to, Frame: pm.frame},
+			opt.Resolution,
+		)
+		return valid
+	}
+
+	first, last := -1, -1
+	for i := 0; i < len(configs)-1; i++ {
+		if !segmentValid(configs[i], configs[i+1]) {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	if first < 0 {
+		return previous, nil
+	}
+
+	// The bridge lands exactly on the first valid waypoint after the invalid stretch, so that the rest of the plan follows on from it
+	// without a jump. If the plan's final waypoint is itself no longer valid, the bridge is planned to its pose instead and ends the
+	// plan there.
+	for last+1 < len(configs)-1 && checkGoalConfiguration(opt, pm.frame, configs[last+1]) != nil {
+		last++
+	}
+	endsPlan := last+1 == len(configs)-1 && checkGoalConfiguration(opt, pm.frame, configs[last+1]) != nil
+
+	path := previous.Path()
+	if len(path) != len(traj) {
+		return nil, errors.New("plan path and trajectory are of different lengths")
+	}
+	bridgeStart, ok := path[first][request.Frame.Name()]
+	if !ok {
+		return nil, fmt.Errorf("frame named %s not found in plan path", request.Frame.Name())
+	}
+	bridgeRequest := *request
+	bridgeRequest.Goal = nil
+	bridgeRequest.GoalConfiguration = nil
+	if endsPlan {
+		bridgeRequest.Goal, ok = path[last+1][request.Frame.Name()]
+	} else {
+		bridgeRequest.GoalConfiguration, ok = traj[last+1][request.Frame.Name()]
+	}
+	if !ok {
+		return nil, fmt.Errorf("frame named %s not found in plan path", request.Frame.Name())
+	}
+	bridgeRequest.AlternateGoals = nil
+	bridgeRequest.StartPose = bridgeStart.Pose()
+	bridgeRequest.StartConfiguration = traj[first]
+	bridgeRequest.Progress = nil
+	bridge, bridgeErr := PlanMotion(ctx, &bridgeRequest)
+	if bridgeErr != nil && !errors.Is(bridgeErr, ErrPlanSuboptimal) {
+		return nil, bridgeErr
+	}
+
+	repaired := make([]node, 0, len(configs)+len(bridge.Trajectory()))
+	for _, config := range configs[:first] {
+		repaired = append(repaired, newConfigurationNode(config))
+	}
+	for _, step := range bridge.Trajectory() {
+		config, err := pm.frame.mapToSlice(step)
+		if err != nil {
+			return nil, err
+		}
+		repaired = append(repaired, newConfigurationNode(config))
+	}
+	if !endsPlan {
+		// the bridge already ends on configs[last+1]
+		for _, config := range configs[last+2:] {
+			repaired = append(repaired, newConfigurationNode(config))
+		}
+	}
+	for i := 0; i < len(repaired)-1; i++ {
+		if !segmentValid(repaired[i].Q(), repaired[i+1].Q()) {
+			return nil, fmt.Errorf("repaired plan is invalid between waypoints %d and %d", i, i+1)
+		}
+	}
+	plan, err := newRRTPlan(repaired, pm.frame, false)
+	if err != nil {
+		return nil, err
+	}
+	// a bridge that was cut short leaves the repaired plan suboptimal too
+	return plan, bridgeErr
+}
+
 // Copy any atomic values.
 func deepAtomicCopyMap(opt map[string]interface{}) map[string]interface{} {
 	optCopy := map[string]interface{}{}
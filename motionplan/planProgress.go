@@ -0,0 +1,86 @@
+package motionplan
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultProgressInterval is how often PlanRequest.Progress is called if no ProgressInterval is set.
+const defaultProgressInterval = 500 * time.Millisecond
+
+// PlanProgress is a snapshot of a plan which is still being solved, passed to PlanRequest.Progress.
+type PlanProgress struct {
+	// Iterations is the number of iterations run by the planners so far, including any fallbacks.
+	Iterations int
+	// BestPath is the best path found so far for the waypoint currently being planned, or nil if none has been found yet.
+	BestPath Trajectory
+}
+
+// progressTracker collects the progress of the planners working on a request so that it can be reported while they run.
+// It is safe to call its methods on a nil tracker, in which case they do nothing.
+type progressTracker struct {
+	iterations atomic.Int64
+	bestPath   atomic.Pointer[[]node]
+}
+
+func (pt *progressTracker) addIteration() {
+	if pt == nil {
+		return
+	}
+	pt.iterations.Add(1)
+}
+
+func (pt *progressTracker) setBestPath(path []node) {
+	if pt == nil || len(path) == 0 {
+		return
+	}
+	pt.bestPath.Store(&path)
+}
+
+func (pt *progressTracker) snapshot(sf *solverFrame) PlanProgress {
+	progress := PlanProgress{Iterations: int(pt.iterations.Load())}
+	if path := pt.bestPath.Load(); path != nil {
+		progress.BestPath = sf.nodesToTrajectory(*path)
+	}
+	return progress
+}
+
+// report calls progress with a snapshot of the tracker once every interval until ctx is done. The returned function stops
+// reporting and waits for any call in flight to return, so progress is never called after it has returned.
+func (pt *progressTracker) report(ctx context.Context, sf *solverFrame, interval time.Duration, progress func(PlanProgress)) func() {
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			// Check again so that a tick racing with a stop does not produce a late call.
+			select {
+			case <-ctx.Done():
+				return
+			case <-done:
+				return
+			default:
+			}
+			progress(pt.snapshot(sf))
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
@@ -49,6 +50,55 @@ func TestEvaluateTrajectory(t *testing.T) {
 	test.That(t, score, test.ShouldAlmostEqual, 18)
 }
 
+func TestPathJointDistance(t *testing.T) {
+	traj := Trajectory{
+		map[string][]referenceframe.Input{"arm": {{0.}, {0.}}, "gantry": {{0.}}},
+		map[string][]referenceframe.Input{"arm": {{3.}, {4.}}, "gantry": {{0.}}},
+		map[string][]referenceframe.Input{"arm": {{3.}, {4.}}, "gantry": {{12.}}},
+	}
+	dist, err := PathJointDistance(traj, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dist, test.ShouldAlmostEqual, 17)
+
+	// the distance matches the metric the planner uses with the same weights
+	weights := map[string][]float64{"arm": {4, 1}}
+	dist, err = PathJointDistance(traj, weights)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, dist, test.ShouldAlmostEqual, math.Sqrt(52)+12)
+	planned := ik.NewWeightedL2InputMetric([]float64{4, 1, 1})(&ik.Segment{
+		StartConfiguration: []referenceframe.Input{{0.}, {0.}, {0.}},
+		EndConfiguration:   []referenceframe.Input{{3.}, {4.}, {0.}},
+	})
+	test.That(t, planned, test.ShouldAlmostEqual, math.Sqrt(52))
+
+	_, err = PathJointDistance(traj, map[string][]float64{"arm": {1}})
+	test.That(t, err, test.ShouldBeError, errors.New("frame arm has 2 inputs but 1 joint weights were given"))
+	_, err = PathJointDistance(traj, map[string][]float64{"gantry": {-1}})
+	test.That(t, err, test.ShouldBeError, errors.New("joint weights for frame gantry cannot be negative"))
+}
+
+func TestEstimateDuration(t *testing.T) {
+	traj := Trajectory{
+		map[string][]referenceframe.Input{"arm": {{0.}, {0.}}, "gantry": {{0.}}},
+		map[string][]referenceframe.Input{"arm": {{1.}, {-4.}}, "gantry": {{100.}}},
+		map[string][]referenceframe.Input{"arm": {{1.}, {-4.}}, "gantry": {{150.}}},
+	}
+	// the slowest input sets how long each step takes
+	maxVelocities := map[string][]float64{"arm": {1, 2}, "gantry": {50}}
+	duration, err := EstimateDuration(traj, maxVelocities)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, duration, test.ShouldEqual, 3*time.Second)
+
+	_, err = EstimateDuration(traj, map[string][]float64{"arm": {1, 2}})
+	test.That(t, err, test.ShouldBeError, errors.New("no max velocities were given for frame gantry"))
+	_, err = EstimateDuration(traj, map[string][]float64{"arm": {1, 0}, "gantry": {50}})
+	test.That(t, err, test.ShouldBeError, errors.New("max velocity of input 1 of frame arm must be positive, got 0"))
+
+	duration, err = EstimateDuration(traj[:1], nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, duration, test.ShouldEqual, 0)
+}
+
 func TestPlanStep(t *testing.T) {
 	baseNameA := "my-base1"
 	baseNameB := "my-base2"
@@ -3,7 +3,10 @@
 package motionplan
 
 import (
+	"fmt"
+	"math"
 	"runtime"
+	"sync/atomic"
 
 	"go.viam.com/rdk/motionplan/ik"
 	"go.viam.com/rdk/motionplan/tpspace"
@@ -55,13 +58,18 @@ const (
 	defaultRandomSeed = 0
 
 	// descriptions of constraints.
-	defaultLinearConstraintDesc         = "Constraint to follow linear path"
-	defaultPseudolinearConstraintDesc   = "Constraint to follow pseudolinear path, with tolerance scaled to path length"
-	defaultOrientationConstraintDesc    = "Constraint to maintain orientation within bounds"
-	defaultBoundingRegionConstraintDesc = "Constraint to maintain position within bounds"
-	defaultObstacleConstraintDesc       = "Collision between the robot and an obstacle"
-	defaultSelfCollisionConstraintDesc  = "Collision between two robot components that are moving"
-	defaultRobotCollisionConstraintDesc = "Collision between a robot component that is moving and one that is stationary"
+	defaultLinearConstraintDesc           = "Constraint to follow linear path"
+	defaultPseudolinearConstraintDesc     = "Constraint to follow pseudolinear path, with tolerance scaled to path length"
+	defaultOrientationConstraintDesc      = "Constraint to maintain orientation within bounds"
+	defaultFixedOrientationConstraintDesc = "Constraint to hold orientation within bounds of a fixed target"
+	defaultBoundingRegionConstraintDesc   = "Constraint to maintain position within bounds"
+	defaultKeepInConstraintDesc           = "Constraint to keep the robot entirely inside the workspace"
+	defaultHalfSpaceConstraintDesc        = "Constraint to keep the robot entirely on one side of a plane"
+	defaultManipulabilityConstraintDesc   = "Constraint to keep the robot away from kinematic singularities"
+	defaultMinHeightConstraintDesc        = "Constraint to keep the end of the robot above a minimum height"
+	defaultObstacleConstraintDesc         = "Collision between the robot and an obstacle"
+	defaultSelfCollisionConstraintDesc    = "Collision between two robot components that are moving"
+	defaultRobotCollisionConstraintDesc   = "Collision between a robot component that is moving and one that is stationary"
 
 	// When breaking down a path into smaller waypoints, add a waypoint every this many mm of movement.
 	defaultPathStepSize = 10
@@ -72,6 +80,41 @@ const (
 
 var defaultNumThreads = runtime.NumCPU() / 2
 
+// workerCount returns the number of worker goroutines to use for a requested number of threads. Zero or less means one per
+// CPU, and requests for more threads than there are CPUs are capped at the CPU count, since more would only oversubscribe them.
+func workerCount(numThreads int) int {
+	if nCPU := runtime.NumCPU(); numThreads <= 0 || numThreads > nCPU {
+		return nCPU
+	}
+	return numThreads
+}
+
+// The set of supported IK solvers.
+const (
+	// NloptIKSolver runs a single nlopt gradient descent solver.
+	NloptIKSolver = "nlopt"
+	// CombinedIKSolver runs one nlopt solver per thread in parallel, each with a different random seed.
+	CombinedIKSolver = "combined"
+)
+
+// ValidateIKSolver returns an error if the given name is not a supported IK solver. An empty name selects the default solver.
+func ValidateIKSolver(name string) error {
+	switch name {
+	case "", NloptIKSolver, CombinedIKSolver:
+		return nil
+	default:
+		return fmt.Errorf("unknown ik solver %q, must be one of %q or %q", name, NloptIKSolver, CombinedIKSolver)
+	}
+}
+
+// The set of supported plan cost metrics.
+const (
+	// PathLengthCostMetric measures plans by how far their inputs move. It is the default.
+	PathLengthCostMetric = "path_length"
+	// TimeCostMetric measures plans by how long they take to execute when every input moves at its velocity limit.
+	TimeCostMetric = "time"
+)
+
 // TODO: Make this an enum
 // the set of supported motion profiles.
 const (
@@ -118,6 +161,7 @@ func newBasicPlannerOptions(frame referenceframe.Frame) *plannerOptions {
 	opt.SmoothIter = defaultSmoothIter
 
 	opt.NumThreads = defaultNumThreads
+	opt.nodeCount = &atomic.Int64{}
 
 	return opt
 }
@@ -145,18 +189,25 @@ type plannerOptions struct {
 	// Percentage interval of max iterations after which to print debug logs
 	LoggingInterval float64 `json:"logging_interval"`
 
-	// Number of seconds before terminating planner
+	// Number of seconds before terminating planner. Planners that have already connected start and goal when this
+	// deadline passes return the best path found so far rather than failing.
 	Timeout float64 `json:"timeout"`
 
 	// Number of times to try to smooth the path
 	SmoothIter int `json:"smooth_iter"`
 
-	// Number of cpu cores to use
+	// Number of cpu cores to use. Zero means all of them, and more than the number of cores is capped at that number.
 	NumThreads int `json:"num_threads"`
 
 	// How close to get to the goal
 	GoalThreshold float64 `json:"goal_threshold"`
 
+	// If set, the goal is reached by any pose within this many mm of it, rather than only by the goal itself.
+	GoalPositionTolerance float64 `json:"goal_position_tolerance_mm"`
+
+	// If set, the goal is reached by any pose within this many degrees of its orientation, rather than only by its orientation.
+	GoalOrientationTolerance float64 `json:"goal_orientation_tolerance_degs"`
+
 	// Number of planner iterations before giving up.
 	PlanIter int `json:"plan_iter"`
 
@@ -169,9 +220,44 @@ type plannerOptions struct {
 	// Number of iterations to mrun before beginning to accept randomly seeded locations.
 	IterBeforeRand int `json:"iter_before_rand"`
 
+	// Probability, between 0 and 1, that each sample of the bidirectional RRT planners is the best goal configuration rather than a
+	// random one. Biasing sampling toward the goal speeds up planning in uncluttered spaces. TP-space planning is not affected.
+	GoalBias float64 `json:"goal_bias"`
+
+	// If set, configurations in which the manipulability of a moving model falls below this are rejected, so that plans keep away
+	// from kinematic singularities. See Manipulability.
+	MinManipulability float64 `json:"min_manipulability"`
+
+	// If set, configurations which put the end of the frame, such as the tip of a tool, below this height in mm are rejected, so
+	// that it never dips below it during the motion. Only the end of the frame is checked; see PlanRequest.HalfSpaces to keep
+	// whole geometries above a plane.
+	MinHeight *float64 `json:"min_height_mm"`
+
+	// If set, the most nodes the search trees of the joint space planners may hold in total, counted across every planner run for
+	// a request, including those run in parallel. Planning stops once it is reached, returning the best path found so far if
+	// RRT* has found one, and ErrNodeLimitReached otherwise. This bounds the memory planning uses on constrained devices.
+	MaxNodes int `json:"max_nodes"`
+
+	// nodeCount counts the nodes added toward MaxNodes. It is shared by every planner run for a request.
+	nodeCount *atomic.Int64
+
+	// What plans are measured by when choosing between them, one of PathLengthCostMetric or TimeCostMetric. Defaults to
+	// PathLengthCostMetric. TimeCostMetric needs a velocity limit for every input of the moving frames, which is given by the
+	// joint_velocity_limits planning option as a map of frame names to a limit for each of the frame's inputs, in units per second.
+	CostMetric string `json:"cost_metric"`
+
 	// Number of seeds to pre-generate for bidirectional position-only solving.
 	PositionSeeds int `json:"position_seeds"`
 
+	// Which IK solver to use, one of NloptIKSolver or CombinedIKSolver. Defaults to CombinedIKSolver.
+	IKSolver string `json:"ik_solver"`
+
+	// Number of iterations each IK solver runs before giving up. If unset, the solver's default is used.
+	IKMaxIterations int `json:"ik_max_iterations"`
+
+	// IK solutions scoring below this are considered to have reached the goal. If unset, the solver's default is used.
+	IKTolerance float64 `json:"ik_tolerance"`
+
 	// This is how far cbirrt will try to extend the map towards a goal per-step. Determined from FrameStep
 	qstep []float64
 
@@ -190,14 +276,39 @@ type plannerOptions struct {
 
 	Fallback *plannerOptions
 
+	// progress, if set, is updated by the planner as it runs so that its progress can be reported before planning returns.
+	progress *progressTracker
+
 	// relativeInputs is a flag that is set by the planning algorithm describing if the solutions it generates are
 	// relative as in each step in the solution builds off a previous one, as opposed to being asolute with respect to some reference frame.
 	relativeInputs bool
 }
 
-// SetMetric sets the distance metric for the solver.
+// SetMetric sets the distance metric for the solver. Any fallback planners are given the same goal.
 func (p *plannerOptions) SetGoal(goal spatialmath.Pose) {
 	p.goalMetric = p.goalMetricConstructor(goal)
+	if p.Fallback != nil {
+		p.Fallback.SetGoal(goal)
+	}
+}
+
+// SetGoals sets the goal metric to converge on whichever of the given goals is closest, so that solving towards any of them succeeds.
+// Any fallback planners are given the same goals.
+func (p *plannerOptions) SetGoals(goals []spatialmath.Pose) {
+	metrics := make([]ik.StateMetric, 0, len(goals))
+	for _, goal := range goals {
+		metrics = append(metrics, p.goalMetricConstructor(goal))
+	}
+	p.goalMetric = func(state *ik.State) float64 {
+		best := math.Inf(1)
+		for _, metric := range metrics {
+			best = math.Min(best, metric(state))
+		}
+		return best
+	}
+	if p.Fallback != nil {
+		p.Fallback.SetGoals(goals)
+	}
 }
 
 // SetPathDist sets the distance metric for the solver to move a constraint-violating point into a valid manifold.
@@ -215,6 +326,37 @@ func (p *plannerOptions) SetMinScore(minScore float64) {
 	p.MinScore = minScore
 }
 
+// AddOrientationConstraint constrains the orientation of the frame being planned for to stay within `tolerance` radians of `target`
+// at every state along the path. The constraint also applies to any fallback planners.
+func (p *plannerOptions) AddOrientationConstraint(target spatialmath.Orientation, tolerance float64) {
+	constraint, pathDist := NewFixedOrientationConstraint(target, tolerance)
+	p.AddStateConstraint(defaultFixedOrientationConstraintDesc, constraint)
+	p.pathMetric = ik.CombineMetrics(p.pathMetric, pathDist)
+	if p.Fallback != nil {
+		p.Fallback.AddOrientationConstraint(target, tolerance)
+	}
+}
+
+// AddKeepInConstraint constrains every geometry of the frame being planned for to stay entirely inside `workspace` at every state
+// along the path. The constraint also applies to any fallback planners.
+func (p *plannerOptions) AddKeepInConstraint(workspace spatialmath.Geometry) {
+	p.AddStateConstraint(defaultKeepInConstraintDesc, NewKeepInConstraint(workspace))
+	if p.Fallback != nil {
+		p.Fallback.AddKeepInConstraint(workspace)
+	}
+}
+
+// AddHalfSpaceConstraints constrains every geometry of the frame being planned for to stay entirely inside each of `halfSpaces` at
+// every state along the path. The constraints also apply to any fallback planners.
+func (p *plannerOptions) AddHalfSpaceConstraints(halfSpaces []*spatialmath.HalfSpace) {
+	for i, halfSpace := range halfSpaces {
+		p.AddStateConstraint(fmt.Sprintf("%s %d", defaultHalfSpaceConstraintDesc, i), NewHalfSpaceConstraint(halfSpace))
+	}
+	if p.Fallback != nil {
+		p.Fallback.AddHalfSpaceConstraints(halfSpaces)
+	}
+}
+
 // addPbConstraints will add all constraints from the protobuf constraint specification. This will deal with only the topological
 // constraints. It will return a bool indicating whether there are any to add.
 func (p *plannerOptions) addPbTopoConstraints(from, to spatialmath.Pose, constraints *Constraints) bool {
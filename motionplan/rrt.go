@@ -43,6 +43,8 @@ type rrtSolution struct {
 	steps []node
 	err   error
 	maps  *rrtMaps
+	// suboptimal is set when planning was cut short and steps is the best path found up to that point.
+	suboptimal bool
 }
 
 type rrtMaps struct {
@@ -113,6 +115,26 @@ func initRRTSolutions(ctx context.Context, mp motionPlanner, seed []referencefra
 	return rrt
 }
 
+// initRRTSolutionsToConfiguration is like initRRTSolutions, but seeds the goal map with the given goal configuration rather than with
+// IK solutions.
+func initRRTSolutionsToConfiguration(mp motionPlanner, seed, goal []referenceframe.Input) *rrtSolution {
+	seedNode := &basicNode{q: seed, cost: 0}
+	goalNode := &basicNode{q: goal, cost: 0}
+	if mp.checkPath(seed, goal) {
+		return &rrtSolution{steps: []node{seedNode, goalNode}}
+	}
+	return &rrtSolution{
+		maps: &rrtMaps{
+			startMap: map[node]node{seedNode: nil},
+			goalMap:  map[node]node{goalNode: nil},
+			optNode: &basicNode{
+				q:    goal,
+				cost: mp.opt().DistanceFunc(&ik.Segment{StartConfiguration: seed, EndConfiguration: goal}),
+			},
+		},
+	}
+}
+
 func shortestPath(maps *rrtMaps, nodePairs []*nodePair) *rrtSolution {
 	if len(nodePairs) == 0 {
 		return &rrtSolution{err: errPlannerFailed, maps: maps}
@@ -264,6 +286,9 @@ type rrtPlan struct {
 	// nodes corresponding to inputs can be cached with the Plan for easy conversion back into a form usable by RRT
 	// depending on how the trajectory is constructed these may be nil and should be computed before usage
 	nodes []node
+
+	// planStats is set by the planManager once planning has finished
+	planStats *PlanStats
 }
 
 func newRRTPlan(solution []node, sf *solverFrame, relative bool) (Plan, error) {
@@ -23,6 +23,9 @@ const (
 	defaultOptimalityThreshold = 1.05
 
 	defaultOptimalityCheckIter = 10
+
+	// How long to wait for the best path found once the planning deadline has passed.
+	bestPathGracePeriod = time.Second
 )
 
 type rrtStarConnectOptions struct {
@@ -83,15 +86,29 @@ func (mp *rrtStarConnectMotionPlanner) plan(ctx context.Context, goal spatialmat
 	utils.PanicCapturingGo(func() {
 		mp.rrtBackgroundRunner(ctx, seed, &rrtParallelPlannerShared{nil, nil, solutionChan})
 	})
+	var solution *rrtSolution
+	var ok bool
 	select {
+	case solution, ok = <-solutionChan:
 	case <-ctx.Done():
-		return nil, ctx.Err()
-	case solution := <-solutionChan:
-		if solution.err != nil {
-			return nil, solution.err
+		// The background runner watches ctx itself so that it can hand back the best path found before the deadline, so it is given a
+		// moment to do so. If it never does, such as after a panic, planning must still end.
+		select {
+		case solution, ok = <-solutionChan:
+		case <-time.After(bestPathGracePeriod):
+			return nil, ctx.Err()
 		}
-		return solution.steps, nil
 	}
+	if !ok {
+		return nil, errPlannerFailed
+	}
+	if solution.err != nil {
+		return nil, solution.err
+	}
+	if solution.suboptimal {
+		return solution.steps, ErrPlanSuboptimal
+	}
+	return solution.steps, nil
 }
 
 // rrtBackgroundRunner will execute the plan. Plan() will call rrtBackgroundRunner in a separate thread and wait for results.
@@ -143,7 +160,9 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 			// stop and return best path
 			if nSolved > 0 {
 				mp.logger.CDebugf(ctx, "RRT* timed out after %d iterations, returning best path", i)
-				rrt.solutionChan <- shortestPath(rrt.maps, shared)
+				solution := shortestPath(rrt.maps, shared)
+				solution.suboptimal = true
+				rrt.solutionChan <- solution
 			} else {
 				mp.logger.CDebugf(ctx, "RRT* timed out after %d iterations, no path found", i)
 				rrt.solutionChan <- &rrtSolution{err: ctx.Err(), maps: rrt.maps}
@@ -151,6 +170,21 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 			return
 		default:
 		}
+		if mp.nodeLimitReached() {
+			// stop and return best path
+			if nSolved > 0 {
+				mp.logger.CDebugf(ctx, "RRT* reached the node limit after %d iterations, returning best path", i)
+				solution := shortestPath(rrt.maps, shared)
+				solution.suboptimal = true
+				rrt.solutionChan <- solution
+			} else {
+				mp.logger.CDebugf(ctx, "RRT* reached the node limit after %d iterations, no path found", i)
+				rrt.solutionChan <- &rrtSolution{err: ErrNodeLimitReached, maps: rrt.maps}
+			}
+			return
+		}
+		mp.iterations.Add(1)
+		mp.planOpts.progress.addIteration()
 
 		tryExtend := func(target node) (node, node, error) {
 			// attempt to extend maps 1 and 2 towards the target
@@ -175,6 +209,10 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 
 		map1reached, map2reached, err := tryExtend(target)
 		if err != nil {
+			if ctx.Err() != nil {
+				// let the check at the top of the loop return the best path found so far
+				continue
+			}
 			rrt.solutionChan <- &rrtSolution{err: err, maps: rrt.maps}
 			return
 		}
@@ -191,6 +229,9 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 			target = newConfigurationNode(targetConf)
 			map1reached, map2reached, err = tryExtend(target)
 			if err != nil {
+				if ctx.Err() != nil {
+					continue
+				}
 				rrt.solutionChan <- &rrtSolution{err: err, maps: rrt.maps}
 				return
 			}
@@ -205,6 +246,7 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 			// Check if we can return
 			if nSolved%defaultOptimalityCheckIter == 0 {
 				solution := shortestPath(rrt.maps, shared)
+				mp.planOpts.progress.setBestPath(solution.steps)
 				// can't use a Trajectory constructor here because can't guarantee its a solverframe being used, so build one manually
 				traj := Trajectory{}
 				for _, step := range solution.steps {
@@ -224,7 +266,7 @@ func (mp *rrtStarConnectMotionPlanner) rrtBackgroundRunner(ctx context.Context,
 		}
 
 		// get next sample, switch map pointers
-		target, err = mp.sample(map1reached, i)
+		target, err = mp.goalBiasedSample(map1reached, i, rrt.maps.optNode)
 		if err != nil {
 			rrt.solutionChan <- &rrtSolution{err: err, maps: rrt.maps}
 			return
@@ -274,6 +316,9 @@ func (mp *rrtStarConnectMotionPlanner) extend(
 			StartConfiguration: oldNear.Q(),
 			EndConfiguration:   near.Q(),
 		})
+		if !mp.reserveNode() {
+			break
+		}
 		near = &basicNode{q: newNear, cost: oldNear.Cost() + extendCost}
 		rrtMap[near] = oldNear
 
@@ -2,6 +2,8 @@ package motionplan
 
 import (
 	"errors"
+	"fmt"
+	"math"
 
 	"go.uber.org/multierr"
 	pb "go.viam.com/api/component/arm/v1"
@@ -321,6 +323,98 @@ func (sf *solverFrame) mapToSlice(inputMap map[string][]frame.Input) ([]frame.In
 	return inputs, nil
 }
 
+// inputWeights returns a weight for each input of the solver frame, in the order of its inputs, from the weights given by frame
+// name. The inputs of frames without weights are weighted 1.
+func (sf *solverFrame) inputWeights(frameWeights map[string][]float64) ([]float64, error) {
+	for name := range frameWeights {
+		if !sf.movingFrame(name) {
+			return nil, fmt.Errorf("joint weights were given for %s, which is not a moving frame of the plan", name)
+		}
+	}
+	var weights []float64
+	for _, f := range sf.frames {
+		dof := len(f.DoF())
+		frameWeight, ok := frameWeights[f.Name()]
+		if !ok {
+			for i := 0; i < dof; i++ {
+				weights = append(weights, 1)
+			}
+			continue
+		}
+		if len(frameWeight) != dof {
+			return nil, fmt.Errorf("frame %s has %d inputs but %d joint weights were given", f.Name(), dof, len(frameWeight))
+		}
+		for _, weight := range frameWeight {
+			if weight < 0 {
+				return nil, fmt.Errorf("joint weights for frame %s cannot be negative", f.Name())
+			}
+		}
+		weights = append(weights, frameWeight...)
+	}
+	return weights, nil
+}
+
+// prismaticInputWeights returns a weight for each input of the solver frame, in the order of its inputs, which scales the inputs of
+// prismatic joints, which are in mm, so that moving one across its whole range counts as far as turning a revolute joint a full
+// circle. Other inputs are weighted 1. It also reports whether the solver frame has any prismatic inputs.
+func (sf *solverFrame) prismaticInputWeights() ([]float64, bool) {
+	var weights []float64
+	hasPrismatic := false
+	for _, f := range sf.frames {
+		prismatic := frame.PrismaticInputs(f)
+		for i, limit := range f.DoF() {
+			if !prismatic[i] {
+				weights = append(weights, 1)
+				continue
+			}
+			hasPrismatic = true
+			l, u := limit.Min, limit.Max
+			// Default to [-999,999] as range if limits are infinite
+			if l == math.Inf(-1) {
+				l = -999
+			}
+			if u == math.Inf(1) {
+				u = 999
+			}
+			scale := 2 * math.Pi / math.Max(u-l, defaultEpsilon)
+			// the metrics weight squared differences
+			weights = append(weights, scale*scale)
+		}
+	}
+	return weights, hasPrismatic
+}
+
+// inputVelocityLimits returns a velocity limit for each input of the solver frame, in the order of its inputs, from the limits given
+// by frame name. Unlike weights there is no sensible default, so every moving frame with inputs must be given limits.
+func (sf *solverFrame) inputVelocityLimits(frameLimits map[string][]float64) ([]float64, error) {
+	for name := range frameLimits {
+		if !sf.movingFrame(name) {
+			return nil, fmt.Errorf("joint velocity limits were given for %s, which is not a moving frame of the plan", name)
+		}
+	}
+	var limits []float64
+	for _, f := range sf.frames {
+		dof := len(f.DoF())
+		if dof == 0 {
+			continue
+		}
+		frameLimit, ok := frameLimits[f.Name()]
+		if !ok {
+			return nil, fmt.Errorf("no joint velocity limits were given for frame %s", f.Name())
+		}
+		if len(frameLimit) != dof {
+			return nil, fmt.Errorf("frame %s has %d inputs but %d joint velocity limits were given", f.Name(), dof, len(frameLimit))
+		}
+		for _, limit := range frameLimit {
+			if limit <= 0 {
+				return nil, fmt.Errorf("joint velocity limits for frame %s must be positive", f.Name())
+			}
+		}
+		limits = append(limits, frameLimit...)
+	}
+	return limits, nil
+}
+
 func (sf *solverFrame) sliceToMap(inputSlice []frame.Input) map[string][]frame.Input {
 	inputs := map[string][]frame.Input{}
 	for k, v := range sf.origSeed {
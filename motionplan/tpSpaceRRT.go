@@ -279,6 +279,7 @@ func (mp *tpSpaceRRTMotionPlanner) rrtBackgroundRunner(
 				}
 			}
 		}
+		mp.planOpts.progress.setBestPath(correctedPath)
 		rrt.solutionChan <- &rrtSolution{steps: correctedPath, maps: rrt.maps}
 	}
 
@@ -306,6 +307,7 @@ func (mp *tpSpaceRRTMotionPlanner) rrtBackgroundRunner(
 			rrt.solutionChan <- &rrtSolution{err: fmt.Errorf("TP Space RRT timeout %w", ctx.Err()), maps: rrt.maps}
 			return
 		}
+		mp.iterations.Add(1)
 		utils.PanicCapturingGo(func() {
 			m1chan <- mp.attemptExtension(ctx, randPosNode, rrt.maps.startMap, false)
 		})
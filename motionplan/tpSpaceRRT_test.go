@@ -142,6 +142,7 @@ func TestPtgWithObstacle(t *testing.T) {
 		worldGeometries.Geometries(),
 		nil, nil,
 		defaultCollisionBufferMM,
+		nil,
 	)
 
 	test.That(t, err, test.ShouldBeNil)
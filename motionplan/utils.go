@@ -83,12 +83,17 @@ func fixOvIncrement(goal, seed spatialmath.Pose) spatialmath.Pose {
 }
 
 type resultPromise struct {
-	steps  []node
-	future chan *rrtSolution
+	steps      []node
+	suboptimal bool
+	future     chan *rrtSolution
 }
 
+// result returns the steps of the solved path. If planning was cut short, they are returned along with ErrPlanSuboptimal.
 func (r *resultPromise) result() ([]node, error) {
 	if r.steps != nil && len(r.steps) > 0 {
+		if r.suboptimal {
+			return r.steps, ErrPlanSuboptimal
+		}
 		return r.steps, nil
 	}
 	// wait for a context cancel or a valid channel result
@@ -96,5 +101,8 @@ func (r *resultPromise) result() ([]node, error) {
 	if planReturn.err != nil {
 		return nil, planReturn.err
 	}
+	if planReturn.suboptimal {
+		return planReturn.steps, ErrPlanSuboptimal
+	}
 	return planReturn.steps, nil
 }
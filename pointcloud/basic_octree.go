@@ -279,6 +279,12 @@ func (octree *BasicOctree) EncompassedBy(geom spatialmath.Geometry) (bool, error
 	return false, errors.New("not implemented")
 }
 
+// Contains returns true if the given point is within the octree.
+// TODO (RSDK-3743): Implement BasicOctree Geometry functions.
+func (octree *BasicOctree) Contains(pt r3.Vector) (bool, error) {
+	return false, errors.New("not implemented")
+}
+
 // SetLabel sets the label of this octree.
 func (octree *BasicOctree) SetLabel(label string) {
 	octree.label = label
@@ -29,6 +29,22 @@ type Limit struct {
 	Max float64
 }
 
+// OutOfBoundsJoints returns, in ascending order, the indices of the inputs which lie outside the limits of the corresponding DoF of
+// the frame. This can be used to check that inputs read from hardware are sane before planning from them.
+func OutOfBoundsJoints(f Frame, inputs []Input) ([]int, error) {
+	limits := f.DoF()
+	if len(inputs) != len(limits) {
+		return nil, NewIncorrectInputLengthError(len(inputs), len(limits))
+	}
+	var oob []int
+	for i, limit := range limits {
+		if inputs[i].Value < limit.Min || inputs[i].Value > limit.Max {
+			oob = append(oob, i)
+		}
+	}
+	return oob, nil
+}
+
 // RestrictedRandomFrameInputs will produce a list of valid, in-bounds inputs for the frame.
 // The range of selection is restricted to `restrictionPercent` percent of the limits, and the
 // selection frame is centered at reference.
@@ -85,6 +101,28 @@ func RandomFrameInputs(m Frame, rSeed *rand.Rand) []Input {
 	return pos
 }
 
+// PrismaticInputs returns whether each input of the frame is the position of a prismatic joint, which is in mm, rather than an
+// angle in radians. This includes the position of a 2D mobile model, which is built from prismatic joints. Inputs of frames other
+// than prismatic and revolute joints, and models built from them, are reported as not prismatic.
+func PrismaticInputs(f Frame) []bool {
+	switch frame := f.(type) {
+	case *translationalFrame:
+		return []bool{true}
+	case *SimpleModel:
+		prismatic := make([]bool, 0, len(frame.DoF()))
+		for _, transform := range frame.OrdTransforms {
+			prismatic = append(prismatic, PrismaticInputs(transform)...)
+		}
+		return prismatic
+	case *namedFrame:
+		return PrismaticInputs(frame.Frame)
+	case *attachedGeometryFrame:
+		return PrismaticInputs(frame.Frame)
+	default:
+		return make([]bool, len(f.DoF()))
+	}
+}
+
 // Limited represents anything that has Limits.
 type Limited interface {
 	// DoF will return a slice with length equal to the number of degrees of freedom.
@@ -216,6 +254,40 @@ func NewNamedFrame(frame Frame, name string) Frame {
 	return &namedFrame{Frame: frame, name: name}
 }
 
+// attachedGeometryFrame is used to rigidly attach a geometry, such as a gripper or a payload, to the end of a frame.
+type attachedGeometryFrame struct {
+	Frame
+	geometry spatial.Geometry
+}
+
+func (af *attachedGeometryFrame) Geometries(inputs []Input) (*GeometriesInFrame, error) {
+	gif, err := af.Frame.Geometries(inputs)
+	if err != nil {
+		return nil, err
+	}
+	tf, err := af.Frame.Transform(inputs)
+	if err != nil {
+		return nil, err
+	}
+	attached := af.geometry.Transform(tf)
+	if attached.Label() == "" {
+		attached.SetLabel(af.Name() + ":attached")
+	}
+	geometries := make([]spatial.Geometry, 0, len(gif.Geometries())+1)
+	geometries = append(geometries, gif.Geometries()...)
+	return NewGeometriesInFrame(gif.Parent(), append(geometries, attached)), nil
+}
+
+// NewFrameWithAttachedGeometry will return a frame which passes through all functions of the original frame, but whose geometries
+// also include the given geometry. The geometry's pose is relative to the end of the frame, e.g. the tool frame of an arm, so that
+// it moves with the frame and is collision checked along with it. If geometry is nil the original frame is returned.
+func NewFrameWithAttachedGeometry(frame Frame, geometry spatial.Geometry) Frame {
+	if geometry == nil {
+		return frame
+	}
+	return &attachedGeometryFrame{Frame: frame, geometry: geometry}
+}
+
 // NewStaticFrame creates a frame given a pose relative to its parent. The pose is fixed for all time.
 // Pose is not allowed to be nil.
 func NewStaticFrame(name string, pose spatial.Pose) (Frame, error) {
@@ -33,6 +33,9 @@ type JointConfig struct {
 	Max      float64                 `json:"max"`                // in mm or degs
 	Min      float64                 `json:"min"`                // in mm or degs
 	Geometry *spatial.GeometryConfig `json:"geometry,omitempty"` // only valid for prismatic/translational joints
+	// MaxVelocity and MaxAcceleration are optional, in mm or degs per second and per second squared
+	MaxVelocity     float64 `json:"max_velocity,omitempty"`
+	MaxAcceleration float64 `json:"max_acceleration,omitempty"`
 }
 
 // DHParamConfig is a revolute and static frame combined in a set of Denavit Hartenberg parameters.
@@ -45,6 +48,9 @@ type DHParamConfig struct {
 	Max      float64                 `json:"max"` // in mm or degs
 	Min      float64                 `json:"min"` // in mm or degs
 	Geometry *spatial.GeometryConfig `json:"geometry,omitempty"`
+	// MaxVelocity and MaxAcceleration are optional, in degs per second and per second squared
+	MaxVelocity     float64 `json:"max_velocity,omitempty"`
+	MaxAcceleration float64 `json:"max_acceleration,omitempty"`
 }
 
 // NewLinkConfig constructs a config from a Frame.
@@ -100,6 +106,14 @@ func (cfg *LinkConfig) Pose() (spatial.Pose, error) {
 	return spatial.NewPoseFromPoint(pt), nil
 }
 
+// motionLimits returns the joint's maximum velocity and acceleration in the units of its inputs, radians or mm.
+func (cfg *JointConfig) motionLimits() (float64, float64) {
+	if cfg.Type == RevoluteJoint {
+		return utils.DegToRad(cfg.MaxVelocity), utils.DegToRad(cfg.MaxAcceleration)
+	}
+	return cfg.MaxVelocity, cfg.MaxAcceleration
+}
+
 // ToFrame converts a JointConfig into a joint frame.
 func (cfg *JointConfig) ToFrame() (Frame, error) {
 	switch cfg.Type {
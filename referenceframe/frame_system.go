@@ -525,6 +525,29 @@ func FrameSystemGeometries(fs FrameSystem, inputMap map[string][]Input) (map[str
 	return allGeometries, errAll
 }
 
+// TransformGeometries re-expresses geometries in the frame named dst, using positions as the inputs for any frames with non-zero DOF.
+// Unlike Transform, the geometries are taken to be expressed in their parent frame itself, such as obstacles seen by a camera which
+// are reported relative to the camera, rather than geometries attached to the frame as part of its definition.
+func TransformGeometries(
+	fs FrameSystem,
+	positions map[string][]Input,
+	geometries *GeometriesInFrame,
+	dst string,
+) (*GeometriesInFrame, error) {
+	src := geometries.Parent()
+	if fs.Frame(src) == nil {
+		return nil, NewFrameMissingError(src)
+	}
+	if fs.Frame(dst) == nil {
+		return nil, NewFrameMissingError(dst)
+	}
+	tf, err := fs.Transform(positions, NewPoseInFrame(src, spatial.NewZeroPose()), dst)
+	if err != nil {
+		return nil, err
+	}
+	return geometries.Transform(tf.(*PoseInFrame)).(*GeometriesInFrame), nil
+}
+
 // ToProtobuf turns all the interfaces into serializable types.
 func (part *FrameSystemPart) ToProtobuf() (*pb.FrameSystemConfig, error) {
 	if part.FrameConfig == nil {
@@ -0,0 +1,102 @@
+package referenceframe
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// FrameSystemJSON describes a frame system, such as a camera mounted on an arm, as a list of frames which are each placed relative
+// to their parent. The parent of a frame is either World or another frame of the list.
+type FrameSystemJSON struct {
+	Name   string            `json:"name"`
+	Frames []FramePartConfig `json:"frames"`
+}
+
+// FramePartConfig is a frame of a FrameSystemJSON. Its link gives where the frame is relative to its parent, and ModelFile
+// optionally names a model JSON file describing the frame's kinematics, relative to the directory of the frame system file.
+type FramePartConfig struct {
+	LinkConfig
+	ModelFile string `json:"model_file,omitempty"`
+}
+
+// ParseFrameSystemJSONFile reads a FrameSystemJSON from the given file and builds the frame system it describes.
+func ParseFrameSystemJSONFile(filename string) (FrameSystem, error) {
+	//nolint:gosec
+	jsonData, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read json file")
+	}
+	return UnmarshalFrameSystemJSON(jsonData, filepath.Dir(filename))
+}
+
+// UnmarshalFrameSystemJSON builds the frame system described by a FrameSystemJSON. Model files are looked up relative to modelDir.
+func UnmarshalFrameSystemJSON(jsonData []byte, modelDir string) (FrameSystem, error) {
+	var cfg FrameSystemJSON
+	if err := json.Unmarshal(jsonData, &cfg); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal json file")
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	parts := make([]*FrameSystemPart, 0, len(cfg.Frames))
+	for _, frameCfg := range cfg.Frames {
+		link, err := frameCfg.ParseConfig()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot parse frame %s", frameCfg.ID)
+		}
+		part := &FrameSystemPart{FrameConfig: link}
+		if frameCfg.ModelFile != "" {
+			modelFile := frameCfg.ModelFile
+			if !filepath.IsAbs(modelFile) {
+				modelFile = filepath.Join(modelDir, modelFile)
+			}
+			part.ModelFrame, err = ParseModelJSONFile(modelFile, frameCfg.ID)
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot parse model of frame %s", frameCfg.ID)
+			}
+		}
+		parts = append(parts, part)
+	}
+	return NewFrameSystem(cfg.Name, parts, nil)
+}
+
+// validate checks that every frame has a unique name and a parent which exists, and that following the parents of any frame
+// leads to World rather than around a cycle.
+func (cfg *FrameSystemJSON) validate() error {
+	parents := make(map[string]string, len(cfg.Frames))
+	for _, frameCfg := range cfg.Frames {
+		if frameCfg.ID == "" || frameCfg.Parent == "" {
+			return ErrEmptyStringFrameName
+		}
+		if frameCfg.ID == World {
+			return errors.Errorf("frame cannot be named %s", World)
+		}
+		if _, ok := parents[frameCfg.ID]; ok {
+			return NewFrameAlreadyExistsError(frameCfg.ID)
+		}
+		parents[frameCfg.ID] = frameCfg.Parent
+	}
+	for _, frameCfg := range cfg.Frames {
+		if _, ok := parents[frameCfg.Parent]; !ok && frameCfg.Parent != World {
+			return NewParentFrameMissingError(frameCfg.ID, frameCfg.Parent)
+		}
+	}
+	for _, frameCfg := range cfg.Frames {
+		path := []string{frameCfg.ID}
+		for name := frameCfg.Parent; name != World; name = parents[name] {
+			path = append(path, name)
+			if name == frameCfg.ID {
+				return errors.Errorf("frame system contains a cycle: %s", strings.Join(path, " -> "))
+			}
+			if len(path) > len(cfg.Frames) {
+				// the cycle does not pass through this frame, and is reported when its own frames are checked
+				break
+			}
+		}
+	}
+	return nil
+}
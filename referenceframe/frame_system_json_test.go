@@ -0,0 +1,90 @@
+package referenceframe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	spatial "go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+func TestParseFrameSystemJSONFile(t *testing.T) {
+	fs, err := ParseFrameSystemJSONFile(rdkutils.ResolveFile("referenceframe/testjson/frame_system.json"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.Name(), test.ShouldEqual, "arm with camera")
+	test.That(t, fs.Frame("arm"), test.ShouldNotBeNil)
+	test.That(t, fs.Frame("arm").DoF(), test.ShouldHaveLength, 6)
+	test.That(t, fs.Frame("camera"), test.ShouldNotBeNil)
+	parent, err := fs.Parent(fs.Frame("camera_origin"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, parent.Name(), test.ShouldEqual, "arm")
+
+	// obstacles seen by the camera move with the arm
+	box, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{0, 0, 100}), r3.Vector{10, 10, 10}, "obstacle")
+	test.That(t, err, test.ShouldBeNil)
+	inCamera := NewGeometriesInFrame("camera", []spatial.Geometry{box})
+	for _, jointPositions := range [][]float64{{0, 0, 0, 0, 0, 0}, {1, -0.5, 0.5, 0, 0.5, 0}} {
+		positions := StartPositions(fs)
+		positions["arm"] = FloatsToInputs(jointPositions)
+		cameraInWorld, err := fs.Transform(positions, NewPoseInFrame("camera", spatial.NewZeroPose()), World)
+		test.That(t, err, test.ShouldBeNil)
+		inWorld, err := TransformGeometries(fs, positions, inCamera, World)
+		test.That(t, err, test.ShouldBeNil)
+		expected := spatial.Compose(cameraInWorld.(*PoseInFrame).Pose(), box.Pose())
+		test.That(t, spatial.PoseAlmostEqual(inWorld.Geometries()[0].Pose(), expected), test.ShouldBeTrue)
+	}
+
+	_, err = ParseFrameSystemJSONFile("nonexistent.json")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestUnmarshalFrameSystemJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		json string
+		err  error
+	}{
+		{
+			"missing parent",
+			`{"frames": [{"id": "camera", "parent": "arm"}]}`,
+			NewParentFrameMissingError("camera", "arm"),
+		},
+		{
+			"cycle",
+			`{"frames": [{"id": "a", "parent": "world"}, {"id": "b", "parent": "c"}, {"id": "c", "parent": "b"}]}`,
+			errors.New("frame system contains a cycle: b -> c -> b"),
+		},
+		{
+			"own parent",
+			`{"frames": [{"id": "a", "parent": "a"}]}`,
+			errors.New("frame system contains a cycle: a -> a"),
+		},
+		{
+			"duplicate",
+			`{"frames": [{"id": "a", "parent": "world"}, {"id": "a", "parent": "world"}]}`,
+			NewFrameAlreadyExistsError("a"),
+		},
+		{
+			"no parent",
+			`{"frames": [{"id": "a"}]}`,
+			ErrEmptyStringFrameName,
+		},
+		{
+			"named world",
+			`{"frames": [{"id": "world", "parent": "world"}]}`,
+			errors.New("frame cannot be named world"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := UnmarshalFrameSystemJSON([]byte(tc.json), "")
+			test.That(t, err, test.ShouldBeError, tc.err)
+		})
+	}
+
+	_, err := UnmarshalFrameSystemJSON([]byte(`{"frames": [{"id": "arm", "parent": "world", "model_file": "missing.json"}]}`), "")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot parse model of frame arm")
+}
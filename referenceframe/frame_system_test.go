@@ -335,6 +335,45 @@ func TestFrameSystemGeometries(t *testing.T) {
 	}
 }
 
+func TestTransformGeometries(t *testing.T) {
+	fs := NewEmptyFrameSystem("test")
+	camera, err := NewStaticFrame("camera", spatial.NewPose(r3.Vector{100, 0, 0}, &spatial.OrientationVectorDegrees{OX: 1, Theta: 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(camera, fs.World()), test.ShouldBeNil)
+	gantry, err := NewTranslationalFrame("gantry", r3.Vector{0, 1, 0}, Limit{Min: -100, Max: 100})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+	positions := StartPositions(fs)
+	positions["gantry"] = FloatsToInputs([]float64{20})
+
+	// an obstacle 50mm in front of the camera, along its z axis
+	box, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{0, 0, 50}), r3.Vector{10, 10, 10}, "obstacle")
+	test.That(t, err, test.ShouldBeNil)
+	inCamera := NewGeometriesInFrame("camera", []spatial.Geometry{box})
+
+	inWorld, err := TransformGeometries(fs, positions, inCamera, World)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inWorld.Parent(), test.ShouldEqual, World)
+	test.That(t, inWorld.Geometries(), test.ShouldHaveLength, 1)
+	test.That(t, inWorld.Geometries()[0].Label(), test.ShouldEqual, "obstacle")
+	test.That(t, spatial.R3VectorAlmostEqual(inWorld.Geometries()[0].Pose().Point(), r3.Vector{150, 0, 0}, 1e-8), test.ShouldBeTrue)
+
+	inGantry, err := TransformGeometries(fs, positions, inCamera, "gantry")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inGantry.Parent(), test.ShouldEqual, "gantry")
+	test.That(t, spatial.R3VectorAlmostEqual(inGantry.Geometries()[0].Pose().Point(), r3.Vector{150, -20, 0}, 1e-8), test.ShouldBeTrue)
+
+	// and back again
+	roundTrip, err := TransformGeometries(fs, positions, inGantry, "camera")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.GeometriesAlmostEqual(roundTrip.Geometries()[0], box), test.ShouldBeTrue)
+
+	_, err = TransformGeometries(fs, positions, NewGeometriesInFrame("lidar", []spatial.Geometry{box}), World)
+	test.That(t, err, test.ShouldBeError, NewFrameMissingError("lidar"))
+	_, err = TransformGeometries(fs, positions, inCamera, "lidar")
+	test.That(t, err, test.ShouldBeError, NewFrameMissingError("lidar"))
+}
+
 func TestReplaceFrame(t *testing.T) {
 	fs := NewEmptyFrameSystem("test")
 	// fill framesystem
@@ -147,6 +147,41 @@ func TestGeometries(t *testing.T) {
 	test.That(t, spatial.GeometriesAlmostEqual(expectedBox, geometries.Geometries()[0]), test.ShouldBeTrue)
 }
 
+func TestFrameWithAttachedGeometry(t *testing.T) {
+	link, err := spatial.NewBox(spatial.NewZeroPose(), r3.Vector{1, 1, 1}, "link")
+	test.That(t, err, test.ShouldBeNil)
+	tf, err := NewTranslationalFrameWithGeometry("slide", r3.Vector{0, 1, 0}, Limit{Min: -30, Max: 30}, link)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, NewFrameWithAttachedGeometry(tf, nil), test.ShouldEqual, tf)
+
+	// the payload sits 5mm beyond the end of the frame, and is rotated relative to it
+	offset := spatial.NewPose(r3.Vector{0, 0, 5}, &spatial.OrientationVectorDegrees{OX: 1, Theta: 30})
+	payload, err := spatial.NewBox(offset, r3.Vector{2, 4, 6}, "")
+	test.That(t, err, test.ShouldBeNil)
+	af := NewFrameWithAttachedGeometry(tf, payload)
+	test.That(t, af.Name(), test.ShouldEqual, tf.Name())
+	test.That(t, af.DoF(), test.ShouldResemble, tf.DoF())
+
+	inputs := FloatsToInputs([]float64{10})
+	geometries, err := af.Geometries(inputs)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, geometries.Parent(), test.ShouldEqual, tf.Name())
+	test.That(t, geometries.Geometries(), test.ShouldHaveLength, 2)
+	test.That(t, spatial.GeometriesAlmostEqual(geometries.GeometryByName("link"), link.Transform(spatial.NewPoseFromPoint(r3.Vector{0, 10, 0}))),
+		test.ShouldBeTrue)
+	attached := geometries.GeometryByName("slide:attached")
+	test.That(t, attached, test.ShouldNotBeNil)
+	test.That(t, spatial.PoseAlmostEqual(attached.Pose(), spatial.Compose(spatial.NewPoseFromPoint(r3.Vector{0, 10, 0}), offset)),
+		test.ShouldBeTrue)
+
+	// the attached geometry itself is left unchanged
+	test.That(t, payload.Label(), test.ShouldEqual, "")
+	test.That(t, spatial.PoseAlmostEqual(payload.Pose(), offset), test.ShouldBeTrue)
+
+	_, err = af.Geometries(FloatsToInputs([]float64{10, 10}))
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestSerializationStatic(t *testing.T) {
 	f, err := NewStaticFrame("foo", spatial.NewPose(r3.Vector{1, 2, 3}, &spatial.R4AA{math.Pi / 2, 4, 5, 6}))
 	test.That(t, err, test.ShouldBeNil)
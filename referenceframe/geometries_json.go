@@ -0,0 +1,86 @@
+package referenceframe
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// GeometriesFromJSON parses a JSON object mapping names to geometry configs into geometries keyed by name. Only boxes, spheres and
+// capsules are supported, and all of their dimensions must be positive. Each geometry is labeled with its name.
+func GeometriesFromJSON(data []byte) (map[string]spatialmath.Geometry, error) {
+	configs := map[string]*spatialmath.GeometryConfig{}
+	if err := json.Unmarshal(data, &configs); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal geometries json")
+	}
+	geometries := make(map[string]spatialmath.Geometry, len(configs))
+	for name, config := range configs {
+		if config == nil {
+			return nil, fmt.Errorf("geometry %q has no config", name)
+		}
+		if err := validateGeometryConfigDimensions(config); err != nil {
+			return nil, fmt.Errorf("geometry %q: %w", name, err)
+		}
+		if config.Label != "" && config.Label != name {
+			return nil, fmt.Errorf("geometry %q is already labeled %q", name, config.Label)
+		}
+		config.Label = name
+		geometry, err := config.ParseConfig()
+		if err != nil {
+			return nil, fmt.Errorf("geometry %q: %w", name, err)
+		}
+		geometries[name] = geometry
+	}
+	return geometries, nil
+}
+
+// ParseGeometriesJSONFile reads a file in the format accepted by GeometriesFromJSON and returns the geometries it describes.
+func ParseGeometriesJSONFile(filename string) (map[string]spatialmath.Geometry, error) {
+	//nolint:gosec
+	jsonData, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read json file")
+	}
+	return GeometriesFromJSON(jsonData)
+}
+
+// GeometriesToJSON writes geometries keyed by name in the format accepted by GeometriesFromJSON.
+func GeometriesToJSON(geometries map[string]spatialmath.Geometry) ([]byte, error) {
+	configs := make(map[string]*spatialmath.GeometryConfig, len(geometries))
+	for name, geometry := range geometries {
+		config, err := spatialmath.NewGeometryConfig(geometry)
+		if err != nil {
+			return nil, fmt.Errorf("geometry %q: %w", name, err)
+		}
+		if err := validateGeometryConfigDimensions(config); err != nil {
+			return nil, fmt.Errorf("geometry %q: %w", name, err)
+		}
+		config.Label = name
+		configs[name] = config
+	}
+	return json.MarshalIndent(configs, "", "  ")
+}
+
+func validateGeometryConfigDimensions(config *spatialmath.GeometryConfig) error {
+	switch config.Type {
+	case spatialmath.BoxType:
+		if config.X <= 0 || config.Y <= 0 || config.Z <= 0 {
+			return fmt.Errorf("box dimensions must be positive, got (%v, %v, %v)", config.X, config.Y, config.Z)
+		}
+	case spatialmath.SphereType:
+		if config.R <= 0 {
+			return fmt.Errorf("sphere radius must be positive, got %v", config.R)
+		}
+	case spatialmath.CapsuleType:
+		if config.R <= 0 || config.L <= 0 {
+			return fmt.Errorf("capsule radius and length must be positive, got %v and %v", config.R, config.L)
+		}
+	default:
+		return fmt.Errorf("unsupported geometry type %q, must be one of box, sphere or capsule", config.Type)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package referenceframe
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestGeometriesJSON(t *testing.T) {
+	box, err := spatialmath.NewBox(
+		spatialmath.NewPose(r3.Vector{X: 100, Y: 200, Z: 300}, &spatialmath.OrientationVectorDegrees{OX: 1, Theta: 30}),
+		r3.Vector{X: 10, Y: 20, Z: 30},
+		"",
+	)
+	test.That(t, err, test.ShouldBeNil)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewPoseFromPoint(r3.Vector{Z: -50}), 25, "")
+	test.That(t, err, test.ShouldBeNil)
+	capsule, err := spatialmath.NewCapsule(spatialmath.NewZeroPose(), 5, 40, "")
+	test.That(t, err, test.ShouldBeNil)
+	geometries := map[string]spatialmath.Geometry{"table": box, "ball": sphere, "pole": capsule}
+
+	data, err := GeometriesToJSON(geometries)
+	test.That(t, err, test.ShouldBeNil)
+	filename := filepath.Join(t.TempDir(), "obstacles.json")
+	test.That(t, os.WriteFile(filename, data, 0o600), test.ShouldBeNil)
+	parsed, err := ParseGeometriesJSONFile(filename)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(parsed), test.ShouldEqual, len(geometries))
+	for name, geometry := range geometries {
+		test.That(t, parsed[name].Label(), test.ShouldEqual, name)
+		geometry.SetLabel(name)
+		test.That(t, spatialmath.GeometriesAlmostEqual(parsed[name], geometry), test.ShouldBeTrue)
+	}
+
+	t.Run("invalid geometries", func(t *testing.T) {
+		_, err := GeometriesFromJSON([]byte(`{"flat": {"type": "box", "x": 10, "y": 10, "z": 0}}`))
+		test.That(t, err, test.ShouldBeError, errors.New(`geometry "flat": box dimensions must be positive, got (10, 10, 0)`))
+
+		_, err = GeometriesFromJSON([]byte(`{"ball": {"type": "sphere", "r": -1}}`))
+		test.That(t, err, test.ShouldBeError, errors.New(`geometry "ball": sphere radius must be positive, got -1`))
+
+		_, err = GeometriesFromJSON([]byte(`{"dot": {"type": "point"}}`))
+		test.That(t, err, test.ShouldBeError,
+			errors.New(`geometry "dot": unsupported geometry type "point", must be one of box, sphere or capsule`))
+
+		_, err = GeometriesFromJSON([]byte(`{"ball": {"type": "sphere", "r": 1, "Label": "other"}}`))
+		test.That(t, err, test.ShouldBeError, errors.New(`geometry "ball" is already labeled "other"`))
+
+		_, err = GeometriesToJSON(map[string]spatialmath.Geometry{"dot": spatialmath.NewPoint(r3.Vector{}, "")})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
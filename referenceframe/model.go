@@ -3,6 +3,7 @@ package referenceframe
 import (
 	"encoding/binary"
 	"encoding/json"
+	"fmt"
 	"math"
 	"math/rand"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	pb "go.viam.com/api/component/arm/v1"
+	"gonum.org/v1/gonum/mat"
 
 	"go.viam.com/rdk/spatialmath"
 )
@@ -20,6 +22,8 @@ import (
 type Model interface {
 	Frame
 	ModelConfig() *ModelConfig
+	// Jacobian returns the 6xN geometric Jacobian of the end of the model at the given inputs, where N is the number of inputs.
+	Jacobian(inputs []Input) (*mat.Dense, error)
 }
 
 // ModelFramer has a method that returns the kinematics information needed to build a dynamic referenceframe.
@@ -35,6 +39,9 @@ type SimpleModel struct {
 	modelConfig   *ModelConfig
 	poseCache     sync.Map
 	lock          sync.RWMutex
+	// maxVelocity and maxAcceleration hold the motion limits of each input, if the model's kinematics file gave them for every joint
+	maxVelocity     []float64
+	maxAcceleration []float64
 }
 
 // NewSimpleModel constructs a new model.
@@ -183,6 +190,91 @@ func (m *SimpleModel) DoF() []Limit {
 	return limits
 }
 
+// MotionLimits returns the maximum velocity and acceleration of each of the model's inputs, in radians or mm per second and per second
+// squared. Both are nil unless the model's kinematics file gave them for every joint.
+func (m *SimpleModel) MotionLimits() (maxVelocity, maxAcceleration []float64) {
+	return m.maxVelocity, m.maxAcceleration
+}
+
+// Jacobian returns the 6xN geometric Jacobian of the end of the model at the given inputs, where N is the number of inputs. Column
+// i maps a change in input i to the resulting velocity of the end of the model in the model's base frame: the first three rows are
+// its linear velocity, in mm per unit of input, and the last three its angular velocity, in radians per unit of input. Only models
+// made of static, rotational and translational frames are supported.
+func (m *SimpleModel) Jacobian(inputs []Input) (*mat.Dense, error) {
+	if len(m.DoF()) != len(inputs) {
+		return nil, NewIncorrectInputLengthError(len(inputs), len(m.DoF()))
+	}
+	end, err := m.Transform(inputs)
+	if end == nil {
+		return nil, err
+	}
+
+	jac := mat.NewDense(6, len(inputs), nil)
+	composed := spatialmath.NewZeroPose()
+	posIdx := 0
+	for _, transform := range m.OrdTransforms {
+		dof := len(transform.DoF())
+		input := inputs[posIdx : posIdx+dof]
+		// the axis of a joint is in the frame of the joint, which is where the frames before it leave off
+		toBase := func(axis r3.Vector) r3.Vector {
+			return spatialmath.Compose(spatialmath.NewPoseFromOrientation(composed.Orientation()), spatialmath.NewPoseFromPoint(axis)).Point()
+		}
+		switch frame := transform.(type) {
+		case *rotationalFrame:
+			axis := toBase(frame.rotAxis)
+			linear := axis.Cross(end.Point().Sub(composed.Point()))
+			jac.SetCol(posIdx, []float64{linear.X, linear.Y, linear.Z, axis.X, axis.Y, axis.Z})
+		case *translationalFrame:
+			axis := toBase(frame.transAxis)
+			jac.SetCol(posIdx, []float64{axis.X, axis.Y, axis.Z, 0, 0, 0})
+		default:
+			if dof > 0 {
+				return nil, errors.Errorf("cannot compute the jacobian of frame %q of type %T", transform.Name(), transform)
+			}
+		}
+		pose, err := transform.Transform(input)
+		if pose == nil {
+			return nil, err
+		}
+		composed = spatialmath.Compose(composed, pose)
+		posIdx += dof
+	}
+	return jac, nil
+}
+
+// Joint describes a single degree of freedom of a frame.
+type Joint struct {
+	Name  string
+	Limit Limit
+}
+
+// Joints returns the name and limits of each degree of freedom of the given frame, in the order in which its inputs are given. For
+// a SimpleModel each joint is named after the frame within the model that it moves; frames with several degrees of freedom have
+// their joints suffixed with their index within that frame.
+func Joints(f Frame) []Joint {
+	model, ok := f.(*SimpleModel)
+	if !ok {
+		return namedJoints(f.Name(), f.DoF())
+	}
+	joints := make([]Joint, 0, len(model.DoF()))
+	for _, transform := range model.OrdTransforms {
+		joints = append(joints, namedJoints(transform.Name(), transform.DoF())...)
+	}
+	return joints
+}
+
+func namedJoints(name string, limits []Limit) []Joint {
+	joints := make([]Joint, 0, len(limits))
+	for i, limit := range limits {
+		jointName := name
+		if len(limits) > 1 {
+			jointName = fmt.Sprintf("%s_%d", name, i)
+		}
+		joints = append(joints, Joint{Name: jointName, Limit: limit})
+	}
+	return joints
+}
+
 // MarshalJSON serializes a Model.
 func (m *SimpleModel) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.modelConfig)
@@ -6,6 +6,8 @@ import (
 
 	"github.com/pkg/errors"
 	"golang.org/x/exp/maps"
+
+	"go.viam.com/rdk/utils"
 )
 
 // ErrNoModelInformation is used when there is no model information.
@@ -59,6 +61,9 @@ func (cfg *ModelConfig) ParseConfig(modelName string) (Model, error) {
 
 	// Make a map of parents for each element for post-process, to allow items to be processed out of order
 	parentMap := map[string]string{}
+	// The maximum velocity and acceleration of each joint, by the name of its frame
+	velocities := map[string]float64{}
+	accelerations := map[string]float64{}
 
 	switch cfg.KinParamType {
 	case "SVA", "":
@@ -92,6 +97,7 @@ func (cfg *ModelConfig) ParseConfig(modelName string) (Model, error) {
 			if err != nil {
 				return nil, err
 			}
+			velocities[joint.ID], accelerations[joint.ID] = joint.motionLimits()
 		}
 
 	case "DH":
@@ -104,6 +110,7 @@ func (cfg *ModelConfig) ParseConfig(modelName string) (Model, error) {
 			jointID := dh.ID + "_j"
 			parentMap[jointID] = dh.Parent
 			transforms[jointID] = rFrame
+			velocities[jointID], accelerations[jointID] = utils.DegToRad(dh.MaxVelocity), utils.DegToRad(dh.MaxAcceleration)
 
 			// Link part of DH param
 			linkID := dh.ID
@@ -121,6 +128,23 @@ func (cfg *ModelConfig) ParseConfig(modelName string) (Model, error) {
 		return nil, err
 	}
 
+	// Motion limits are only usable if every joint has them
+	maxVelocity := []float64{}
+	maxAcceleration := []float64{}
+	for _, transform := range model.OrdTransforms {
+		if len(transform.DoF()) == 0 {
+			continue
+		}
+		if velocities[transform.Name()] <= 0 || accelerations[transform.Name()] <= 0 {
+			return model, nil
+		}
+		maxVelocity = append(maxVelocity, velocities[transform.Name()])
+		maxAcceleration = append(maxAcceleration, accelerations[transform.Name()])
+	}
+	if len(maxVelocity) > 0 {
+		model.maxVelocity, model.maxAcceleration = maxVelocity, maxAcceleration
+	}
+
 	return model, nil
 }
 
@@ -140,3 +140,165 @@ func Test2DMobileModelFrame(t *testing.T) {
 	limit := frame.DoF()
 	test.That(t, limit[0], test.ShouldResemble, expLimit[0])
 }
+
+func TestOutOfBoundsJoints(t *testing.T) {
+	m, err := ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	oob, err := OutOfBoundsJoints(m, make([]Input, 6))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, oob, test.ShouldBeEmpty)
+
+	oob, err = OutOfBoundsJoints(m, FloatsToInputs([]float64{10, 0, 0, -10, 0, 10}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, oob, test.ShouldResemble, []int{0, 3, 5})
+
+	_, err = OutOfBoundsJoints(m, make([]Input, 5))
+	test.That(t, err, test.ShouldBeError, NewIncorrectInputLengthError(5, 6))
+}
+
+func TestJoints(t *testing.T) {
+	m, err := ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	joints := Joints(m)
+	test.That(t, len(joints), test.ShouldEqual, len(m.DoF()))
+	names := make([]string, 0, len(joints))
+	for i, joint := range joints {
+		test.That(t, joint.Limit, test.ShouldResemble, m.DoF()[i])
+		names = append(names, joint.Name)
+	}
+	test.That(t, names, test.ShouldResemble, []string{"waist", "shoulder", "elbow", "forearm_rot", "wrist", "gripper_rot"})
+
+	limits := []Limit{{-10, 10}, {-20, 20}}
+	mobile, err := New2DMobileModelFrame("base", limits, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, Joints(mobile), test.ShouldResemble, []Joint{{Name: "x", Limit: limits[0]}, {Name: "y", Limit: limits[1]}})
+}
+
+func TestMotionLimits(t *testing.T) {
+	m, err := UnmarshalModelJSON([]byte(`{
+		"name": "rail_arm",
+		"links": [
+			{"id": "carriage", "parent": "rail", "translation": {"x": 0, "y": 0, "z": 50}},
+			{"id": "arm", "parent": "shoulder", "translation": {"x": 300, "y": 0, "z": 0}}
+		],
+		"joints": [
+			{
+				"id": "rail", "type": "prismatic", "parent": "world", "axis": {"x": 1, "y": 0, "z": 0}, "min": -500, "max": 1500,
+				"max_velocity": 250, "max_acceleration": 500
+			},
+			{
+				"id": "shoulder", "type": "revolute", "parent": "carriage", "axis": {"x": 0, "y": 0, "z": 1}, "min": -90, "max": 90,
+				"max_velocity": 180, "max_acceleration": 360
+			}
+		]
+	}`), "")
+	test.That(t, err, test.ShouldBeNil)
+	maxVelocity, maxAcceleration := m.(*SimpleModel).MotionLimits()
+	test.That(t, maxVelocity, test.ShouldResemble, []float64{250, math.Pi})
+	test.That(t, maxAcceleration, test.ShouldResemble, []float64{500, 2 * math.Pi})
+
+	// limits which aren't given for every joint are not used
+	m, err = ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	maxVelocity, maxAcceleration = m.(*SimpleModel).MotionLimits()
+	test.That(t, maxVelocity, test.ShouldBeNil)
+	test.That(t, maxAcceleration, test.ShouldBeNil)
+}
+
+func TestPrismaticInputs(t *testing.T) {
+	// a revolute arm carried along a linear rail
+	railArm, err := UnmarshalModelJSON([]byte(`{
+		"name": "rail_arm",
+		"links": [
+			{"id": "carriage", "parent": "rail", "translation": {"x": 0, "y": 0, "z": 50}},
+			{"id": "arm", "parent": "shoulder", "translation": {"x": 300, "y": 0, "z": 0}}
+		],
+		"joints": [
+			{"id": "rail", "type": "prismatic", "parent": "world", "axis": {"x": 1, "y": 0, "z": 0}, "min": -500, "max": 1500},
+			{"id": "shoulder", "type": "revolute", "parent": "carriage", "axis": {"x": 0, "y": 0, "z": 1}, "min": -90, "max": 90}
+		]
+	}`), "")
+	test.That(t, err, test.ShouldBeNil)
+	// prismatic limits are in mm, while revolute limits are converted from degrees to radians
+	test.That(t, railArm.DoF(), test.ShouldResemble, []Limit{{-500, 1500}, {-math.Pi / 2, math.Pi / 2}})
+	test.That(t, PrismaticInputs(railArm), test.ShouldResemble, []bool{true, false})
+
+	pose, err := railArm.Transform(FloatsToInputs([]float64{1000, math.Pi / 2}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{X: 1000, Y: 300, Z: 50}, 1e-8), test.ShouldBeTrue)
+
+	test.That(t, PrismaticInputs(NewNamedFrame(railArm, "renamed")), test.ShouldResemble, []bool{true, false})
+	sphere, err := spatial.NewSphere(spatial.NewZeroPose(), 10, "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, PrismaticInputs(NewFrameWithAttachedGeometry(railArm, sphere)), test.ShouldResemble, []bool{true, false})
+
+	// the position of a mobile base slides along x and y, while its heading turns
+	mobile, err := New2DMobileModelFrame("base", []Limit{{-10, 10}, {-20, 20}, {-math.Pi, math.Pi}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, PrismaticInputs(mobile), test.ShouldResemble, []bool{true, true, false})
+}
+
+func TestJacobian(t *testing.T) {
+	ur5e, err := ParseModelJSONFile(utils.ResolveFile("referenceframe/testjson/ur5eDH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	// a gantry carrying a revolute joint on a raised offset
+	gantry := NewSimpleModel("gantry")
+	slide, err := NewTranslationalFrame("slide", r3.Vector{X: 1, Y: 1}, Limit{-1000, 1000})
+	test.That(t, err, test.ShouldBeNil)
+	offset, err := NewStaticFrame("offset", spatial.NewPose(r3.Vector{Z: 100}, &spatial.OrientationVectorDegrees{OX: 1, Theta: 30}))
+	test.That(t, err, test.ShouldBeNil)
+	wrist, err := NewRotationalFrame("wrist", spatial.R4AA{RY: 1}, Limit{-math.Pi, math.Pi})
+	test.That(t, err, test.ShouldBeNil)
+	tool, err := NewStaticFrame("tool", spatial.NewPoseFromPoint(r3.Vector{X: 50, Z: 20}))
+	test.That(t, err, test.ShouldBeNil)
+	gantry.OrdTransforms = []Frame{slide, offset, wrist, tool}
+
+	// each column of the jacobian should match the change in pose from nudging its input either way
+	const step = 1e-4
+	numericJacobian := func(m Model, inputs []float64) [][]float64 {
+		cols := make([][]float64, 0, len(inputs))
+		for i := range inputs {
+			nudged := func(by float64) spatial.Pose {
+				nudgedInputs := append([]float64{}, inputs...)
+				nudgedInputs[i] += by
+				pose, err := m.Transform(FloatsToInputs(nudgedInputs))
+				test.That(t, err, test.ShouldBeNil)
+				return pose
+			}
+			plus, minus := nudged(step), nudged(-step)
+			linear := plus.Point().Sub(minus.Point()).Mul(1 / (2 * step))
+			rotation := spatial.Compose(
+				spatial.NewPoseFromOrientation(plus.Orientation()),
+				spatial.PoseInverse(spatial.NewPoseFromOrientation(minus.Orientation())),
+			)
+			angular := rotation.Orientation().AxisAngles().ToR3().Mul(1 / (2 * step))
+			cols = append(cols, []float64{linear.X, linear.Y, linear.Z, angular.X, angular.Y, angular.Z})
+		}
+		return cols
+	}
+
+	for _, tc := range []struct {
+		model  Model
+		inputs []float64
+	}{
+		{ur5e, []float64{0, 0, 0, 0, 0, 0}},
+		{ur5e, []float64{0.3, -1.2, 1.1, -0.4, 0.9, 2.1}},
+		{gantry, []float64{250, 0.7}},
+	} {
+		jac, err := tc.model.Jacobian(FloatsToInputs(tc.inputs))
+		test.That(t, err, test.ShouldBeNil)
+		rows, cols := jac.Dims()
+		test.That(t, rows, test.ShouldEqual, 6)
+		test.That(t, cols, test.ShouldEqual, len(tc.inputs))
+		for i, col := range numericJacobian(tc.model, tc.inputs) {
+			for j, expected := range col {
+				test.That(t, jac.At(j, i), test.ShouldAlmostEqual, expected, 1e-3)
+			}
+		}
+	}
+
+	_, err = ur5e.Jacobian(FloatsToInputs([]float64{0}))
+	test.That(t, err, test.ShouldBeError, NewIncorrectInputLengthError(1, 6))
+}
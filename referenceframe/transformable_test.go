@@ -41,3 +41,48 @@ func TestGeometriesInFrame(t *testing.T) {
 	test.That(t, gF.Parent(), test.ShouldEqual, convertedGF.Parent())
 	test.That(t, spatial.GeometriesAlmostEqual(one, convertedGF.GeometryByName("one")), test.ShouldBeTrue)
 }
+
+func TestGeometriesInFrameOrientation(t *testing.T) {
+	// tilted boxes, including ones turned upside down, where the orientation vector sits on the pole
+	orientations := []spatial.Orientation{
+		&spatial.OrientationVectorDegrees{OX: 1, OY: 1, OZ: 1, Theta: 30},
+		&spatial.EulerAngles{Roll: 0.3, Pitch: 0.2, Yaw: 1.1},
+		&spatial.R4AA{Theta: math.Pi, RX: 1},
+		&spatial.R4AA{Theta: math.Pi, RY: 1},
+		&spatial.R4AA{Theta: math.Pi / 2, RY: 1},
+	}
+	geometries := make([]spatial.Geometry, 0, len(orientations))
+	for i, o := range orientations {
+		box, err := spatial.NewBox(spatial.NewPose(r3.Vector{1, 2, 3}, o), r3.Vector{10, 20, 30}, string(rune('a'+i)))
+		test.That(t, err, test.ShouldBeNil)
+		geometries = append(geometries, box)
+	}
+	gF := NewGeometriesInFrame("frame", geometries)
+
+	checkOrientation := func(converted *GeometriesInFrame) {
+		test.That(t, len(converted.Geometries()), test.ShouldEqual, len(geometries))
+		for _, geometry := range geometries {
+			convertedGeometry := converted.GeometryByName(geometry.Label())
+			test.That(t, spatial.GeometriesAlmostEqual(geometry, convertedGeometry), test.ShouldBeTrue)
+			// the corners of the box are where they were, rather than where they would be if it were axis aligned
+			points, convertedPoints := geometry.ToPoints(1), convertedGeometry.ToPoints(1)
+			test.That(t, len(convertedPoints), test.ShouldEqual, len(points))
+			for i, point := range points {
+				test.That(t, spatial.R3VectorAlmostEqual(point, convertedPoints[i], 1e-6), test.ShouldBeTrue)
+			}
+		}
+	}
+
+	converted, err := ProtobufToGeometriesInFrame(GeometriesInFrameToProtobuf(gF))
+	test.That(t, err, test.ShouldBeNil)
+	checkOrientation(converted)
+
+	ws, err := NewWorldState([]*GeometriesInFrame{gF}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	proto, err := ws.ToProtobuf()
+	test.That(t, err, test.ShouldBeNil)
+	convertedWS, err := WorldStateFromProtobuf(proto)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(convertedWS.obstacles), test.ShouldEqual, 1)
+	checkOrientation(convertedWS.obstacles[0])
+}
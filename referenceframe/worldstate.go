@@ -2,6 +2,7 @@ package referenceframe
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -60,6 +61,33 @@ func NewWorldState(obstacles []*GeometriesInFrame, transforms []*LinkInFrame) (*
 	return ws, nil
 }
 
+// NewWorldStateProtobuf builds the protobuf definition of a WorldState from a map of obstacles in the world frame, keyed by name.
+// Copies of the geometries are labeled with their key, so any geometry which already carries a different label is rejected.
+func NewWorldStateProtobuf(obstacles map[string]spatialmath.Geometry) (*commonpb.WorldState, error) {
+	names := make([]string, 0, len(obstacles))
+	for name := range obstacles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	geometries := make([]spatialmath.Geometry, 0, len(obstacles))
+	for _, name := range names {
+		geometry := obstacles[name]
+		if label := geometry.Label(); label != "" && label != name {
+			return nil, fmt.Errorf("geometry %q is already labeled %q", name, label)
+		}
+		// label a copy, so that the caller's geometry is left as it was
+		labeled := geometry.Transform(spatialmath.NewZeroPose())
+		labeled.SetLabel(name)
+		geometries = append(geometries, labeled)
+	}
+	ws, err := NewWorldState([]*GeometriesInFrame{NewGeometriesInFrame(World, geometries)}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ws.ToProtobuf()
+}
+
 // WorldStateFromProtobuf takes the protobuf definition of a WorldState and converts it to a rdk defined WorldState.
 func WorldStateFromProtobuf(proto *commonpb.WorldState) (*WorldState, error) {
 	transforms, err := LinkInFramesFromTransformsProtobuf(proto.GetTransforms())
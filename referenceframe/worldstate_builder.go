@@ -0,0 +1,141 @@
+package referenceframe
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+// WorldStateBuilder incrementally builds a WorldState from named obstacles, such as those detected by a vision service, which are
+// added, updated and removed as they change rather than by rebuilding the whole WorldState. Each obstacle is converted to protobuf
+// once when it is set, so producing the protobuf of a WorldState only converts the obstacles which changed since the last one.
+// It is safe for concurrent use.
+type WorldStateBuilder struct {
+	mu         sync.Mutex
+	obstacles  map[string]worldStateObstacle
+	transforms []*LinkInFrame
+}
+
+// worldStateObstacle is a named obstacle of a WorldStateBuilder, along with the frame it is in and its protobuf representation.
+type worldStateObstacle struct {
+	parent   string
+	geometry spatialmath.Geometry
+	proto    *commonpb.Geometry
+}
+
+// NewWorldStateBuilder returns a WorldStateBuilder with no obstacles or transforms.
+func NewWorldStateBuilder() *WorldStateBuilder {
+	return &WorldStateBuilder{obstacles: make(map[string]worldStateObstacle)}
+}
+
+// Set adds the given obstacles in the parent frame, keyed by name, replacing any obstacles that already have those names. An empty
+// parent is the world frame. Geometries are labeled with their key, so any geometry which already carries a different label is
+// rejected, in which case none of the obstacles are set.
+func (b *WorldStateBuilder) Set(parent string, obstacles map[string]spatialmath.Geometry) error {
+	if parent == "" {
+		parent = World
+	}
+	for name, geometry := range obstacles {
+		if name == "" {
+			return fmt.Errorf("obstacles in frame %q must be named", parent)
+		}
+		if label := geometry.Label(); label != "" && label != name {
+			return fmt.Errorf("geometry %q is already labeled %q", name, label)
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for name, geometry := range obstacles {
+		geometry.SetLabel(name)
+		b.obstacles[name] = worldStateObstacle{parent: parent, geometry: geometry, proto: geometry.ToProtobuf()}
+	}
+	return nil
+}
+
+// Remove removes the obstacles with the given names. Names which aren't obstacles of the builder are ignored.
+func (b *WorldStateBuilder) Remove(names ...string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, name := range names {
+		delete(b.obstacles, name)
+	}
+}
+
+// SetTransforms replaces the transforms of the WorldStates built.
+func (b *WorldStateBuilder) SetTransforms(transforms []*LinkInFrame) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.transforms = transforms
+}
+
+// WorldState returns a snapshot of the obstacles and transforms of the builder, which can be planned against. Later changes to
+// the builder do not affect it.
+func (b *WorldStateBuilder) WorldState() *WorldState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ws := &WorldState{
+		obstacleNames: make(map[string]bool, len(b.obstacles)),
+		obstacles:     make([]*GeometriesInFrame, 0),
+		transforms:    append([]*LinkInFrame{}, b.transforms...),
+	}
+	b.forEachFrame(func(parent string, obstacles []worldStateObstacle) {
+		geometries := make([]spatialmath.Geometry, 0, len(obstacles))
+		for _, obstacle := range obstacles {
+			ws.obstacleNames[obstacle.geometry.Label()] = true
+			geometries = append(geometries, obstacle.geometry)
+		}
+		ws.obstacles = append(ws.obstacles, NewGeometriesInFrame(parent, geometries))
+	})
+	return ws
+}
+
+// ToProtobuf returns the protobuf definition of a snapshot of the obstacles and transforms of the builder. The geometries in it
+// are shared with other protobufs produced by the builder and must not be modified.
+func (b *WorldStateBuilder) ToProtobuf() (*commonpb.WorldState, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	transforms, err := LinkInFramesToTransformsProtobuf(b.transforms)
+	if err != nil {
+		return nil, err
+	}
+	proto := &commonpb.WorldState{Obstacles: make([]*commonpb.GeometriesInFrame, 0), Transforms: transforms}
+	b.forEachFrame(func(parent string, obstacles []worldStateObstacle) {
+		geometries := make([]*commonpb.Geometry, 0, len(obstacles))
+		for _, obstacle := range obstacles {
+			geometries = append(geometries, obstacle.proto)
+		}
+		proto.Obstacles = append(proto.Obstacles, &commonpb.GeometriesInFrame{ReferenceFrame: parent, Geometries: geometries})
+	})
+	return proto, nil
+}
+
+// forEachFrame calls fn with the obstacles in each frame, ordered by name, with the frames also ordered by name so that the
+// WorldStates built are the same for the same obstacles. The lock must be held.
+func (b *WorldStateBuilder) forEachFrame(fn func(parent string, obstacles []worldStateObstacle)) {
+	names := make([]string, 0, len(b.obstacles))
+	for name := range b.obstacles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	byFrame := make(map[string][]worldStateObstacle)
+	parents := make([]string, 0)
+	for _, name := range names {
+		obstacle := b.obstacles[name]
+		if _, ok := byFrame[obstacle.parent]; !ok {
+			parents = append(parents, obstacle.parent)
+		}
+		byFrame[obstacle.parent] = append(byFrame[obstacle.parent], obstacle)
+	}
+	sort.Strings(parents)
+	for _, parent := range parents {
+		fn(parent, byFrame[parent])
+	}
+}
@@ -0,0 +1,68 @@
+package referenceframe
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/spatialmath"
+)
+
+func TestWorldStateBuilder(t *testing.T) {
+	newBox := func(x float64) spatialmath.Geometry {
+		box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: x}), r3.Vector{X: 10, Y: 10, Z: 10}, "")
+		test.That(t, err, test.ShouldBeNil)
+		return box
+	}
+	fs := NewEmptyFrameSystem("test")
+	offset, err := NewStaticFrame("offset", spatialmath.NewPoseFromPoint(r3.Vector{Y: 100}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(offset, fs.World()), test.ShouldBeNil)
+
+	builder := NewWorldStateBuilder()
+	ws := builder.WorldState()
+	test.That(t, ws.ObstacleNames(), test.ShouldBeEmpty)
+
+	test.That(t, builder.Set("", map[string]spatialmath.Geometry{"a": newBox(100), "b": newBox(200)}), test.ShouldBeNil)
+	test.That(t, builder.Set("offset", map[string]spatialmath.Geometry{"c": newBox(300)}), test.ShouldBeNil)
+	ws = builder.WorldState()
+	test.That(t, ws.ObstacleNames(), test.ShouldResemble, map[string]bool{"a": true, "b": true, "c": true})
+	obstacles, err := ws.ObstaclesInWorldFrame(fs, StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(obstacles.Geometries()), test.ShouldEqual, 3)
+
+	proto, err := builder.ToProtobuf()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(proto.GetObstacles()), test.ShouldEqual, 2)
+	test.That(t, proto.GetObstacles()[0].GetReferenceFrame(), test.ShouldEqual, "offset")
+	test.That(t, proto.GetObstacles()[1].GetReferenceFrame(), test.ShouldEqual, World)
+	test.That(t, proto.GetObstacles()[1].GetGeometries()[0].GetLabel(), test.ShouldEqual, "a")
+	fromProto, err := WorldStateFromProtobuf(proto)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fromProto.ObstacleNames(), test.ShouldResemble, ws.ObstacleNames())
+
+	// moving an obstacle and removing another only changes those obstacles, and earlier snapshots are unaffected
+	test.That(t, builder.Set("", map[string]spatialmath.Geometry{"c": newBox(400)}), test.ShouldBeNil)
+	builder.Remove("b", "nonexistent")
+	updated, err := builder.ToProtobuf()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(updated.GetObstacles()), test.ShouldEqual, 1)
+	test.That(t, len(updated.GetObstacles()[0].GetGeometries()), test.ShouldEqual, 2)
+	test.That(t, updated.GetObstacles()[0].GetGeometries()[0], test.ShouldEqual, proto.GetObstacles()[1].GetGeometries()[0])
+	test.That(t, builder.WorldState().ObstacleNames(), test.ShouldResemble, map[string]bool{"a": true, "c": true})
+	test.That(t, ws.ObstacleNames(), test.ShouldResemble, map[string]bool{"a": true, "b": true, "c": true})
+
+	link := NewLinkInFrame(World, spatialmath.NewZeroPose(), "link", nil)
+	builder.SetTransforms([]*LinkInFrame{link})
+	test.That(t, builder.WorldState().Transforms(), test.ShouldResemble, []*LinkInFrame{link})
+
+	labeled, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "sphere")
+	test.That(t, err, test.ShouldBeNil)
+	err = builder.Set("", map[string]spatialmath.Geometry{"d": labeled})
+	test.That(t, err, test.ShouldBeError, errors.New(`geometry "d" is already labeled "sphere"`))
+	err = builder.Set("offset", map[string]spatialmath.Geometry{"": newBox(0)})
+	test.That(t, err, test.ShouldBeError, errors.New(`obstacles in frame "offset" must be named`))
+	test.That(t, builder.WorldState().ObstacleNames(), test.ShouldResemble, map[string]bool{"a": true, "c": true})
+}
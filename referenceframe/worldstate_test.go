@@ -1,9 +1,11 @@
 package referenceframe
 
 import (
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/golang/geo/r3"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"go.viam.com/test"
 
@@ -44,6 +46,29 @@ func TestWorldStateConstruction(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestNewWorldStateProtobuf(t *testing.T) {
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: 100}), r3.Vector{X: 10, Y: 10, Z: 10}, "")
+	test.That(t, err, test.ShouldBeNil)
+	sphere, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "sphere")
+	test.That(t, err, test.ShouldBeNil)
+
+	proto, err := NewWorldStateProtobuf(map[string]spatialmath.Geometry{"sphere": sphere, "box": box})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(proto.GetObstacles()), test.ShouldEqual, 1)
+	test.That(t, proto.GetObstacles()[0].GetReferenceFrame(), test.ShouldEqual, World)
+	test.That(t, proto.GetObstacles()[0].GetGeometries()[0].GetLabel(), test.ShouldEqual, "box")
+	// the geometries passed in are not relabeled
+	test.That(t, box.Label(), test.ShouldEqual, "")
+
+	ws, err := WorldStateFromProtobuf(proto)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ws.ObstacleNames(), test.ShouldResemble, map[string]bool{"box": true, "sphere": true})
+
+	// a geometry which is already labeled cannot be used under another name
+	_, err = NewWorldStateProtobuf(map[string]spatialmath.Geometry{"sphere": sphere, "ball": sphere})
+	test.That(t, err, test.ShouldBeError, errors.New("geometry \"ball\" is already labeled \"sphere\""))
+}
+
 func TestString(t *testing.T) {
 	foo, err := spatialmath.NewSphere(spatialmath.NewZeroPose(), 10, "foo")
 	test.That(t, err, test.ShouldBeNil)
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"image/draw"
 	"io"
 	"math"
 	"os"
@@ -108,6 +109,66 @@ func (params *PinholeCameraModel) UndistortDepthMap(dm *rimage.DepthMap) (*rimag
 	return undistortedDm, nil
 }
 
+// UndistortionMap is a precomputed lookup from each pixel of an undistorted image to the pixel of the distorted image it is
+// read from, so that many images of the same size can be undistorted without evaluating the distortion model for every pixel.
+type UndistortionMap struct {
+	width, height int
+	// src holds the offset of the nearest distorted pixel for each undistorted pixel in row-major order, or -1 for pixels
+	// which fall outside of the distorted image.
+	src []int
+}
+
+// UndistortionMap precomputes the nearest neighbor lookup used by UndistortImage for images of the size of the intrinsics.
+func (params *PinholeCameraModel) UndistortionMap() (*UndistortionMap, error) {
+	if err := params.PinholeCameraIntrinsics.CheckValid(); err != nil {
+		return nil, err
+	}
+	if params.Distortion == nil {
+		return nil, errors.New("cannot undistort without a distortion model")
+	}
+	m := &UndistortionMap{width: params.Width, height: params.Height, src: make([]int, params.Width*params.Height)}
+	distortionMap := params.DistortionMap()
+	maxX, maxY := float64(params.Width-1), float64(params.Height-1)
+	for v := 0; v < params.Height; v++ {
+		for u := 0; u < params.Width; u++ {
+			i := v*params.Width + u
+			x, y := distortionMap(float64(u), float64(v))
+			if x < 0 || y < 0 || x > maxX || y > maxY {
+				m.src[i] = -1
+				continue
+			}
+			m.src[i] = int(math.Round(y))*params.Width + int(math.Round(x))
+		}
+	}
+	return m, nil
+}
+
+// Undistort returns a new image undistorted by the map. Pixels which fall outside of the distorted image are left black.
+func (m *UndistortionMap) Undistort(img image.Image) (*image.RGBA, error) {
+	if img == nil {
+		return nil, errors.New("input image is nil")
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() != m.width || bounds.Dy() != m.height {
+		return nil, errors.Errorf("img dimension and intrinsics don't match Image(%d,%d) != Intrinsics(%d,%d)",
+			bounds.Dx(), bounds.Dy(), m.width, m.height)
+	}
+	in, ok := img.(*image.RGBA)
+	if !ok || in.Stride != 4*m.width {
+		in = image.NewRGBA(image.Rect(0, 0, m.width, m.height))
+		draw.Draw(in, in.Bounds(), img, bounds.Min, draw.Src)
+	}
+	out := image.NewRGBA(image.Rect(0, 0, m.width, m.height))
+	for i, src := range m.src {
+		if src < 0 {
+			out.Pix[4*i+3] = 0xff
+			continue
+		}
+		copy(out.Pix[4*i:4*i+4], in.Pix[4*src:4*src+4])
+	}
+	return out, nil
+}
+
 // PinholeCameraIntrinsics holds the parameters necessary to do a perspective projection of a 3D scene to the 2D plane.
 type PinholeCameraIntrinsics struct {
 	Width  int     `json:"width_px"`
@@ -3,6 +3,7 @@ package transform
 import (
 	"context"
 	"image"
+	"image/color"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -188,6 +189,56 @@ func TestUndistortDepthMap(t *testing.T) {
 	test.That(t, corrected.GetDepth(1279, 719), test.ShouldEqual, img.GetDepth(1279, 719))
 }
 
+func TestUndistortionMap(t *testing.T) {
+	params := &PinholeCameraIntrinsics{
+		Width:  800,
+		Height: 600,
+		Fx:     887.07855759,
+		Fy:     886.579955,
+		Ppx:    382.80075175,
+		Ppy:    302.75546742,
+	}
+	distortion := &BrownConrady{
+		RadialK1:     -0.42333866,
+		RadialK2:     0.25696641,
+		TangentialP1: 0.00142052,
+		TangentialP2: -0.00116427,
+		RadialK3:     -0.06468911,
+	}
+	pinhole := &PinholeCameraModel{PinholeCameraIntrinsics: params, Distortion: distortion}
+
+	// the model must be complete
+	_, err := (&PinholeCameraModel{Distortion: distortion}).UndistortionMap()
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = (&PinholeCameraModel{PinholeCameraIntrinsics: params}).UndistortionMap()
+	test.That(t, err.Error(), test.ShouldContainSubstring, "without a distortion model")
+
+	undistortion, err := pinhole.UndistortionMap()
+	test.That(t, err, test.ShouldBeNil)
+	_, err = undistortion.Undistort(nil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "input image is nil")
+	_, err = undistortion.Undistort(image.NewRGBA(image.Rect(0, 0, 1280, 720)))
+	test.That(t, err.Error(), test.ShouldContainSubstring, "img dimension and intrinsics don't match")
+
+	// the map reads the same pixels as UndistortImage
+	img := image.NewRGBA(image.Rect(0, 0, 800, 600))
+	for y := 0; y < 600; y++ {
+		for x := 0; x < 800; x++ {
+			img.Set(x, y, color.RGBA{uint8(x), uint8(y), uint8(x + y), 0xff})
+		}
+	}
+	expected, err := pinhole.UndistortImage(rimage.ConvertImage(img))
+	test.That(t, err, test.ShouldBeNil)
+	corrected, err := undistortion.Undistort(img)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, corrected.Bounds(), test.ShouldResemble, img.Bounds())
+	for _, pt := range []image.Point{{0, 0}, {400, 300}, {120, 80}, {799, 599}, {700, 20}} {
+		r, g, b, _ := corrected.At(pt.X, pt.Y).RGBA()
+		er, eg, eb, _ := expected.At(pt.X, pt.Y).RGBA()
+		test.That(t, []uint32{r >> 8, g >> 8, b >> 8}, test.ShouldResemble, []uint32{er >> 8, eg >> 8, eb >> 8})
+	}
+}
+
 func TestGetCameraMatrix(t *testing.T) {
 	intrinsics := &PinholeCameraIntrinsics{
 		Width:  0,
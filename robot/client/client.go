@@ -251,7 +251,7 @@ func New(ctx context.Context, address string, clientLogger logging.ZapCompatible
 		rpc.WithUnaryClientInterceptor(logging.UnaryClientInterceptor),
 	)
 
-	if err := rc.connect(ctx); err != nil {
+	if err := rc.initialConnect(ctx, rOpts.connectAttempts, rOpts.connectBackoff, rOpts.connectTimeout); err != nil {
 		return nil, err
 	}
 
@@ -338,6 +338,37 @@ func (rc *RobotClient) connect(ctx context.Context) error {
 	return nil
 }
 
+// initialConnect dials the robot for the first time, making up to `attempts` attempts which each take at most `timeout`. The
+// wait between attempts starts at `backoff` and doubles after each failure.
+func (rc *RobotClient) initialConnect(ctx context.Context, attempts int, backoff, timeout time.Duration) error {
+	if attempts < 1 {
+		attempts = 1
+	}
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			if !utils.SelectContextOrWait(ctx, backoff) {
+				return multierr.Combine(err, ctx.Err())
+			}
+			backoff *= 2
+		}
+		attemptCtx, cancel := ctx, func() {}
+		if timeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, timeout)
+		}
+		err = rc.connect(attemptCtx)
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if attempts > 1 {
+			err = errors.Wrapf(err, "connection attempt %d of %d failed", attempt, attempts)
+			rc.Logger().CDebugw(ctx, "failed to connect to remote", "address", rc.address, "error", err)
+		}
+	}
+	return err
+}
+
 func (rc *RobotClient) connectWithLock(ctx context.Context) error {
 	rc.mu.Lock()
 	defer rc.mu.Unlock()
@@ -32,6 +32,15 @@ type robotClientOpts struct {
 
 	// controls whether or not sessions are disabled.
 	disableSessions bool
+
+	// connectAttempts is how many times to try the initial dial to the robot. If <=1, it is tried once.
+	connectAttempts int
+
+	// connectBackoff is how long to wait before retrying the initial dial. It doubles after each failed attempt.
+	connectBackoff time.Duration
+
+	// connectTimeout bounds each attempt at the initial dial. If <=0, attempts are bounded only by the context passed to New.
+	connectTimeout time.Duration
 }
 
 // RobotClientOption configures how we set up the connection.
@@ -92,6 +101,23 @@ func WithDisableSessions() RobotClientOption {
 	})
 }
 
+// WithConnectRetry returns a RobotClientOption which retries the initial dial to the robot up to `attempts` times in total,
+// waiting `backoff` before the first retry and doubling the wait after each further failure. It does not affect RPCs made once
+// the client is connected.
+func WithConnectRetry(attempts int, backoff time.Duration) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.connectAttempts = attempts
+		o.connectBackoff = backoff
+	})
+}
+
+// WithConnectTimeout returns a RobotClientOption which bounds how long each attempt at the initial dial to the robot may take.
+func WithConnectTimeout(timeout time.Duration) RobotClientOption {
+	return newFuncRobotClientOption(func(o *robotClientOpts) {
+		o.connectTimeout = timeout
+	})
+}
+
 // WithDialOptions returns a RobotClientOption which sets the options for making
 // gRPC connections to other servers.
 func WithDialOptions(opts ...rpc.DialOption) RobotClientOption {
@@ -1215,6 +1215,48 @@ func TestClientDialerOption(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestClientConnectRetry(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	addr := listener.Addr().String()
+	test.That(t, listener.Close(), test.ShouldBeNil)
+
+	t.Run("fails after all attempts", func(t *testing.T) {
+		_, err := New(context.Background(), addr, logger, WithConnectRetry(2, 10*time.Millisecond), WithConnectTimeout(250*time.Millisecond))
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "connection attempt 2 of 2 failed")
+	})
+
+	t.Run("succeeds once the robot is up", func(t *testing.T) {
+		injectRobot := &inject.Robot{
+			ResourceNamesFunc:   func() []resource.Name { return emptyResources },
+			ResourceRPCAPIsFunc: func() []resource.RPCAPI { return nil },
+		}
+		gServer := grpc.NewServer()
+		pb.RegisterRobotServiceServer(gServer, server.New(injectRobot))
+		defer gServer.Stop()
+
+		var served sync.WaitGroup
+		served.Add(1)
+		go func() {
+			defer served.Done()
+			time.Sleep(200 * time.Millisecond)
+			listener, err := net.Listen("tcp", addr)
+			if err != nil {
+				return
+			}
+			gServer.Serve(listener)
+		}()
+
+		client, err := New(context.Background(), addr, logger, WithConnectRetry(10, 50*time.Millisecond), WithConnectTimeout(250*time.Millisecond))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, client.Close(context.Background()), test.ShouldBeNil)
+		gServer.Stop()
+		served.Wait()
+	})
+}
+
 func TestClientResources(t *testing.T) {
 	injectRobot := &inject.Robot{}
 
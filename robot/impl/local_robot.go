@@ -7,6 +7,8 @@ package robotimpl
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -1045,9 +1047,47 @@ func RobotFromResources(
 	logger logging.Logger,
 	opts ...Option,
 ) (robot.LocalRobot, error) {
+	if err := validateResources(resources); err != nil {
+		return nil, err
+	}
 	return newWithResources(ctx, &config.Config{}, resources, logger, opts...)
 }
 
+// validateResources checks that every resource passed to RobotFromResources is non-nil, is keyed by a well-formed name that
+// matches the resource's own name, and appears only once.
+func validateResources(resources map[resource.Name]resource.Resource) error {
+	names := make([]resource.Name, 0, len(resources))
+	for name := range resources {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+
+	seen := map[uintptr]resource.Name{}
+	for _, name := range names {
+		if err := name.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid resource name %q", name)
+		}
+		res := resources[name]
+		if res == nil {
+			return errors.Errorf("resource %q is nil", name)
+		}
+		value := reflect.ValueOf(res)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return errors.Errorf("resource %q is nil", name)
+			}
+			if other, ok := seen[value.Pointer()]; ok {
+				return errors.Errorf("resource %q is the same resource as %q", name, other)
+			}
+			seen[value.Pointer()] = name
+		}
+		if resName := res.Name().Name; resName != name.Name {
+			return errors.Errorf("resource %q is named %q", name, resName)
+		}
+	}
+	return nil
+}
+
 // DiscoverComponents takes a list of discovery queries and returns corresponding
 // component configurations.
 func (r *localRobot) DiscoverComponents(ctx context.Context, qs []resource.DiscoveryQuery) ([]resource.Discovery, error) {
@@ -1091,6 +1091,49 @@ func TestStatus(t *testing.T) {
 	})
 }
 
+func TestRobotFromResourcesValidation(t *testing.T) {
+	logger := logging.NewTestLogger(t)
+	arm1 := arm.Named("arm1")
+	arm2 := arm.Named("arm2")
+	res1 := rtestutils.NewUnimplementedResource(arm1)
+
+	var nilArm *inject.Arm
+	for _, tc := range []struct {
+		name      string
+		resources map[resource.Name]resource.Resource
+		err       string
+	}{
+		{"nil resource", map[resource.Name]resource.Resource{arm1: nil}, `resource "rdk:component:arm/arm1" is nil`},
+		{"typed nil resource", map[resource.Name]resource.Resource{arm1: nilArm}, `resource "rdk:component:arm/arm1" is nil`},
+		{
+			"duplicate resource",
+			map[resource.Name]resource.Resource{arm1: res1, arm2: res1},
+			`resource "rdk:component:arm/arm2" is the same resource as "rdk:component:arm/arm1"`,
+		},
+		{
+			"mismatched name",
+			map[resource.Name]resource.Resource{arm2: rtestutils.NewUnimplementedResource(arm1)},
+			`resource "rdk:component:arm/arm2" is named "arm1"`,
+		},
+		{
+			"malformed name",
+			map[resource.Name]resource.Resource{arm.Named(""): rtestutils.NewUnimplementedResource(arm.Named(""))},
+			"name field for resource is empty",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := RobotFromResources(context.Background(), tc.resources, logger)
+			test.That(t, r, test.ShouldBeNil)
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+		})
+	}
+
+	r, err := RobotFromResources(context.Background(), map[resource.Name]resource.Resource{arm1: res1}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+}
+
 func TestStatusRemote(t *testing.T) {
 	logger := logging.NewTestLogger(t)
 	// set up remotes
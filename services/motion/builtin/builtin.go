@@ -222,7 +222,7 @@ func (ms *builtIn) Move(
 		Constraints:        constraints,
 		Options:            extra,
 	})
-	if err != nil {
+	if err != nil && !errors.Is(err, motionplan.ErrPlanSuboptimal) {
 		return false, err
 	}
 
@@ -121,7 +121,9 @@ func (e *execution[R]) newPlanWithExecutor(ctx context.Context, seedPlan motionp
 		return planWithExecutor{}, err
 	}
 	plan, err := pe.Plan(ctx)
-	if err != nil {
+	if errors.Is(err, motionplan.ErrPlanSuboptimal) {
+		e.logger.CDebug(ctx, err.Error())
+	} else if err != nil {
 		return planWithExecutor{}, err
 	}
 	return planWithExecutor{
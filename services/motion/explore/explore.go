@@ -243,7 +243,7 @@ func (ms *explore) Move(
 
 	// Create motionplan plan
 	plan, err := ms.createMotionPlan(ctx, kb, destination, extra)
-	if err != nil {
+	if err != nil && !errors.Is(err, motionplan.ErrPlanSuboptimal) {
 		return false, err
 	}
 
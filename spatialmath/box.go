@@ -193,6 +193,11 @@ func (b *box) EncompassedBy(g Geometry) (bool, error) {
 	return false, newCollisionTypeUnsupportedError(b, g)
 }
 
+// Contains returns whether the point is inside the box, or on its surface.
+func (b *box) Contains(pt r3.Vector) (bool, error) {
+	return pointVsBoxCollision(pt, b, defaultCollisionBufferMM), nil
+}
+
 // closestPoint returns the closest point on the specified box to the specified point
 // Reference: https://github.com/gszauer/GamePhysicsCookbook/blob/a0b8ee0c39fed6d4b90bb6d2195004dfcf5a1115/Code/Geometry3D.cpp#L165
 func (b *box) closestPoint(pt r3.Vector) r3.Vector {
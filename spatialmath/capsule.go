@@ -131,7 +131,7 @@ func (c *capsule) Transform(toPremultiply Pose) Geometry {
 	}
 }
 
-// ToProto converts the capsule to a Geometry proto message.
+// ToProtobuf converts the capsule to a Geometry proto message.
 func (c *capsule) ToProtobuf() *commonpb.Geometry {
 	return &commonpb.Geometry{
 		Center: PoseToProtobuf(c.pose),
@@ -231,6 +231,11 @@ func (c *capsule) rotationMatrix() *RotationMatrix {
 	return c.rotMatrix
 }
 
+// Contains returns whether the point is inside the capsule, or on its surface.
+func (c *capsule) Contains(pt r3.Vector) (bool, error) {
+	return capsuleVsPointDistance(c, pt) <= defaultCollisionBufferMM, nil
+}
+
 func capsuleVsPointDistance(c *capsule, other r3.Vector) float64 {
 	return DistToLineSegment(c.segA, c.segB, other) - c.radius
 }
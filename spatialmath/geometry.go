@@ -28,6 +28,9 @@ type Geometry interface {
 	// EncompassedBy returns a bool describing if a given Geometry is completely encompassed by the Geometry passed as an argument.
 	EncompassedBy(Geometry) (bool, error)
 
+	// Contains returns a bool describing if the given point is inside the Geometry. Points on the surface of the Geometry are inside it.
+	Contains(r3.Vector) (bool, error)
+
 	// SetLabel sets the name of the geometry
 	SetLabel(string)
 
@@ -182,25 +185,36 @@ func GeometriesAlmostEqual(a, b Geometry) bool {
 	}
 }
 
-// NewGeometryFromProto instantiates a new Geometry from a protobuf Geometry message.
+// NewGeometryFromProto instantiates a new Geometry from a protobuf Geometry message. A sphere with a radius of zero is read back as
+// a point.
 func NewGeometryFromProto(geometry *commonpb.Geometry) (Geometry, error) {
+	if geometry == nil {
+		return nil, errors.New("cannot convert nil protobuf geometry")
+	}
 	if geometry.Center == nil {
 		return nil, errors.New("cannot have nil pose for geometry")
 	}
 	pose := NewPoseFromProtobuf(geometry.Center)
-	if box := geometry.GetBox().GetDimsMm(); box != nil {
-		return NewBox(pose, r3.Vector{X: box.X, Y: box.Y, Z: box.Z}, geometry.Label)
-	}
-	if capsule := geometry.GetCapsule(); capsule != nil {
-		return NewCapsule(pose, capsule.RadiusMm, capsule.LengthMm, geometry.Label)
-	}
-	if sphere := geometry.GetSphere(); sphere != nil {
-		if sphere.RadiusMm == 0 {
+	switch geometryType := geometry.GeometryType.(type) {
+	case *commonpb.Geometry_Box:
+		dims := geometryType.Box.GetDimsMm()
+		if dims == nil {
+			return nil, errors.New("cannot have nil dimensions for box geometry")
+		}
+		return NewBox(pose, r3.Vector{X: dims.X, Y: dims.Y, Z: dims.Z}, geometry.Label)
+	case *commonpb.Geometry_Capsule:
+		if geometryType.Capsule == nil {
+			return nil, errors.New("cannot have nil dimensions for capsule geometry")
+		}
+		return NewCapsule(pose, geometryType.Capsule.RadiusMm, geometryType.Capsule.LengthMm, geometry.Label)
+	case *commonpb.Geometry_Sphere:
+		if geometryType.Sphere.GetRadiusMm() == 0 {
 			return NewPoint(pose.Point(), geometry.Label), nil
 		}
-		return NewSphere(pose, sphere.RadiusMm, geometry.Label)
+		return NewSphere(pose, geometryType.Sphere.RadiusMm, geometry.Label)
+	default:
+		return nil, errGeometryTypeUnsupported
 	}
-	return nil, errGeometryTypeUnsupported
 }
 
 // NewGeometriesFromProto converts a list of Geometries from protobuf.
@@ -66,12 +66,24 @@ func TestGeometrySerializationJSON(t *testing.T) {
 
 func TestGeometryToFromProtobuf(t *testing.T) {
 	deg45 := math.Pi / 4
+	oriented := &OrientationVectorDegrees{OX: 1, OY: -2, OZ: 0.5, Theta: 37}
+	orientedCapsule, err := NewCapsule(NewPose(r3.Vector{-7, 8, 9}, oriented), 4, 20, "oriented capsule")
+	test.That(t, err, test.ShouldBeNil)
+	orientedSphere, err := NewSphere(NewPose(r3.Vector{3, 4, 5}, oriented), 10, "oriented sphere")
+	test.That(t, err, test.ShouldBeNil)
+	capsule, err := NewCapsule(NewPoseFromPoint(r3.Vector{1, 2, 3}), 2, 10, "capsule")
+	test.That(t, err, test.ShouldBeNil)
 	testCases := []struct {
 		name     string
 		geometry Geometry
 	}{
 		{"box", makeTestBox(&EulerAngles{0, 0, deg45}, r3.Vector{0, 0, 0}, r3.Vector{2, 2, 2}, "box")},
+		{"axis aligned box", makeTestBox(NewZeroOrientation(), r3.Vector{10, -20, 30}, r3.Vector{1, 2, 3}, "axis aligned box")},
+		{"oriented box", makeTestBox(oriented, r3.Vector{-10, 20, 3.5}, r3.Vector{4, 0.5, 12}, "oriented box")},
 		{"sphere", makeTestSphere(r3.Vector{3, 4, 5}, 10, "sphere")},
+		{"oriented sphere", orientedSphere},
+		{"capsule", capsule},
+		{"oriented capsule", orientedCapsule},
 		{"point", NewPoint(r3.Vector{3, 4, 5}, "point")},
 	}
 	for _, testCase := range testCases {
@@ -80,12 +92,22 @@ func TestGeometryToFromProtobuf(t *testing.T) {
 			test.That(t, err, test.ShouldBeNil)
 			test.That(t, GeometriesAlmostEqual(testCase.geometry, newVol), test.ShouldBeTrue)
 			test.That(t, testCase.geometry.Label(), test.ShouldEqual, testCase.name)
+			test.That(t, PoseAlmostEqual(newVol.Pose(), testCase.geometry.Pose()), test.ShouldBeTrue)
+			test.That(t, newVol.Label(), test.ShouldEqual, testCase.geometry.Label())
 		})
 	}
 
 	// test that bad message does not generate error
-	_, err := NewGeometryFromProto(&commonpb.Geometry{Center: PoseToProtobuf(NewZeroPose())})
+	_, err = NewGeometryFromProto(&commonpb.Geometry{Center: PoseToProtobuf(NewZeroPose())})
 	test.That(t, err.Error(), test.ShouldContainSubstring, errGeometryTypeUnsupported.Error())
+
+	_, err = NewGeometryFromProto(nil)
+	test.That(t, err, test.ShouldBeError, errors.New("cannot convert nil protobuf geometry"))
+	_, err = NewGeometryFromProto(&commonpb.Geometry{
+		Center:       PoseToProtobuf(NewZeroPose()),
+		GeometryType: &commonpb.Geometry_Box{Box: &commonpb.RectangularPrism{}},
+	})
+	test.That(t, err, test.ShouldBeError, errors.New("cannot have nil dimensions for box geometry"))
 }
 
 type geometryComparisonTestCase struct {
@@ -577,6 +599,14 @@ func TestCapsuleVsBoxCollision(t *testing.T) {
 			},
 			math.Sqrt(3),
 		},
+		{
+			"separated rotated box vertex closest",
+			[2]Geometry{
+				makeTestCapsule(&OrientationVector{0, 1, 0, 0}, r3.Vector{math.Sqrt2 + 3, 0, 0}, 1, 4),
+				makeTestBox(&OrientationVector{math.Pi / 4, 0, 0, 1}, r3.Vector{}, r3.Vector{2, 2, 2}, ""),
+			},
+			1,
+		},
 		{
 			"face tangent",
 			[2]Geometry{
@@ -802,6 +832,79 @@ func TestCapsuleVsPointEncompassed(t *testing.T) {
 	testGeometryEncompassed(t, cases)
 }
 
+func TestGeometryContains(t *testing.T) {
+	// a box turned 45 degrees about z, so that its faces are not aligned with the axes
+	box, err := NewBox(NewPose(r3.Vector{100, 0, 0}, &OrientationVectorDegrees{OZ: 1, Theta: 45}), r3.Vector{20, 20, 20}, "")
+	test.That(t, err, test.ShouldBeNil)
+	faceNormal := r3.Vector{math.Sqrt2 / 2, math.Sqrt2 / 2, 0}
+	sphere, err := NewSphere(NewPoseFromPoint(r3.Vector{0, 0, 10}), 10, "")
+	test.That(t, err, test.ShouldBeNil)
+	// a capsule lying along x, which is 40mm long with caps of radius 5mm
+	capsule, err := NewCapsule(NewPose(r3.Vector{}, &OrientationVectorDegrees{OX: 1}), 5, 40, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, tc := range []struct {
+		name     string
+		geometry Geometry
+		point    r3.Vector
+		expected bool
+	}{
+		{"box center", box, r3.Vector{100, 0, 0}, true},
+		{"box face", box, r3.Vector{100, 0, 0}.Add(faceNormal.Mul(10)), true},
+		{"box edge", box, r3.Vector{100, 0, 10}.Add(faceNormal.Mul(10)), true},
+		{"box corner", box, r3.Vector{100 - 10*math.Sqrt2, 0, -10}, true},
+		{"outside box face", box, r3.Vector{100, 0, 0}.Add(faceNormal.Mul(10.001)), false},
+		{"outside box along unturned axis", box, r3.Vector{110, 0, 0}.Add(r3.Vector{0, 4.2, 0}), false},
+		{"sphere center", sphere, r3.Vector{0, 0, 10}, true},
+		{"sphere surface", sphere, r3.Vector{0, 0, 20}, true},
+		{"outside sphere", sphere, r3.Vector{0, 0, 20.001}, false},
+		{"capsule center", capsule, r3.Vector{}, true},
+		{"capsule tip", capsule, r3.Vector{20, 0, 0}, true},
+		{"capsule side", capsule, r3.Vector{10, 5, 0}, true},
+		{"past capsule tip", capsule, r3.Vector{20.001, 0, 0}, false},
+		{"outside capsule side", capsule, r3.Vector{10, 0, 5.001}, false},
+		{"beside capsule cap", capsule, r3.Vector{19, 4, 0}, false},
+		{"point", NewPoint(r3.Vector{1, 2, 3}, ""), r3.Vector{1, 2, 3}, true},
+		{"other point", NewPoint(r3.Vector{1, 2, 3}, ""), r3.Vector{1, 2, 3.001}, false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			contains, err := tc.geometry.Contains(tc.point)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, contains, test.ShouldEqual, tc.expected)
+		})
+	}
+}
+
+func TestBoundingBox(t *testing.T) {
+	// a cube turned 45 degrees about z, so that its corners stick out along x and y
+	box, err := NewBox(NewPose(r3.Vector{100, 0, 0}, &OrientationVectorDegrees{OZ: 1, Theta: 45}), r3.Vector{20, 20, 20}, "")
+	test.That(t, err, test.ShouldBeNil)
+	sphere, err := NewSphere(NewPoseFromPoint(r3.Vector{0, 0, 10}), 10, "")
+	test.That(t, err, test.ShouldBeNil)
+	// a capsule lying along x, which is 40mm long with caps of radius 5mm
+	capsule, err := NewCapsule(NewPose(r3.Vector{}, &OrientationVectorDegrees{OX: 1}), 5, 40, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, tc := range []struct {
+		name     string
+		geometry Geometry
+		min      r3.Vector
+		max      r3.Vector
+	}{
+		{"box", box, r3.Vector{100 - 10*math.Sqrt2, -10 * math.Sqrt2, -10}, r3.Vector{100 + 10*math.Sqrt2, 10 * math.Sqrt2, 10}},
+		{"sphere", sphere, r3.Vector{-10, -10, 0}, r3.Vector{10, 10, 20}},
+		{"capsule", capsule, r3.Vector{-20, -5, -5}, r3.Vector{20, 5, 5}},
+		{"point", NewPoint(r3.Vector{1, 2, 3}, ""), r3.Vector{1, 2, 3}, r3.Vector{1, 2, 3}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			minPt, maxPt, err := BoundingBox(tc.geometry)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, R3VectorAlmostEqual(minPt, tc.min, 1e-6), test.ShouldBeTrue)
+			test.That(t, R3VectorAlmostEqual(maxPt, tc.max, 1e-6), test.ShouldBeTrue)
+		})
+	}
+}
+
 func TestNewGeometryFromProto(t *testing.T) {
 	malformedGeom := commonpb.Geometry{}
 	viamGeom, err := NewGeometryFromProto(&malformedGeom)
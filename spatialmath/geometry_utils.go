@@ -1,6 +1,8 @@
 package spatialmath
 
 import (
+	"math"
+
 	"github.com/golang/geo/r3"
 )
 
@@ -99,6 +101,37 @@ func BoundingSphere(geometry Geometry) (Geometry, error) {
 	return NewSphere(NewZeroPose(), r, geometry.Label())
 }
 
+// BoundingBox returns the minimum and maximum corners of the smallest axis aligned box that encloses the given geometry.
+// Two geometries whose bounding boxes do not overlap cannot be in collision, which makes this a cheap check to run before an
+// exact one.
+func BoundingBox(geometry Geometry) (r3.Vector, r3.Vector, error) {
+	var extent r3.Vector
+	center := geometry.Pose().Point()
+	switch g := geometry.(type) {
+	case *box:
+		// each world axis is spanned by the projections of the box's half sizes along its own rotated axes
+		rm := g.rotationMatrix()
+		for i := 0; i < 3; i++ {
+			extent.X += math.Abs(rm.At(i, 0)) * g.halfSize[i]
+			extent.Y += math.Abs(rm.At(i, 1)) * g.halfSize[i]
+			extent.Z += math.Abs(rm.At(i, 2)) * g.halfSize[i]
+		}
+	case *sphere:
+		extent = r3.Vector{X: g.radius, Y: g.radius, Z: g.radius}
+	case *capsule:
+		center = g.center
+		extent = r3.Vector{
+			X: math.Abs(g.capVec.X) + g.radius,
+			Y: math.Abs(g.capVec.Y) + g.radius,
+			Z: math.Abs(g.capVec.Z) + g.radius,
+		}
+	case *point:
+	default:
+		return r3.Vector{}, r3.Vector{}, errGeometryTypeUnsupported
+	}
+	return center.Sub(extent), center.Add(extent), nil
+}
+
 // closestSegmentTrianglePoints takes a line segment and a triangle, and returns the point on each closest to the other.
 func closestPointsSegmentTriangle(ap1, ap2 r3.Vector, t *triangle) (bestSegPt, bestTriPt r3.Vector) {
 	// The closest triangle point is either on the edge or within the triangle.
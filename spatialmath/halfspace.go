@@ -0,0 +1,69 @@
+package spatialmath
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+)
+
+// HalfSpace is the region of space on one side of a plane. It holds every point whose offset from a point on the plane has a
+// non-negative component along the plane's normal, so a floor at z = 0 is the half space through the origin with normal +Z.
+type HalfSpace struct {
+	point  r3.Vector
+	normal r3.Vector
+}
+
+// NewHalfSpace returns the half space bounded by the plane through point with the given normal, which points into the half space.
+func NewHalfSpace(point, normal r3.Vector) (*HalfSpace, error) {
+	norm := normal.Norm()
+	if norm == 0 {
+		return nil, errors.New("half space normal cannot be the zero vector")
+	}
+	return &HalfSpace{point: point, normal: normal.Mul(1 / norm)}, nil
+}
+
+// Point returns a point on the plane bounding the half space.
+func (hs *HalfSpace) Point() r3.Vector {
+	return hs.point
+}
+
+// Normal returns the unit normal of the plane bounding the half space, which points into the half space.
+func (hs *HalfSpace) Normal() r3.Vector {
+	return hs.normal
+}
+
+// SignedDistance returns how far the point lies from the plane bounding the half space. It is negative for points outside.
+func (hs *HalfSpace) SignedDistance(pt r3.Vector) float64 {
+	return pt.Sub(hs.point).Dot(hs.normal)
+}
+
+// Encompasses returns whether the geometry lies entirely inside the half space.
+func (hs *HalfSpace) Encompasses(g Geometry) (bool, error) {
+	depth, err := hs.penetrationDepth(g)
+	if err != nil {
+		return false, err
+	}
+	return depth <= 0, nil
+}
+
+// penetrationDepth returns how far the geometry reaches past the plane bounding the half space, or a non-positive number if it
+// lies inside.
+func (hs *HalfSpace) penetrationDepth(g Geometry) (float64, error) {
+	switch g := g.(type) {
+	case *box:
+		depth := math.Inf(-1)
+		for _, vert := range g.vertices() {
+			depth = math.Max(depth, -hs.SignedDistance(vert))
+		}
+		return depth, nil
+	case *sphere:
+		return g.radius - hs.SignedDistance(g.pose.Point()), nil
+	case *capsule:
+		return g.radius - math.Min(hs.SignedDistance(g.segA), hs.SignedDistance(g.segB)), nil
+	case *point:
+		return -hs.SignedDistance(g.position), nil
+	default:
+		return math.Inf(1), errGeometryTypeUnsupported
+	}
+}
@@ -0,0 +1,50 @@
+package spatialmath
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+)
+
+func TestHalfSpace(t *testing.T) {
+	_, err := NewHalfSpace(r3.Vector{}, r3.Vector{})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// a floor at z = 0
+	floor, err := NewHalfSpace(r3.Vector{}, r3.Vector{Z: 10})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, floor.Normal(), test.ShouldResemble, r3.Vector{Z: 1})
+	test.That(t, floor.SignedDistance(r3.Vector{X: 5, Z: 3}), test.ShouldAlmostEqual, 3)
+	test.That(t, floor.SignedDistance(r3.Vector{X: 5, Z: -3}), test.ShouldAlmostEqual, -3)
+
+	boxAbove, err := NewBox(NewPoseFromPoint(r3.Vector{Z: 10}), r3.Vector{10, 10, 10}, "")
+	test.That(t, err, test.ShouldBeNil)
+	boxAcross, err := NewBox(NewPoseFromPoint(r3.Vector{Z: 4}), r3.Vector{10, 10, 10}, "")
+	test.That(t, err, test.ShouldBeNil)
+	capsuleAbove, err := NewCapsule(NewPoseFromPoint(r3.Vector{Z: 10}), 2, 20, "")
+	test.That(t, err, test.ShouldBeNil)
+	capsuleAcross, err := NewCapsule(NewPoseFromPoint(r3.Vector{Z: 5}), 2, 20, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	for _, tc := range []struct {
+		name     string
+		geometry Geometry
+		expected bool
+	}{
+		{"box above", boxAbove, true},
+		{"box across", boxAcross, false},
+		{"sphere above", makeTestSphere(r3.Vector{Z: 5}, 5, ""), true},
+		{"sphere across", makeTestSphere(r3.Vector{Z: 4}, 5, ""), false},
+		{"capsule above", capsuleAbove, true},
+		{"capsule across", capsuleAcross, false},
+		{"point above", NewPoint(r3.Vector{Z: 1}, ""), true},
+		{"point below", NewPoint(r3.Vector{Z: -1}, ""), false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			inside, err := floor.Encompasses(tc.geometry)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, inside, test.ShouldEqual, tc.expected)
+		})
+	}
+}
@@ -163,6 +163,17 @@ func NewOrientationVectorDegrees() *OrientationVectorDegrees {
 	return &OrientationVectorDegrees{Theta: 0, OX: 0, OY: 0, OZ: 1}
 }
 
+// NewOrientationVectorDegreesFromAxis returns an orientation pointing along the axis (ox, oy, oz) and rotated theta degrees about
+// it. The axis is normalized, so it need not be of unit length, but it must not be zero.
+func NewOrientationVectorDegreesFromAxis(ox, oy, oz, theta float64) (*OrientationVectorDegrees, error) {
+	ovd := &OrientationVectorDegrees{Theta: theta, OX: ox, OY: oy, OZ: oz}
+	if err := ovd.IsValid(); err != nil {
+		return nil, err
+	}
+	ovd.Normalize()
+	return ovd, nil
+}
+
 // Radians converts a OrientationVectorDegrees to an OrientationVector.
 func (ovd *OrientationVectorDegrees) Radians() *OrientationVector {
 	return &OrientationVector{Theta: utils.DegToRad(ovd.Theta), OX: ovd.OX, OY: ovd.OY, OZ: ovd.OZ}
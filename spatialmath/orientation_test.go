@@ -57,6 +57,25 @@ func TestOrientationVectorDegrees(t *testing.T) {
 	testCompatibility(t, ovd45x)
 }
 
+func TestOrientationVectorDegreesFromAxis(t *testing.T) {
+	ovd, err := NewOrientationVectorDegreesFromAxis(0, -2, 2, 90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, ovd.Theta, test.ShouldEqual, 90)
+	test.That(t, ovd.OX, test.ShouldEqual, 0)
+	test.That(t, ovd.OY, test.ShouldAlmostEqual, -math.Sqrt(2)/2)
+	test.That(t, ovd.OZ, test.ShouldAlmostEqual, math.Sqrt(2)/2)
+	test.That(t, OrientationAlmostEqual(ovd, ov45x), test.ShouldBeTrue)
+
+	_, err = NewOrientationVectorDegreesFromAxis(0, 0, 0, 90)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// converting between degrees and radians changes only theta
+	test.That(t, ovd.Radians().Theta, test.ShouldAlmostEqual, math.Pi/2)
+	test.That(t, ovd.Radians().Degrees(), test.ShouldResemble, ovd)
+	test.That(t, ov45x.Degrees().Radians(), test.ShouldResemble, ov45x)
+	test.That(t, ov45x.Degrees().Theta, test.ShouldAlmostEqual, ovd45x.Theta)
+}
+
 func TestRotationMatrix(t *testing.T) {
 	testCompatibility(t, rm45x)
 }
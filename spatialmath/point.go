@@ -63,7 +63,7 @@ func (pt *point) Transform(toPremultiply Pose) Geometry {
 	return &point{Compose(toPremultiply, NewPoseFromPoint(pt.position)).Point(), pt.label}
 }
 
-// ToProto converts the point to a Geometry proto message.
+// ToProtobuf converts the point to a Geometry proto message.
 func (pt *point) ToProtobuf() *commonpb.Geometry {
 	return &commonpb.Geometry{
 		Center: PoseToProtobuf(NewPoseFromPoint(pt.position)),
@@ -115,6 +115,11 @@ func (pt *point) EncompassedBy(g Geometry) (bool, error) {
 	return pt.CollidesWith(g, defaultCollisionBufferMM)
 }
 
+// Contains returns whether the point is at the same position as this one.
+func (pt *point) Contains(other r3.Vector) (bool, error) {
+	return pt.position.Sub(other).Norm() <= defaultCollisionBufferMM, nil
+}
+
 // pointVsBoxCollision takes a box and a point as arguments and returns a bool describing if they are in collision. \
 // true == collision / false == no collision.
 func pointVsBoxCollision(pt r3.Vector, b *box, collisionBufferMM float64) bool {
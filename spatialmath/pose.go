@@ -73,6 +73,16 @@ func NewPoseFromOrientation(o Orientation) Pose {
 	return q
 }
 
+// NewPoseFromOrientationDegrees returns a pose at point, pointing along the axis (ox, oy, oz) and rotated theta degrees about it,
+// as an orientation vector in degrees would. This is the same as the o_x, o_y, o_z and theta fields of a protobuf pose.
+func NewPoseFromOrientationDegrees(point r3.Vector, ox, oy, oz, theta float64) (Pose, error) {
+	ovd, err := NewOrientationVectorDegreesFromAxis(ox, oy, oz, theta)
+	if err != nil {
+		return nil, err
+	}
+	return NewPose(point, ovd), nil
+}
+
 // NewPoseFromProtobuf creates a new pose from a protobuf pose.
 func NewPoseFromProtobuf(pos *commonpb.Pose) Pose {
 	return newDualQuaternionFromProtobuf(pos)
@@ -138,6 +148,14 @@ func PoseDelta(a, b Pose) Pose {
 	}
 }
 
+// PoseDistance returns how far apart two poses are, as the distance between their points in mm and the angle in radians of the
+// shortest rotation between their orientations. It can be used to pick which of several goals is nearest to a pose, accounting for
+// orientation as well as position.
+func PoseDistance(a, b Pose) (float64, float64) {
+	delta := PoseDelta(a, b)
+	return delta.Point().Norm(), QuatToR3AA(delta.Orientation().Quaternion()).Norm()
+}
+
 // PoseToProtobuf converts a pose to the pose format protobuf expects (which is as OrientationVectorDegrees).
 func PoseToProtobuf(p Pose) *commonpb.Pose {
 	final := &commonpb.Pose{}
@@ -160,7 +178,8 @@ func PoseInverse(p Pose) Pose {
 }
 
 // Interpolate will return a new Pose that has been interpolated the set amount between two poses.
-// Note that position and orientation are interpolated separately, then the two are combined.
+// Note that position and orientation are interpolated separately, then the two are combined: the position is linearly
+// interpolated, and the orientation is spherically linearly interpolated (slerp) along the shortest rotation between the two.
 // Note that slerp(q1, q2) != slerp(q2, q1)
 // p1 and p2 are the two poses to interpolate between, by is a float representing the amount to interpolate between them.
 // by == 0 will return p1, by == 1 will return p2, and by == 0.5 will return the pose halfway between them.
@@ -186,6 +205,14 @@ func PoseAlmostEqualEps(a, b Pose, epsilon float64) bool {
 	return PoseAlmostCoincidentEps(a, b, epsilon) && OrientationAlmostEqualEps(a.Orientation(), b.Orientation(), epsilon)
 }
 
+// PoseAlmostEqualTol will return a bool describing whether 2 poses are within linTol mm of each other in position, and within angTol
+// radians of each other in orientation, as measured by the angle of the rotation between them. A quaternion and its negation
+// represent the same orientation, and so are equal.
+func PoseAlmostEqualTol(a, b Pose, linTol, angTol float64) bool {
+	linear, angular := PoseDistance(a, b)
+	return linear <= linTol && angular <= angTol
+}
+
 // PoseAlmostCoincident will return a bool describing whether 2 poses approximately are at the same 3D coordinate location.
 // This uses the same epsilon as the default value for the Viam IK solver.
 func PoseAlmostCoincident(a, b Pose) bool {
@@ -62,6 +62,20 @@ func ptCompare(t *testing.T, p1, p2 r3.Vector) {
 	test.That(t, p1.Z, test.ShouldAlmostEqual, p2.Z)
 }
 
+func TestPoseFromOrientationDegrees(t *testing.T) {
+	// a tool pointing straight down, rotated a quarter turn
+	p, err := NewPoseFromOrientationDegrees(r3.Vector{1, 2, 3}, 0, 0, -1, 90)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, R3VectorAlmostEqual(p.Point(), r3.Vector{1, 2, 3}, 1e-9), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqual(p, NewPose(r3.Vector{1, 2, 3}, &OrientationVector{math.Pi / 2, 0, 0, -1})), test.ShouldBeTrue)
+	ovd := p.Orientation().OrientationVectorDegrees()
+	test.That(t, ovd.OZ, test.ShouldAlmostEqual, -1)
+	test.That(t, ovd.Theta, test.ShouldAlmostEqual, 90)
+
+	_, err = NewPoseFromOrientationDegrees(r3.Vector{}, 0, 0, 0, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestDualQuatTransform(t *testing.T) {
 	// Start with point [3, 4, 5] - Rotate by 180 degrees around x-axis and then displace by [4,2,6]
 	pt := NewPoseFromPoint(r3.Vector{3., 4., 5.}) // starting point
@@ -104,6 +118,35 @@ func TestPoseInterpolation(t *testing.T) {
 	p2 = NewPose(r3.Vector{100, 200, 200}, ov)
 	intP = Interpolate(p1, p2, 0.1)
 	ptCompare(t, intP.Point(), r3.Vector{100, 110, 200})
+
+	// orientation is slerped, so a quarter turn about Z is split evenly
+	p1 = NewPose(r3.Vector{0, 0, 0}, NewZeroOrientation())
+	p2 = NewPose(r3.Vector{10, 0, 0}, &R4AA{Theta: math.Pi / 2, RZ: 1})
+	test.That(t, PoseAlmostEqual(Interpolate(p1, p2, 0), p1), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqual(Interpolate(p1, p2, 1), p2), test.ShouldBeTrue)
+	intP = Interpolate(p1, p2, 0.5)
+	ptCompare(t, intP.Point(), r3.Vector{5, 0, 0})
+	test.That(t, OrientationAlmostEqual(intP.Orientation(), &R4AA{Theta: math.Pi / 4, RZ: 1}), test.ShouldBeTrue)
+	intP = Interpolate(p1, p2, 0.25)
+	test.That(t, OrientationAlmostEqual(intP.Orientation(), &R4AA{Theta: math.Pi / 8, RZ: 1}), test.ShouldBeTrue)
+
+	// a three quarter turn is interpolated the short way around, as a quarter turn the other way
+	p2 = NewPose(r3.Vector{}, &R4AA{Theta: 3 * math.Pi / 2, RZ: 1})
+	intP = Interpolate(p1, p2, 0.5)
+	test.That(t, OrientationAlmostEqual(intP.Orientation(), &R4AA{Theta: -math.Pi / 4, RZ: 1}), test.ShouldBeTrue)
+
+	// antipodal quaternions are the same orientation, which is kept throughout
+	q := quat.Number{Real: math.Cos(0.3), Imag: math.Sin(0.3)}
+	p1 = NewPose(r3.Vector{}, (*Quaternion)(&q))
+	p2 = NewPose(r3.Vector{}, (*Quaternion)(&quat.Number{Real: -q.Real, Imag: -q.Imag}))
+	for _, by := range []float64{0.25, 0.5, 0.9} {
+		intP = Interpolate(p1, p2, by)
+		test.That(t, OrientationAlmostEqual(intP.Orientation(), p1.Orientation()), test.ShouldBeTrue)
+	}
+	p1 = NewPose(r3.Vector{}, (*Quaternion)(&quat.Number{Real: 1}))
+	p2 = NewPose(r3.Vector{}, (*Quaternion)(&quat.Number{Real: -1}))
+	intP = Interpolate(p1, p2, 0.3)
+	test.That(t, OrientationAlmostEqual(intP.Orientation(), NewZeroOrientation()), test.ShouldBeTrue)
 }
 
 func TestLidarPose(t *testing.T) {
@@ -136,6 +179,64 @@ func TestPoseAlmostEqual(t *testing.T) {
 	test.That(t, PoseAlmostCoincident(p1, p3), test.ShouldBeFalse)
 }
 
+func TestPoseAlmostEqualTol(t *testing.T) {
+	p1 := NewPose(r3.Vector{1, 2, 3}, &R4AA{Theta: 0.5, RZ: 1})
+	p2 := NewPose(r3.Vector{1, 2.5, 3}, &R4AA{Theta: 0.6, RZ: 1})
+	test.That(t, PoseAlmostEqualTol(p1, p1, 1e-9, 1e-9), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqualTol(p1, p2, 0.6, 0.11), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqualTol(p2, p1, 0.6, 0.11), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqualTol(p1, p2, 0.4, 0.11), test.ShouldBeFalse)
+	test.That(t, PoseAlmostEqualTol(p1, p2, 0.6, 0.09), test.ShouldBeFalse)
+
+	// the angle between orientations is that of the shortest rotation between them
+	p3 := NewPose(r3.Vector{1, 2, 3}, &R4AA{Theta: -0.5, RZ: 1})
+	test.That(t, PoseAlmostEqualTol(p1, p3, 1e-9, 1.01), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqualTol(p1, p3, 1e-9, 0.99), test.ShouldBeFalse)
+	p4 := NewPose(r3.Vector{}, &R4AA{Theta: math.Pi - 0.05, RX: 1})
+	p5 := NewPose(r3.Vector{}, &R4AA{Theta: -math.Pi + 0.05, RX: 1})
+	test.That(t, PoseAlmostEqualTol(p4, p5, 1e-9, 0.11), test.ShouldBeTrue)
+
+	// a quaternion and its negation are the same orientation
+	q := quat.Number{Real: math.Cos(0.25), Kmag: math.Sin(0.25)}
+	p6 := NewPose(r3.Vector{1, 2, 3}, (*Quaternion)(&q))
+	p7 := NewPose(r3.Vector{1, 2, 3}, (*Quaternion)(&quat.Number{Real: -q.Real, Kmag: -q.Kmag}))
+	test.That(t, PoseAlmostEqualTol(p6, p7, 1e-9, 1e-9), test.ShouldBeTrue)
+	test.That(t, PoseAlmostEqualTol(p1, p7, 1e-9, 1e-9), test.ShouldBeTrue)
+}
+
+func TestPoseDistance(t *testing.T) {
+	p1 := NewPose(r3.Vector{1, 2, 3}, &R4AA{Theta: 0.5, RZ: 1})
+	p2 := NewPose(r3.Vector{4, 6, 3}, &R4AA{Theta: 0.8, RZ: 1})
+	linear, angular := PoseDistance(p1, p2)
+	test.That(t, linear, test.ShouldAlmostEqual, 5)
+	test.That(t, angular, test.ShouldAlmostEqual, 0.3)
+	linear, angular = PoseDistance(p2, p1)
+	test.That(t, linear, test.ShouldAlmostEqual, 5)
+	test.That(t, angular, test.ShouldAlmostEqual, 0.3)
+	linear, angular = PoseDistance(p1, p1)
+	test.That(t, linear, test.ShouldAlmostEqual, 0)
+	test.That(t, angular, test.ShouldAlmostEqual, 0)
+
+	// the angle is that of the shortest rotation, about any axis
+	p3 := NewPose(r3.Vector{}, &R4AA{Theta: math.Pi - 0.05, RX: 1})
+	p4 := NewPose(r3.Vector{}, &R4AA{Theta: -math.Pi + 0.05, RX: 1})
+	_, angular = PoseDistance(p3, p4)
+	test.That(t, angular, test.ShouldAlmostEqual, 0.1)
+	_, angular = PoseDistance(NewZeroPose(), NewPose(r3.Vector{}, &OrientationVectorDegrees{OX: 1}))
+	test.That(t, angular, test.ShouldAlmostEqual, math.Pi/2)
+
+	// the goal at the same point but facing the wrong way is further than one slightly offset but facing the right way
+	start := NewPose(r3.Vector{100, 0, 0}, &OrientationVectorDegrees{OZ: 1})
+	flipped := NewPose(r3.Vector{100, 0, 0}, &OrientationVectorDegrees{OZ: -1})
+	offset := NewPose(r3.Vector{105, 0, 0}, &OrientationVectorDegrees{OZ: 1})
+	linear, angular = PoseDistance(start, flipped)
+	test.That(t, linear, test.ShouldAlmostEqual, 0)
+	test.That(t, angular, test.ShouldAlmostEqual, math.Pi)
+	linear, angular = PoseDistance(start, offset)
+	test.That(t, linear, test.ShouldAlmostEqual, 5)
+	test.That(t, angular, test.ShouldAlmostEqual, 0)
+}
+
 var (
 	ov  = &OrientationVector{math.Pi / 2, 0, 0, -1}
 	p1b = NewPose(r3.Vector{1, 2, 3}, ov)
@@ -243,7 +243,7 @@ func slerp(qN1, qN2 quat.Number, by float64) quat.Number {
 	q2 := mgl64.Quat{qN2.Real, mgl64.Vec3{qN2.Imag, qN2.Jmag, qN2.Kmag}}
 
 	// check we don't have a double cover issue
-	// interpolating to the opposite hemisphere will produce unexpected results
+	// interpolating to the opposite hemisphere would take the long way around, and is undefined for antipodal quaternions
 	if oppositeHemisphere(qN1, qN2) {
 		q2 = q2.Scale(-1)
 	}
@@ -260,21 +260,10 @@ func slerp(qN1, qN2 quat.Number, by float64) quat.Number {
 	return quat.Number{q.W, q.X(), q.Y(), q.Z()}
 }
 
-// oppositeHemisphere returns true if all sings are opposite between two quats.
+// oppositeHemisphere returns true if the two quats are more than a half turn apart on the 4D unit sphere, in which case the
+// negation of one represents the same orientation while being closer to the other.
 func oppositeHemisphere(q1, q2 quat.Number) bool {
-	if math.Signbit(q1.Real) == math.Signbit(q2.Real) {
-		return false
-	}
-	if math.Signbit(q1.Imag) == math.Signbit(q2.Imag) {
-		return false
-	}
-	if math.Signbit(q1.Jmag) == math.Signbit(q2.Jmag) {
-		return false
-	}
-	if math.Signbit(q1.Kmag) == math.Signbit(q2.Kmag) {
-		return false
-	}
-	return true
+	return q1.Real*q2.Real+q1.Imag*q2.Imag+q1.Jmag*q2.Jmag+q1.Kmag*q2.Kmag < 0
 }
 
 // MarshalJSON marshals to W, X, Y, Z json.
@@ -76,7 +76,7 @@ func (s *sphere) Transform(toPremultiply Pose) Geometry {
 	return &sphere{Compose(toPremultiply, s.pose), s.radius, s.label}
 }
 
-// ToProto converts the sphere to a Geometry proto message.
+// ToProtobuf converts the sphere to a Geometry proto message.
 func (s *sphere) ToProtobuf() *commonpb.Geometry {
 	return &commonpb.Geometry{
 		Center: PoseToProtobuf(s.pose),
@@ -138,6 +138,11 @@ func (s *sphere) EncompassedBy(g Geometry) (bool, error) {
 	return true, newCollisionTypeUnsupportedError(s, g)
 }
 
+// Contains returns whether the point is inside the sphere, or on its surface.
+func (s *sphere) Contains(pt r3.Vector) (bool, error) {
+	return sphereVsPointDistance(s, pt) <= defaultCollisionBufferMM, nil
+}
+
 // sphereVsPointDistance takes a sphere and a point as arguments and returns a floating point number.  If this number is nonpositive it
 // represents the penetration depth of the point within the sphere.  If the returned float is positive it represents the separation
 // distance between the point and the sphere, which are not in collision.